@@ -17,7 +17,10 @@ func NewApp(version string, commitSHA string, engine engine.Engine) *cobra.Comma
 		NewProcessingWorkerCommand(engine),
 		NewInitCommand(),
 		NewScanCommand(engine),
+		NewLSPCommand(engine),
 		NewIgnoreCommand(),
+		NewRulesCommand(engine),
+		NewCompareCommand(),
 		NewVersionCommand(version, commitSHA),
 	)
 
@@ -41,6 +44,9 @@ Available Commands:
 	scan              Scan a directory or file
 	init              Write the default config to bearer.yml
 	ignore            Manage ignored fingerprints
+	rules             Manage rule bundles
+	compare           Compare the findings of two security reports
+	lsp               Start a language server for in-editor findings
 	version           Print the version
 
 Examples:
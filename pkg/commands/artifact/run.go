@@ -19,6 +19,8 @@ import (
 	"github.com/bearer/bearer/pkg/commands/process/gitrepository"
 	"github.com/bearer/bearer/pkg/commands/process/settings"
 	settingsloader "github.com/bearer/bearer/pkg/commands/process/settings/loader"
+	"github.com/bearer/bearer/pkg/detectors/gitleaks"
+	"github.com/bearer/bearer/pkg/detectors/gitleaks/verifier"
 	"github.com/bearer/bearer/pkg/engine"
 	"github.com/bearer/bearer/pkg/flag"
 	flagtypes "github.com/bearer/bearer/pkg/flag/types"
@@ -26,6 +28,7 @@ import (
 	reportoutput "github.com/bearer/bearer/pkg/report/output"
 	"github.com/bearer/bearer/pkg/report/output/stats"
 	outputtypes "github.com/bearer/bearer/pkg/report/output/types"
+	"github.com/bearer/bearer/pkg/report/writer"
 	scannerstats "github.com/bearer/bearer/pkg/scanner/stats"
 	"github.com/bearer/bearer/pkg/util/file"
 	"github.com/bearer/bearer/pkg/util/ignore"
@@ -59,6 +62,10 @@ type Runner interface {
 	Scan(ctx context.Context, opts flagtypes.Options) ([]files.File, *basebranchfindings.Findings, error)
 	// Report a writes a report
 	Report(files []files.File, baseBranchFindings *basebranchfindings.Findings) (bool, error)
+	// Watch re-scans changed files and reports again until interrupted
+	Watch(ctx context.Context, opts flagtypes.Options, baseBranchFindings *basebranchfindings.Findings) error
+	// Close removes the temporary files used by the scan
+	Close() error
 }
 
 type runner struct {
@@ -69,7 +76,10 @@ type runner struct {
 	scanSettings   settings.Config
 	stats          *scannerstats.Stats
 	gitContext     *gitrepository.Context
+	repository     *gitrepository.Repository
 	engine         engine.Engine
+	// scannedBaseFiles are the base branch files scanned in diff mode
+	scannedBaseFiles set.Set[string]
 }
 
 // NewRunner initializes Runner that provides scanning functionalities.
@@ -97,7 +107,7 @@ func NewRunner(
 	}
 
 	path := os.TempDir() + "/bearer" + scanID
-	completedPath := strings.Replace(path, ".jsonl", "-completed.jsonl", 1)
+	completedPath := completedReportPath(path)
 
 	r.reportPath = path
 
@@ -155,6 +165,7 @@ func (r *runner) Scan(ctx context.Context, opts flagtypes.Options) ([]files.File
 	if err != nil {
 		return nil, nil, fmt.Errorf("git repository error: %w", err)
 	}
+	r.repository = repository
 
 	fileList, err := filelist.Discover(repository, r.targetPath, r.goclocResult, r.scanSettings)
 	if err != nil {
@@ -162,13 +173,13 @@ func (r *runner) Scan(ctx context.Context, opts flagtypes.Options) ([]files.File
 	}
 
 	var baseBranchFindings *basebranchfindings.Findings
-	if err := repository.WithBaseBranch(func() error {
+	if err := repository.WithBaseBranch(fileList, func(baseTargetPath string) error {
 		if !opts.Quiet {
 			outputhandler.StdErrLog(fmt.Sprintf("\nScanning base branch %s", r.gitContext.BaseBranch))
 		}
 
-		baseBranchFindings, err = r.scanBaseBranch(fileList)
-		if err != nil {
+		baseBranchFindings = basebranchfindings.New(fileList)
+		if err := r.scanBaseBranch(fileList, baseTargetPath, baseBranchFindings); err != nil {
 			return err
 		}
 
@@ -181,28 +192,87 @@ func (r *runner) Scan(ctx context.Context, opts flagtypes.Options) ([]files.File
 		return nil, nil, err
 	}
 
-	if err := r.engine.Scan(&r.scanSettings, r.stats, r.reportPath, r.targetPath, fileList.Files); err != nil {
+	sourcePath := r.targetPath
+	if repository != nil && repository.SourcePath() != r.targetPath {
+		sourcePath = repository.SourcePath()
+		r.scanSettings.SourceDir = sourcePath
+	}
+
+	if err := r.engine.Scan(&r.scanSettings, r.stats, r.reportPath, sourcePath, fileList.Files); err != nil {
 		return nil, nil, err
 	}
 
+	if r.scanSettings.Scan.History {
+		if err := r.scanHistory(); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return fileList.Files, baseBranchFindings, nil
 }
 
-func (r *runner) scanBaseBranch(fileList *files.List) (*basebranchfindings.Findings, error) {
-	result := basebranchfindings.New(fileList)
+// scanHistory adds the secrets found in the git history to the report. The
+// history is only scanned once per run, and not for the base branch or when
+// re-scanning changed files
+func (r *runner) scanHistory() error {
+	log.Debug().Msgf("scanning git history for secrets")
+
+	reportFile, err := os.OpenFile(r.reportPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer reportFile.Close()
+
+	secretsConfig, err := gitleaks.LoadConfig(r.scanSettings.Scan.Secrets)
+	if err != nil {
+		return err
+	}
+
+	var secretVerifier verifier.Verifier
+	if r.scanSettings.Scan.Secrets.Verify {
+		secretVerifier = verifier.New(r.scanSettings.Scan.Secrets.Verifiers)
+	}
+
+	if err := gitleaks.ScanHistory(
+		r.targetPath,
+		r.scanSettings.Scan.Since,
+		secretsConfig,
+		secretVerifier,
+		&writer.Detectors{File: reportFile},
+	); err != nil {
+		return fmt.Errorf("failed to scan git history: %w", err)
+	}
+
+	return nil
+}
+
+// scanBaseBranch scans the base files of the list and adds their findings to
+// the base branch findings
+func (r *runner) scanBaseBranch(
+	fileList *files.List,
+	baseTargetPath string,
+	result *basebranchfindings.Findings,
+) error {
+	if r.scannedBaseFiles == nil {
+		r.scannedBaseFiles = set.New[string]()
+	}
+
+	for _, baseFile := range fileList.BaseFiles {
+		r.scannedBaseFiles.Add(baseFile.FilePath)
+	}
 
 	if len(fileList.BaseFiles) == 0 {
-		return result, nil
+		return nil
 	}
 
 	if err := r.engine.Scan(
 		&r.scanSettings,
 		r.stats,
 		r.reportPath+".base",
-		r.targetPath,
+		baseTargetPath,
 		fileList.BaseFiles,
 	); err != nil {
-		return nil, err
+		return err
 	}
 
 	report := types.Report{
@@ -211,9 +281,12 @@ func (r *runner) scanBaseBranch(fileList *files.List) (*basebranchfindings.Findi
 		HasFiles:    len(fileList.BaseFiles) != 0,
 	}
 
-	reportData, err := reportoutput.GetData(report, r.scanSettings, r.gitContext, nil)
+	baseSettings := r.scanSettings
+	baseSettings.SourceDir = baseTargetPath
+
+	reportData, err := reportoutput.GetData(report, baseSettings, r.gitContext, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	for _, findings := range reportData.FindingsBySeverity {
@@ -222,7 +295,7 @@ func (r *runner) scanBaseBranch(fileList *files.List) (*basebranchfindings.Findi
 		}
 	}
 
-	return result, nil
+	return nil
 }
 
 func getIgnoredFingerprints(settings settings.Config) (
@@ -310,6 +383,8 @@ func Run(ctx context.Context, opts flagtypes.Options, engine engine.Engine) (err
 		return err
 	}
 
+	// the timeout applies to the initial scan only
+	watchCtx := ctx
 	ctx, cancel := context.WithTimeout(ctx, scanSettings.Worker.Timeout)
 	defer cancel()
 
@@ -328,6 +403,7 @@ func Run(ctx context.Context, opts flagtypes.Options, engine engine.Engine) (err
 	if err != nil {
 		return err
 	}
+	defer r.Close()
 
 	files, baseBranchFindings, err := r.Scan(ctx, opts)
 	if err != nil {
@@ -339,8 +415,7 @@ func Run(ctx context.Context, opts flagtypes.Options, engine engine.Engine) (err
 		return fmt.Errorf("report error: %w", err)
 	} else {
 		reportPath := r.ReportPath()
-		if !strings.HasSuffix(reportPath, "-completed.jsonl") {
-			newPath := strings.Replace(reportPath, ".jsonl", "-completed.jsonl", 1)
+		if newPath := completedReportPath(reportPath); newPath != reportPath {
 			log.Debug().Msgf("renaming report %s -> %s", reportPath, newPath)
 			err := os.Rename(reportPath, newPath)
 			if err != nil {
@@ -353,6 +428,10 @@ func Run(ctx context.Context, opts flagtypes.Options, engine engine.Engine) (err
 		outputhandler.StdErrLog(fmt.Sprintf("=====================================\n\nProfile\n\n%s", stats.String()))
 	}
 
+	if opts.Watch {
+		return r.Watch(watchCtx, opts, baseBranchFindings)
+	}
+
 	if reportFailed {
 		if scanSettings.Scan.ExitCode == -1 {
 			return ReportFailedError(1)
@@ -452,6 +531,10 @@ func (r *runner) ReportPath() string {
 	return r.reportPath
 }
 
+func (r *runner) Close() error {
+	return r.repository.Close()
+}
+
 func anySupportedLanguagesPresent(engine engine.Engine, inputgocloc *gocloc.Result, config settings.Config) bool {
 	if inputgocloc == nil {
 		return true
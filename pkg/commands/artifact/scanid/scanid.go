@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"os"
+	"slices"
 	"sort"
 	"strings"
 
@@ -13,16 +15,24 @@ import (
 	"github.com/bearer/bearer/cmd/bearer/build"
 	"github.com/bearer/bearer/pkg/commands/process/gitrepository"
 	"github.com/bearer/bearer/pkg/commands/process/settings"
+	"github.com/bearer/bearer/pkg/flag"
+	flagtypes "github.com/bearer/bearer/pkg/flag/types"
 	"github.com/bearer/bearer/pkg/util/file"
 )
 
 func Build(scanSettings settings.Config, gitContext *gitrepository.Context) (string, error) {
 	var sha string
-	if gitContext != nil && !gitContext.HasUncommittedChanges {
+	// uncommitted changes don't matter when scanning a head commit
+	if gitContext != nil && (gitContext.HeadCommitHash != "" || !gitContext.HasUncommittedChanges) {
+		headCommitHash := gitContext.CurrentCommitHash
+		if gitContext.HeadCommitHash != "" {
+			headCommitHash = gitContext.HeadCommitHash
+		}
+
 		if gitContext.BaseCommitHash == "" {
-			sha = gitContext.CurrentCommitHash
+			sha = headCommitHash
 		} else {
-			sha = gitContext.BaseCommitHash + "_" + gitContext.CurrentCommitHash
+			sha = gitContext.BaseCommitHash + "_" + headCommitHash
 		}
 	}
 
@@ -60,6 +70,11 @@ func hashConfig(scanSettings settings.Config) (string, error) {
 
 	targetHash := md5.Sum([]byte(absTarget))
 
+	filesHash, err := hashFiles(scanSettings.Scan.Files)
+	if err != nil {
+		return "", fmt.Errorf("error building files hash: %w", err)
+	}
+
 	hashBuilder := md5.New()
 	if _, err := hashBuilder.Write(targetHash[:]); err != nil {
 		return "", err
@@ -70,6 +85,26 @@ func hashConfig(scanSettings settings.Config) (string, error) {
 	if _, err := hashBuilder.Write(scannersHash); err != nil {
 		return "", err
 	}
+	if _, err := hashBuilder.Write(filesHash); err != nil {
+		return "", err
+	}
+	// the history scan adds detections for the commits after the `since` ref
+	if scanSettings.Scan.History {
+		if _, err := hashBuilder.Write([]byte("history:" + scanSettings.Scan.Since)); err != nil {
+			return "", err
+		}
+	}
+
+	if slices.Contains(scanSettings.Scan.Scanner, flag.ScannerSecrets) {
+		secretsHash, err := hashSecrets(scanSettings.Scan.Secrets)
+		if err != nil {
+			return "", fmt.Errorf("error building secrets hash: %w", err)
+		}
+
+		if _, err := hashBuilder.Write(secretsHash); err != nil {
+			return "", err
+		}
+	}
 
 	return hex.EncodeToString(hashBuilder.Sum(nil)[:]), nil
 }
@@ -100,6 +135,32 @@ func hashRules(rules map[string]*settings.Rule) ([]byte, error) {
 	return hashBuilder.Sum(nil), nil
 }
 
+func hashSecrets(options flagtypes.SecretsOptions) ([]byte, error) {
+	hashBuilder := md5.New()
+
+	optionsContent, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := hashBuilder.Write(optionsContent); err != nil {
+		return nil, err
+	}
+
+	if options.Config != "" {
+		configContent, err := os.ReadFile(options.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := hashBuilder.Write(configContent); err != nil {
+			return nil, err
+		}
+	}
+
+	return hashBuilder.Sum(nil), nil
+}
+
 func hashScanners(scanners []string) ([]byte, error) {
 	hashBuilder := md5.New()
 
@@ -116,3 +177,20 @@ func hashScanners(scanners []string) ([]byte, error) {
 
 	return hashBuilder.Sum(nil), nil
 }
+
+func hashFiles(files []string) ([]byte, error) {
+	hashBuilder := md5.New()
+
+	sortedFiles := make([]string, len(files))
+	copy(sortedFiles, files)
+	sort.Strings(sortedFiles)
+
+	for _, file := range sortedFiles {
+		_, err := hashBuilder.Write([]byte(file))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return hashBuilder.Sum(nil), nil
+}
@@ -0,0 +1,382 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bearer/bearer/pkg/commands/process/filelist"
+	"github.com/bearer/bearer/pkg/commands/process/filelist/files"
+	filelistignore "github.com/bearer/bearer/pkg/commands/process/filelist/ignore"
+	"github.com/bearer/bearer/pkg/commands/process/watcher"
+	flagtypes "github.com/bearer/bearer/pkg/flag/types"
+	"github.com/bearer/bearer/pkg/report/basebranchfindings"
+	"github.com/bearer/bearer/pkg/report/detections"
+	"github.com/bearer/bearer/pkg/report/output/stats"
+	"github.com/bearer/bearer/pkg/util/jsonlines"
+	"github.com/bearer/bearer/pkg/util/linescanner"
+	outputhandler "github.com/bearer/bearer/pkg/util/output"
+	"github.com/bearer/bearer/pkg/util/tmpfile"
+)
+
+// Watch re-scans files as they change and writes the report again, until
+// interrupted. The engine (and so the loaded rules and worker processes) are
+// kept between scans. In diff mode, the findings from the base branch are
+// carried into each re-scan
+func (r *runner) Watch(
+	ctx context.Context,
+	opts flagtypes.Options,
+	baseBranchFindings *basebranchfindings.Findings,
+) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	report := newWatchReport()
+	if err := report.load(completedReportPath(r.reportPath)); err != nil {
+		return fmt.Errorf("failed to read report: %w", err)
+	}
+
+	fileIgnore := filelistignore.New(r.targetPath, r.scanSettings)
+	fileWatcher, err := watcher.New(r.targetPath, func(path string, info fs.FileInfo) bool {
+		return fileIgnore.Ignore(r.targetPath, path, nil, info)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch target: %w", err)
+	}
+	defer fileWatcher.Close()
+
+	r.reuseDetection = false
+	r.reportPath = tmpfile.Create(".jsonl")
+	defer os.Remove(r.reportPath)
+	defer os.Remove(r.reportPath + ".base")
+
+	deltaReportPath := tmpfile.Create(".jsonl")
+	defer os.Remove(deltaReportPath)
+
+	if !opts.Quiet {
+		outputhandler.StdErrLog(fmt.Sprintf("\nWatching %s for changes. Press Ctrl+C to stop.", opts.Target))
+	}
+
+	for {
+		changedPaths, err := fileWatcher.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		}
+
+		baseBranchFindings, err = r.rescan(ctx, opts, report, changedPaths, deltaReportPath, baseBranchFindings)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		}
+	}
+}
+
+func (r *runner) rescan(
+	ctx context.Context,
+	opts flagtypes.Options,
+	report *watchReport,
+	changedPaths []string,
+	deltaReportPath string,
+	baseBranchFindings *basebranchfindings.Findings,
+) (*basebranchfindings.Findings, error) {
+	var relativePaths []string
+	for _, path := range changedPaths {
+		if relativePath, err := filepath.Rel(r.targetPath, path); err == nil {
+			report.remove(relativePath)
+			relativePaths = append(relativePaths, relativePath)
+		}
+	}
+
+	if err := r.updateGoclocResult(opts, changedPaths); err != nil {
+		return nil, fmt.Errorf("failed to analyze changed files: %w", err)
+	}
+
+	var fileList *files.List
+	if baseBranchFindings != nil {
+		diffFileList, err := filelist.Discover(r.repository, r.targetPath, r.goclocResult, r.scanSettings)
+		if err != nil {
+			return nil, err
+		}
+
+		baseBranchFindings, err = r.updateBaseBranchFindings(baseBranchFindings, diffFileList)
+		if err != nil {
+			return nil, err
+		}
+
+		fileList = &files.List{}
+		for _, file := range diffFileList.Files {
+			if pathMatches(file.FilePath, relativePaths) {
+				fileList.Files = append(fileList.Files, file)
+			}
+		}
+	} else {
+		config := r.scanSettings
+		config.Scan.Files = changedPaths
+
+		var err error
+		fileList, err = filelist.Discover(nil, r.targetPath, r.goclocResult, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !opts.Quiet {
+		outputhandler.StdErrLog(fmt.Sprintf("\nRe-scanning %d changed file(s)", len(fileList.Files)))
+	}
+
+	if len(fileList.Files) != 0 {
+		if err := r.engine.Scan(&r.scanSettings, r.stats, deltaReportPath, r.targetPath, fileList.Files); err != nil {
+			return nil, err
+		}
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if err := report.load(deltaReportPath); err != nil {
+			return nil, fmt.Errorf("failed to read report: %w", err)
+		}
+	}
+
+	if err := report.write(r.reportPath); err != nil {
+		return nil, fmt.Errorf("failed to write report: %w", err)
+	}
+
+	// pick up any changes to the ignore file
+	var err error
+	r.scanSettings.CloudIgnoresUsed,
+		r.scanSettings.IgnoredFingerprints,
+		r.scanSettings.StaleIgnoredFingerprintIds,
+		err = getIgnoredFingerprints(r.scanSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.Report(report.files(), baseBranchFindings); err != nil {
+		return nil, fmt.Errorf("report error: %w", err)
+	}
+
+	return baseBranchFindings, nil
+}
+
+// updateGoclocResult refreshes the language statistics of the changed paths,
+// so that files in a language which was not present at the start are handled
+func (r *runner) updateGoclocResult(opts flagtypes.Options, changedPaths []string) error {
+	previousLanguageIDs := GetFoundLanguageIDs(r.engine, r.goclocResult.Languages)
+
+	if err := stats.UpdateGoclocResult(r.goclocResult, changedPaths); err != nil {
+		return err
+	}
+
+	if allowed := allowedGoclocLanguages(r.engine, opts.Language); allowed != nil {
+		filterGoclocResult(r.goclocResult, allowed)
+	}
+
+	if opts.Quiet {
+		return nil
+	}
+
+	for _, languageID := range GetFoundLanguageIDs(r.engine, r.goclocResult.Languages) {
+		if !slices.Contains(previousLanguageIDs, languageID) {
+			outputhandler.StdErrLog(fmt.Sprintf(
+				"\nFound %s files, which were not present at the start. Restart to load the rules for this language.",
+				languageID,
+			))
+		}
+	}
+
+	return nil
+}
+
+// updateBaseBranchFindings translates the base branch findings using the
+// changes since the base branch as they are now. Base files which are part of
+// the changes for the first time are scanned
+func (r *runner) updateBaseBranchFindings(
+	baseBranchFindings *basebranchfindings.Findings,
+	diffFileList *files.List,
+) (*basebranchfindings.Findings, error) {
+	result := baseBranchFindings.Update(diffFileList)
+
+	newBaseFileList := &files.List{}
+	for _, baseFile := range diffFileList.BaseFiles {
+		if !r.scannedBaseFiles.Has(baseFile.FilePath) {
+			newBaseFileList.BaseFiles = append(newBaseFileList.BaseFiles, baseFile)
+		}
+	}
+
+	if len(newBaseFileList.BaseFiles) == 0 {
+		return result, nil
+	}
+
+	if err := r.repository.WithBaseBranch(newBaseFileList, func(baseTargetPath string) error {
+		return r.scanBaseBranch(newBaseFileList, baseTargetPath, result)
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// watchReport holds the detections of the latest scan of each file, so that a
+// complete report can be written after scanning only the changed files
+type watchReport struct {
+	filenames map[string]struct{}
+	lines     map[string][][]byte
+}
+
+type watchReportLine struct {
+	Type      detections.DetectionType `json:"type"`
+	File      string                   `json:"file"`
+	Filenames []string                 `json:"filenames"`
+	CommitSHA string                   `json:"commit_sha"`
+	Source    *struct {
+		Filename string `json:"filename"`
+	} `json:"source"`
+}
+
+func newWatchReport() *watchReport {
+	return &watchReport{
+		filenames: make(map[string]struct{}),
+		lines:     make(map[string][][]byte),
+	}
+}
+
+// load adds the detections from a scan report
+func (report *watchReport) load(reportPath string) error {
+	reportFile, err := os.Open(reportPath)
+	if err != nil {
+		return err
+	}
+	defer reportFile.Close()
+
+	scanner := linescanner.New(reportFile)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var reportLine watchReportLine
+		if err := json.Unmarshal(line, &reportLine); err != nil {
+			return err
+		}
+
+		if reportLine.Type == detections.TypeFileList {
+			for _, filename := range reportLine.Filenames {
+				report.filenames[filename] = struct{}{}
+			}
+
+			continue
+		}
+
+		// detections from the git history are not re-scanned, so they are kept
+		// with the detections not associated with a file
+		filename := reportLine.File
+		if reportLine.Source != nil && reportLine.CommitSHA == "" {
+			filename = reportLine.Source.Filename
+		}
+
+		report.lines[filename] = append(report.lines[filename], bytes.Clone(line))
+	}
+
+	return scanner.Err()
+}
+
+// remove drops the detections for a path, which may be a directory
+func (report *watchReport) remove(relativePath string) {
+	paths := []string{relativePath}
+
+	for filename := range report.filenames {
+		if pathMatches(filename, paths) {
+			delete(report.filenames, filename)
+		}
+	}
+
+	for filename := range report.lines {
+		if filename != "" && pathMatches(filename, paths) {
+			delete(report.lines, filename)
+		}
+	}
+}
+
+func (report *watchReport) files() []files.File {
+	filenames := report.sortedFilenames()
+
+	result := make([]files.File, len(filenames))
+	for i, filename := range filenames {
+		result[i] = files.File{FilePath: filename}
+	}
+
+	return result
+}
+
+func (report *watchReport) write(reportPath string) error {
+	reportFile, err := os.Create(reportPath)
+	if err != nil {
+		return err
+	}
+	defer reportFile.Close()
+
+	filenames := report.sortedFilenames()
+
+	// detections not associated with a file are kept as is
+	for _, filename := range append([]string{""}, filenames...) {
+		for _, line := range report.lines[filename] {
+			if _, err := reportFile.Write(append(line, '\n')); err != nil {
+				return err
+			}
+		}
+	}
+
+	fileList := []detections.FileListDetection{{
+		Type:      detections.TypeFileList,
+		Filenames: filenames,
+	}}
+
+	return jsonlines.Encode(reportFile, &fileList)
+}
+
+func (report *watchReport) sortedFilenames() []string {
+	filenames := make([]string, 0, len(report.filenames))
+	for filename := range report.filenames {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	return filenames
+}
+
+// pathMatches returns whether a filename is one of the paths, or is inside one
+// of them
+func pathMatches(filename string, paths []string) bool {
+	for _, path := range paths {
+		if filename == path || strings.HasPrefix(filename, path+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func completedReportPath(reportPath string) string {
+	if strings.HasSuffix(reportPath, "-completed.jsonl") {
+		return reportPath
+	}
+
+	return strings.Replace(reportPath, ".jsonl", "-completed.jsonl", 1)
+}
@@ -0,0 +1,48 @@
+package artifact
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchReport(t *testing.T) {
+	dir := t.TempDir()
+	initialPath := filepath.Join(dir, "initial.jsonl")
+	deltaPath := filepath.Join(dir, "delta.jsonl")
+	mergedPath := filepath.Join(dir, "merged.jsonl")
+
+	require.NoError(t, os.WriteFile(initialPath, []byte(
+		`{"type":"custom_risk","source":{"filename":"app/a.rb"}}
+{"type":"custom_risk","source":{"filename":"app/b.rb"}}
+{"type":"file_error","file":"lib/c.rb"}
+{"type":"secret_leak","commit_sha":"abc123","source":{"filename":"app/b.rb"}}
+{"type":"file_list","filenames":["app/a.rb","app/b.rb","lib/c.rb"]}
+`), 0600))
+
+	require.NoError(t, os.WriteFile(deltaPath, []byte(
+		`{"type":"custom_classified","source":{"filename":"app/b.rb"}}
+{"type":"file_list","filenames":["app/b.rb","app/d.rb"]}
+`), 0600))
+
+	report := newWatchReport()
+	require.NoError(t, report.load(initialPath))
+
+	report.remove("app/b.rb")
+	report.remove("lib")
+	require.NoError(t, report.load(deltaPath))
+	require.NoError(t, report.write(mergedPath))
+
+	content, err := os.ReadFile(mergedPath)
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"secret_leak","commit_sha":"abc123","source":{"filename":"app/b.rb"}}
+{"type":"custom_risk","source":{"filename":"app/a.rb"}}
+{"type":"custom_classified","source":{"filename":"app/b.rb"}}
+{"type":"file_list","filenames":["app/a.rb","app/b.rb","app/d.rb"]}
+`, string(content))
+
+	assert.Len(t, report.files(), 3)
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bearer/bearer/pkg/flag"
+	"github.com/bearer/bearer/pkg/report/output/security"
+)
+
+func NewCompareCommand() *cobra.Command {
+	var CompareFlags = flag.Flags{
+		flag.GeneralFlagGroup,
+		flag.CompareFlagGroup,
+	}
+	cmd := &cobra.Command{
+		Use:   "compare <previous-report> <current-report>",
+		Short: "Compare the findings of two security reports",
+		Example: `# Show the new, fixed and unchanged findings between two scans
+$ bearer scan . --format json --output old.json
+$ bearer scan . --format json --output new.json
+$ bearer compare old.json new.json
+
+# Write the comparison to an HTML file
+$ bearer compare old.json new.json --format html --output comparison.html`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := CompareFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			setLogLevel(cmd)
+
+			options, err := CompareFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			if len(args) != 2 {
+				return cmd.Help()
+			}
+
+			cmd.SilenceUsage = true
+
+			previousFindings, err := security.LoadFindings(args[0])
+			if err != nil {
+				return err
+			}
+
+			currentFindings, err := security.LoadFindings(args[1])
+			if err != nil {
+				return err
+			}
+
+			output, err := security.FormatComparison(
+				security.CompareFindings(previousFindings, currentFindings),
+				options.CompareOptions.OutputFormat,
+				options.GeneralOptions.NoColor || options.CompareOptions.OutputPath != "",
+			)
+			if err != nil {
+				return err
+			}
+
+			if options.CompareOptions.OutputPath != "" {
+				if err := os.WriteFile(options.CompareOptions.OutputPath, []byte(output), 0644); err != nil {
+					return fmt.Errorf("error writing output file %w", err)
+				}
+
+				return nil
+			}
+
+			cmd.Print(output + "\n")
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	CompareFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, CompareFlags.Usages(cmd)))
+
+	return cmd
+}
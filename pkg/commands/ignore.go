@@ -2,7 +2,6 @@ package commands
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
@@ -151,7 +150,10 @@ func newIgnoreAddCommand() *cobra.Command {
 		Use:   "add <fingerprint>",
 		Short: "Add an ignored fingerprint",
 		Example: `# Add an ignored fingerprint to your ignore file
-$ bearer ignore add <fingerprint> --author Mish --comment "Possible false positive"`,
+$ bearer ignore add <fingerprint> --author Mish --comment "Possible false positive"
+
+# Record the team which accepted the risk
+$ bearer ignore add <fingerprint> --owner @acme/payments --comment "Accepted risk"`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := IgnoreShowFlags.Bind(cmd); err != nil {
 				return fmt.Errorf("flag bind error: %w", err)
@@ -220,6 +222,10 @@ $ bearer ignore add <fingerprint> --author Mish --comment "Possible false positi
 				cmd.Printf("\n")
 			}
 
+			if options.IgnoreAddOptions.Owner != "" {
+				fingerprintEntry.Owner = &options.IgnoreAddOptions.Owner
+			}
+
 			// update entry to include additional information
 			ignoredFingerprints[fingerprintId] = fingerprintEntry
 
@@ -227,7 +233,7 @@ $ bearer ignore add <fingerprint> --author Mish --comment "Possible false positi
 				cmd.Printf("\nCreating ignore file...\n")
 			}
 
-			if err := writeIgnoreFile(ignoredFingerprints, ignoreFilepath); err != nil {
+			if err := ignore.WriteIgnoreFile(ignoredFingerprints, ignoreFilepath); err != nil {
 				return err
 			}
 
@@ -289,7 +295,7 @@ $ bearer ignore remove <fingerprint>`,
 			}
 
 			delete(ignoredFingerprints, fingerprintId)
-			if err := writeIgnoreFile(ignoredFingerprints, ignoreFilepath); err != nil {
+			if err := ignore.WriteIgnoreFile(ignoredFingerprints, ignoreFilepath); err != nil {
 				return err
 			}
 
@@ -372,7 +378,7 @@ $ bearer ignore migrate`,
 
 			// either no duplicate entries at this point or --force is true so we can ignore merge error
 			_ = ignore.MergeIgnoredFingerprints(fingerprintsToMigrate, ignoredFingerprints, options.IgnoreMigrateOptions.Force)
-			return writeIgnoreFile(ignoredFingerprints, ignoreFilepath)
+			return ignore.WriteIgnoreFile(ignoredFingerprints, ignoreFilepath)
 		},
 		SilenceErrors: false,
 		SilenceUsage:  false,
@@ -394,16 +400,6 @@ func setLogLevel(cmd *cobra.Command) {
 	})
 }
 
-func writeIgnoreFile(ignoredFingerprints map[string]ignoretypes.IgnoredFingerprint, ignoreFilePath string) error {
-	data, err := json.MarshalIndent(ignoredFingerprints, "", "  ")
-	if err != nil {
-		// failed to marshall data
-		return err
-	}
-
-	return os.WriteFile(ignoreFilePath, data, 0644)
-}
-
 func getIgnoredFingerprintsFromConfig(configPath string) (ignoredFingerprintsFromConfig map[string]ignoretypes.IgnoredFingerprint) {
 	ignoredFingerprintsFromConfig = make(map[string]ignoretypes.IgnoredFingerprint)
 
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/bearer/bearer/pkg/engine"
+	"github.com/bearer/bearer/pkg/flag"
+	"github.com/bearer/bearer/pkg/lsp"
+	"github.com/bearer/bearer/pkg/util/output"
+)
+
+var LSPFlags = flag.Flags{
+	flag.RuleFlagGroup,
+	flag.ScanFlagGroup,
+	flag.GeneralFlagGroup,
+}
+
+func NewLSPCommand(engine engine.Engine) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lsp [flags]",
+		Short: "Start a language server for in-editor findings",
+		Example: `  # Start the language server, communicating over stdio
+  $ bearer lsp`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := LSPFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// stdout carries the protocol, so anything else written to it
+			// (including by worker processes) is redirected to stderr
+			protocolWriter := os.Stdout
+			os.Stdout = os.Stderr
+			cmd.SetOut(os.Stderr)
+
+			logLevel := viper.GetString(flag.LogLevelFlag.ConfigName)
+			if viper.GetBool(flag.DebugFlag.ConfigName) {
+				logLevel = flag.DebugLogLevel
+			}
+
+			output.Setup(cmd, output.SetupRequest{
+				LogLevel:  logLevel,
+				Quiet:     true,
+				ProcessID: "main",
+			})
+
+			_, loadFileMessage, _ := readConfig(args)
+			log.Debug().Msgf("%s", loadFileMessage)
+
+			options, err := LSPFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %w", err)
+			}
+
+			cmd.SilenceUsage = true
+
+			server := lsp.NewServer(cmd.InOrStdin(), protocolWriter, func(rootPath string) (lsp.DocumentScanner, error) {
+				options.Target = rootPath
+
+				scanner, err := lsp.NewScanner(cmd.Context(), options, engine)
+				if err != nil {
+					return nil, err
+				}
+
+				return scanner, nil
+			})
+
+			return server.Run()
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+
+	LSPFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, LSPFlags.Usages(cmd)))
+
+	return cmd
+}
@@ -1,7 +1,10 @@
 package filelist
 
 import (
+	"bytes"
+	"io"
 	"io/fs"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -13,12 +16,17 @@ import (
 	"github.com/bearer/bearer/pkg/commands/process/filelist/timeout"
 	"github.com/bearer/bearer/pkg/commands/process/gitrepository"
 	"github.com/bearer/bearer/pkg/commands/process/settings"
+	"github.com/bearer/bearer/pkg/git"
 )
 
 // Discover searches directory for files to scan, skipping the ones specified by skip config and assigning timeout speficfied by timeout config
 func Discover(repository *gitrepository.Repository, targetPath string, goclocResult *gocloc.Result, config settings.Config) (*flfiles.List, error) {
 	ignore := ignore.New(targetPath, config)
 
+	if IsExplicit(config) {
+		return discoverExplicit(ignore, targetPath, goclocResult, config)
+	}
+
 	if !config.IgnoreGit {
 		fileList, err := repository.ListFiles(ignore, goclocResult)
 		if err != nil {
@@ -34,8 +42,156 @@ func Discover(repository *gitrepository.Repository, targetPath string, goclocRes
 		log.Debug().Msg("No files found from Git")
 	}
 
+	files, err := walk(ignore, targetPath, targetPath, goclocResult, config)
+
+	return &flfiles.List{Files: files}, err
+}
+
+// IsExplicit returns true when the files to scan were given explicitly (as
+// arguments or via --files-from) rather than discovered from the target
+func IsExplicit(config settings.Config) bool {
+	return config.Scan.FilesFrom != "" || len(config.Scan.Files) != 0
+}
+
+// ReadPaths parses a list of paths separated by either NUL characters or
+// newlines, as produced by `git diff --name-only [-z]` and similar tools
+func ReadPaths(reader io.Reader) ([]string, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	separator := []byte("\n")
+	if bytes.IndexByte(content, 0) != -1 {
+		separator = []byte{0}
+	}
+
+	var paths []string
+	for _, entry := range bytes.Split(content, separator) {
+		path := strings.TrimSuffix(string(entry), "\r")
+		if strings.TrimSpace(path) == "" {
+			continue
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// ResolvePath returns the absolute path for a file given on the command line.
+// The parent directory is resolved in the same way as the target path, so
+// that the file can be made relative to it. The file itself may not exist
+func ResolvePath(path string) (string, error) {
+	fullPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	if resolvedDir, err := filepath.EvalSymlinks(filepath.Dir(fullPath)); err == nil {
+		return filepath.Join(resolvedDir, filepath.Base(fullPath)), nil
+	}
+
+	return fullPath, nil
+}
+
+// discoverExplicit applies the same skip rules as a directory walk to an
+// explicit list of files. Paths are resolved against the working directory and
+// reported relative to the target path. Files excluded by .gitignore are
+// skipped, as they are when listing the files from git
+func discoverExplicit(
+	ignore *ignore.FileIgnore,
+	targetPath string,
+	goclocResult *gocloc.Result,
+	config settings.Config,
+) (*flfiles.List, error) {
 	var files []flfiles.File
-	err := filepath.WalkDir(targetPath, func(filePath string, d fs.DirEntry, err error) error {
+	seen := make(map[string]struct{})
+
+	for _, path := range config.Scan.Files {
+		fullPath, err := ResolvePath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		relativePath, err := filepath.Rel(targetPath, fullPath)
+		if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, "../") {
+			log.Debug().Msgf("skipping file outside of target: %s", path)
+			continue
+		}
+
+		fileInfo, err := os.Lstat(fullPath)
+		if err != nil {
+			// eg. a file deleted in the changes being committed
+			log.Debug().Msgf("skipping due to info error %s: %s", path, err)
+			continue
+		}
+
+		var pathFiles []flfiles.File
+		if fileInfo.IsDir() {
+			pathFiles, err = walk(ignore, targetPath, fullPath, goclocResult, config)
+			if err != nil {
+				return nil, err
+			}
+		} else if ignore.Ignore(targetPath, fullPath, goclocResult, fileInfo) {
+			log.Debug().Msgf("skipping file due to file skip rules: %s", relativePath)
+		} else {
+			pathFiles = []flfiles.File{{
+				FilePath: relativePath,
+				Timeout:  timeout.Assign(fileInfo, config),
+			}}
+		}
+
+		for _, file := range pathFiles {
+			if _, exists := seen[file.FilePath]; exists {
+				continue
+			}
+
+			seen[file.FilePath] = struct{}{}
+			files = append(files, file)
+		}
+	}
+
+	if config.IgnoreGit || config.Scan.SkipGitIgnore {
+		return &flfiles.List{Files: files}, nil
+	}
+
+	return withoutGitIgnored(targetPath, files)
+}
+
+func withoutGitIgnored(targetPath string, files []flfiles.File) (*flfiles.List, error) {
+	filenames := make([]string, len(files))
+	for i, file := range files {
+		filenames[i] = file.FilePath
+	}
+
+	ignoredFilenames, err := git.IgnoredFiles(targetPath, filenames)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []flfiles.File
+	for _, file := range files {
+		if ignoredFilenames.Has(file.FilePath) {
+			log.Debug().Msgf("skipping file due to .gitignore: %s", file.FilePath)
+			continue
+		}
+
+		result = append(result, file)
+	}
+
+	return &flfiles.List{Files: result}, nil
+}
+
+func walk(
+	ignore *ignore.FileIgnore,
+	targetPath string,
+	rootPath string,
+	goclocResult *gocloc.Result,
+	config settings.Config,
+) ([]flfiles.File, error) {
+	var files []flfiles.File
+	err := filepath.WalkDir(rootPath, func(filePath string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -73,5 +229,5 @@ func Discover(repository *gitrepository.Repository, targetPath string, goclocRes
 		return nil
 	})
 
-	return &flfiles.List{Files: files}, err
+	return files, err
 }
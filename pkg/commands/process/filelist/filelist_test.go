@@ -2,7 +2,9 @@ package filelist_test
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/bearer/bearer/pkg/commands/process/filelist"
@@ -226,3 +228,127 @@ func TestDiscoverLanguageFilter(t *testing.T) {
 		assert.ElementsMatch(t, []string{"a.rb"}, filePaths(output))
 	})
 }
+
+func TestDiscoverExplicitFiles(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to resolve temp dir: %s", err)
+	}
+
+	for name, content := range map[string]string{
+		"app/user.rb":       "puts 1\n",
+		"app/admin.rb":      "puts 2\n",
+		"spec/user_spec.rb": "puts 3\n",
+		"vendor/lib.rb":     "puts 4\n",
+	} {
+		fullPath := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o700); err != nil {
+			t.Fatalf("failed to create directory for %s: %s", name, err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %s", name, err)
+		}
+	}
+
+	config := settings.Config{
+		Scan: flagtypes.ScanOptions{
+			SkipPath: []string{"vendor"},
+			Files: []string{
+				filepath.Join(dir, "app/user.rb"),
+				filepath.Join(dir, "app/user.rb"),
+				filepath.Join(dir, "vendor/lib.rb"),
+				filepath.Join(dir, "deleted.rb"),
+				filepath.Join(filepath.Dir(dir), "outside.rb"),
+				filepath.Join(dir, "spec"),
+			},
+		},
+		Worker: settings.WorkerOptions{
+			FileSizeMaximum:           100000,
+			TimeoutFileBytesPerSecond: 1,
+		},
+	}
+
+	output, err := filelist.Discover(nil, dir, &gocloc.Result{}, config)
+	assert.NoError(t, err)
+	assert.Equal(t, &files.List{
+		Files: []files.File{
+			{FilePath: "app/user.rb"},
+			{FilePath: "spec/user_spec.rb"},
+		},
+	}, output)
+}
+
+func TestDiscoverExplicitFilesGitIgnore(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to resolve temp dir: %s", err)
+	}
+
+	for name, content := range map[string]string{
+		".gitignore":       "tmp/\n*.log.rb\n",
+		"app/user.rb":      "puts 1\n",
+		"app/debug.log.rb": "puts 2\n",
+		"tmp/cache.rb":     "puts 3\n",
+	} {
+		fullPath := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o700); err != nil {
+			t.Fatalf("failed to create directory for %s: %s", name, err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %s", name, err)
+		}
+	}
+
+	command := exec.Command("git", "init", ".")
+	command.Dir = dir
+	if output, err := command.CombinedOutput(); err != nil {
+		t.Fatalf("failed to init git repository: %s\n%s", err, output)
+	}
+
+	config := settings.Config{
+		Scan: flagtypes.ScanOptions{
+			Files: []string{
+				filepath.Join(dir, "app"),
+				filepath.Join(dir, "tmp/cache.rb"),
+			},
+		},
+		Worker: settings.WorkerOptions{
+			FileSizeMaximum:           100000,
+			TimeoutFileBytesPerSecond: 1,
+		},
+	}
+
+	output, err := filelist.Discover(nil, dir, &gocloc.Result{}, config)
+	assert.NoError(t, err)
+	assert.Equal(t, &files.List{
+		Files: []files.File{
+			{FilePath: "app/user.rb"},
+		},
+	}, output)
+
+	config.Scan.SkipGitIgnore = true
+
+	output, err = filelist.Discover(nil, dir, &gocloc.Result{}, config)
+	assert.NoError(t, err)
+	assert.Equal(t, &files.List{
+		Files: []files.File{
+			{FilePath: "app/debug.log.rb"},
+			{FilePath: "app/user.rb"},
+			{FilePath: "tmp/cache.rb"},
+		},
+	}, output)
+}
+
+func TestReadPaths(t *testing.T) {
+	t.Run("newline separated", func(t *testing.T) {
+		paths, err := filelist.ReadPaths(strings.NewReader("a.rb\r\nb c.rb\n\nd.rb"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.rb", "b c.rb", "d.rb"}, paths)
+	})
+
+	t.Run("NUL separated", func(t *testing.T) {
+		paths, err := filelist.ReadPaths(strings.NewReader("a.rb\x00b\nc.rb\x00"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.rb", "b\nc.rb"}, paths)
+	})
+}
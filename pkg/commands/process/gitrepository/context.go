@@ -25,7 +25,8 @@ type Context struct {
 	BaseBranch string
 	CommitHash,
 	CurrentCommitHash,
-	BaseCommitHash string
+	BaseCommitHash,
+	HeadCommitHash string
 	OriginURL string
 	ID,
 	Host,
@@ -65,7 +66,17 @@ func NewContext(options *flagtypes.Options) (*Context, error) {
 		return nil, fmt.Errorf("error getting current commit hash: %w", err)
 	}
 
-	baseCommitHash, err := getBaseCommitHash(options, rootDir, baseBranch, currentCommitHash)
+	headCommitHash, err := getHeadCommitHash(options, rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("error getting head commit hash: %w", err)
+	}
+
+	scannedCommitHash := currentCommitHash
+	if headCommitHash != "" {
+		scannedCommitHash = headCommitHash
+	}
+
+	baseCommitHash, err := getBaseCommitHash(options, rootDir, baseBranch, scannedCommitHash)
 	if err != nil {
 		return nil, fmt.Errorf("error getting base commit hash: %w", err)
 	}
@@ -91,9 +102,10 @@ func NewContext(options *flagtypes.Options) (*Context, error) {
 		CurrentBranch:         currentBranch,
 		DefaultBranch:         defaultBranch,
 		BaseBranch:            baseBranch,
-		CommitHash:            getCommitHash(options, currentCommitHash),
+		CommitHash:            getCommitHash(options, scannedCommitHash),
 		CurrentCommitHash:     currentCommitHash,
 		BaseCommitHash:        baseCommitHash,
+		HeadCommitHash:        headCommitHash,
 		OriginURL:             originURL,
 		ID:                    id,
 		Host:                  host,
@@ -159,6 +171,10 @@ func getBaseBranch(options *flagtypes.Options, defaultBranch string) (string, er
 		return options.DiffBaseBranch, nil
 	}
 
+	if options.DiffBaseCommit != "" {
+		return options.DiffBaseCommit, nil
+	}
+
 	if defaultBranch != "" {
 		log.Debug().Msgf("using default branch %s for diff base branch", defaultBranch)
 		return defaultBranch, nil
@@ -178,11 +194,24 @@ func getCommitHash(options *flagtypes.Options, currentCommitHash string) string
 	return currentCommitHash
 }
 
+func getHeadCommitHash(options *flagtypes.Options, rootDir string) (string, error) {
+	if options.DiffHeadCommit == "" {
+		return "", nil
+	}
+
+	hash, err := git.ResolveCommit(rootDir, options.DiffHeadCommit)
+	if err != nil {
+		return "", fmt.Errorf("invalid ref: %w", err)
+	}
+
+	return hash, nil
+}
+
 func getBaseCommitHash(
 	options *flagtypes.Options,
 	rootDir string,
 	baseBranch string,
-	currentCommitHash string,
+	headCommitHash string,
 ) (string, error) {
 	if baseBranch == "" {
 		return "", nil
@@ -192,12 +221,12 @@ func getBaseCommitHash(
 		return options.DiffBaseCommit, nil
 	}
 
-	if hash, err := lookupBaseCommitHashFromGithub(options, baseBranch, currentCommitHash); hash != "" || err != nil {
+	if hash, err := lookupBaseCommitHashFromGithub(options, baseBranch, headCommitHash); hash != "" || err != nil {
 		return hash, err
 	}
 
 	log.Debug().Msg("finding merge base using local repository")
-	hash, err := git.GetMergeBase(rootDir, "origin/"+baseBranch, currentCommitHash)
+	hash, err := git.GetMergeBase(rootDir, "origin/"+baseBranch, headCommitHash)
 	if err != nil {
 		if !strings.Contains(err.Error(), "Not a valid object name") {
 			return "", fmt.Errorf("invalid ref: %w", err)
@@ -209,7 +238,7 @@ func getBaseCommitHash(
 	}
 
 	log.Debug().Msg("remote ref not found, trying local ref")
-	hash, err = git.GetMergeBase(rootDir, baseBranch, currentCommitHash)
+	hash, err = git.GetMergeBase(rootDir, baseBranch, headCommitHash)
 	if err != nil {
 		return "", fmt.Errorf("invalid ref: %w", err)
 	}
@@ -2,8 +2,8 @@ package gitrepository
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,7 +16,6 @@ import (
 	"github.com/bearer/bearer/pkg/commands/process/filelist/timeout"
 	"github.com/bearer/bearer/pkg/commands/process/settings"
 	"github.com/bearer/bearer/pkg/git"
-	"github.com/bearer/bearer/pkg/util/output"
 )
 
 type Repository struct {
@@ -25,6 +24,10 @@ type Repository struct {
 	targetPath,
 	gitTargetPath string
 	context *Context
+	// sourcePath is the target within the snapshot of the head commit, or the
+	// target itself when scanning the working tree
+	sourcePath  string
+	snapshotDir string
 }
 
 func New(ctx context.Context, config settings.Config, targetPath string, context *Context) (*Repository, error) {
@@ -46,6 +49,7 @@ func New(ctx context.Context, config settings.Config, targetPath string, context
 		targetPath:    targetPath,
 		gitTargetPath: gitTargetPath,
 		context:       context,
+		sourcePath:    targetPath,
 	}
 
 	if err = repository.fetchMergeBaseCommit(); err != nil {
@@ -129,11 +133,21 @@ func (repository *Repository) getDiffFiles(
 	renames := make(map[string]string)
 	chunks := make(map[string]git.Chunks)
 
-	filePatches, err := git.Diff(repository.context.RootDir, repository.context.BaseCommitHash)
+	filePatches, err := git.Diff(
+		repository.context.RootDir,
+		repository.context.BaseCommitHash,
+		repository.context.HeadCommitHash,
+	)
 	if err != nil {
 		return nil, err
 	}
 
+	if repository.context.HeadCommitHash != "" {
+		if err := repository.snapshotHead(filePatches); err != nil {
+			return nil, err
+		}
+	}
+
 	for _, patch := range filePatches {
 		// we're not interested in removals
 		if patch.ToPath == "" {
@@ -175,39 +189,109 @@ func (repository *Repository) getDiffFiles(
 	}, nil
 }
 
-func (repository *Repository) WithBaseBranch(body func() error) error {
+// SourcePath returns the path the files of the target are read from. This is
+// a snapshot of the head commit when one is given, otherwise the target
+func (repository *Repository) SourcePath() string {
+	return repository.sourcePath
+}
+
+// Close removes the snapshot of the head commit
+func (repository *Repository) Close() error {
+	if repository == nil || repository.snapshotDir == "" {
+		return nil
+	}
+
+	return os.RemoveAll(repository.snapshotDir)
+}
+
+// WithBaseBranch calls body with a path containing the base files as they are
+// in the base commit. The files are read from git, so the working tree is left
+// untouched
+func (repository *Repository) WithBaseBranch(fileList *files.List, body func(baseTargetPath string) error) error {
 	if repository == nil || !repository.config.Scan.Diff {
 		return nil
 	}
 
-	if repository.context.HasUncommittedChanges {
-		return errors.New("uncommitted changes found in your repository. commit or stash changes your changes and retry")
+	gitPaths := make([]string, len(fileList.BaseFiles))
+	for i, baseFile := range fileList.BaseFiles {
+		gitPaths[i] = filepath.ToSlash(filepath.Join(repository.gitTargetPath, baseFile.FilePath))
 	}
 
-	if err := git.Switch(repository.context.RootDir, repository.context.BaseCommitHash, true); err != nil {
-		return fmt.Errorf("error switching to base branch: %w", err)
+	snapshotDir, err := repository.snapshot(repository.context.BaseCommitHash, gitPaths)
+	if err != nil {
+		return fmt.Errorf("error reading base branch files: %w", err)
 	}
+	defer func() {
+		if err := os.RemoveAll(snapshotDir); err != nil {
+			log.Debug().Msgf("failed to remove base branch snapshot %s: %s", snapshotDir, err)
+		}
+	}()
 
-	err := body()
+	return body(filepath.Join(snapshotDir, repository.gitTargetPath))
+}
 
-	if restoreErr := repository.restoreCurrent(); restoreErr != nil {
-		wrappedErr := fmt.Errorf("error restoring to current commit: %w", restoreErr)
-		if err == nil {
-			return wrappedErr
+func (repository *Repository) snapshotHead(filePatches []git.FilePatch) error {
+	var gitPaths []string
+	for _, patch := range filePatches {
+		if patch.ToPath != "" {
+			gitPaths = append(gitPaths, patch.ToPath)
 		}
+	}
 
-		output.StdErrLog(wrappedErr.Error())
+	snapshotDir, err := repository.snapshot(repository.context.HeadCommitHash, gitPaths)
+	if err != nil {
+		return fmt.Errorf("error reading head commit files: %w", err)
 	}
 
-	return err
+	repository.snapshotDir = snapshotDir
+	repository.sourcePath = filepath.Join(snapshotDir, repository.gitTargetPath)
+
+	return nil
 }
 
-func (repository *Repository) restoreCurrent() error {
-	if repository.context.CurrentBranch == "" {
-		return git.Switch(repository.context.RootDir, repository.context.CurrentCommitHash, true)
+// snapshot writes the files at the given paths, relative to the root of the
+// repository, as they are in a commit to a new temporary directory
+func (repository *Repository) snapshot(commitHash string, gitPaths []string) (string, error) {
+	snapshotDir, err := os.MkdirTemp("", "bearer-snapshot-")
+	if err != nil {
+		return "", err
+	}
+
+	for _, gitPath := range gitPaths {
+		if err := repository.snapshotFile(snapshotDir, commitHash, gitPath); err != nil {
+			_ = os.RemoveAll(snapshotDir)
+			return "", err
+		}
+	}
+
+	return snapshotDir, nil
+}
+
+func (repository *Repository) snapshotFile(snapshotDir, commitHash, gitPath string) error {
+	path := filepath.Join(snapshotDir, filepath.FromSlash(gitPath))
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = git.ShowFile(repository.context.RootDir, commitHash, gitPath, func(reader io.Reader) error {
+		_, err := io.Copy(file, reader)
+		return err
+	})
+	closeErr := file.Close()
+
+	if err != nil {
+		// eg. submodules have no content in the repository. the file is left out
+		// of the snapshot, so it is skipped by the scan
+		log.Debug().Msgf("failed to read %s at %s: %s", gitPath, commitHash, err)
+		return os.Remove(path)
 	}
 
-	return git.Switch(repository.context.RootDir, repository.context.CurrentBranch, false)
+	return closeErr
 }
 
 func (repository *Repository) fileFor(
@@ -225,14 +309,14 @@ func (repository *Repository) fileFor(
 		return nil
 	}
 
-	fullPath := filepath.Join(repository.targetPath, relativePath)
+	fullPath := filepath.Join(repository.sourcePath, relativePath)
 	fileInfo, err := os.Stat(fullPath)
 	if err != nil {
 		log.Debug().Msgf("error getting file stat: %s, %s", fullPath, err)
 		return nil
 	}
 
-	if ignore != nil && ignore.Ignore(repository.targetPath, fullPath, goclocResult, fileInfo) {
+	if ignore != nil && ignore.Ignore(repository.sourcePath, fullPath, goclocResult, fileInfo) {
 		return nil
 	}
 
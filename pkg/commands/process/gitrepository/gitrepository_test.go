@@ -0,0 +1,145 @@
+package gitrepository_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bearer/bearer/pkg/commands/process/filelist/files"
+	"github.com/bearer/bearer/pkg/commands/process/gitrepository"
+	"github.com/bearer/bearer/pkg/commands/process/settings"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	command := exec.Command("git", append([]string{
+		"-c", "user.name=Bearer CI",
+		"-c", "user.email=ci@bearer.com",
+		"-c", "commit.gpgSign=false",
+	}, args...)...)
+	command.Dir = dir
+
+	output, err := command.CombinedOutput()
+	require.NoError(t, err, "git %s: %s", strings.Join(args, " "), output)
+
+	return strings.TrimSpace(string(output))
+}
+
+func commitFile(t *testing.T, dir, filename, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, filename)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "--message=update "+filename)
+
+	return runGit(t, dir, "rev-parse", "HEAD")
+}
+
+func newConfig() settings.Config {
+	config := settings.Config{}
+	config.Scan.Diff = true
+	config.Worker.TimeoutFileBytesPerSecond = 1
+
+	return config
+}
+
+func setupRepository(t *testing.T) (rootDir, baseCommitHash, headCommitHash string) {
+	t.Helper()
+
+	rootDir, err := file.CanonicalPath(t.TempDir())
+	require.NoError(t, err)
+
+	runGit(t, rootDir, "init", ".")
+	commitFile(t, rootDir, "app/main.rb", "base\n")
+	baseCommitHash = commitFile(t, rootDir, "app/other.rb", "other\n")
+	headCommitHash = commitFile(t, rootDir, "app/main.rb", "head\n")
+	commitFile(t, rootDir, "app/new.rb", "new\n")
+
+	return rootDir, baseCommitHash, headCommitHash
+}
+
+func TestWithBaseBranch(t *testing.T) {
+	rootDir, baseCommitHash, _ := setupRepository(t)
+
+	// uncommitted changes don't prevent reading the base files
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "app", "main.rb"), []byte("uncommitted\n"), 0600))
+
+	targetPath := filepath.Join(rootDir, "app")
+	repository, err := gitrepository.New(context.Background(), newConfig(), targetPath, &gitrepository.Context{
+		RootDir:               rootDir,
+		BaseCommitHash:        baseCommitHash,
+		HasUncommittedChanges: true,
+	})
+	require.NoError(t, err)
+
+	fileList, err := repository.ListFiles(nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, repository.Close())
+
+	assert.Equal(t, targetPath, repository.SourcePath())
+	assert.ElementsMatch(t, []string{"main.rb", "new.rb"}, filePaths(fileList.Files))
+	assert.Equal(t, []string{"main.rb"}, filePaths(fileList.BaseFiles))
+
+	var snapshotPath string
+	err = repository.WithBaseBranch(fileList, func(baseTargetPath string) error {
+		snapshotPath = baseTargetPath
+
+		content, err := os.ReadFile(filepath.Join(baseTargetPath, "main.rb"))
+		require.NoError(t, err)
+		assert.Equal(t, "base\n", string(content))
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.NoDirExists(t, snapshotPath)
+
+	content, err := os.ReadFile(filepath.Join(rootDir, "app", "main.rb"))
+	require.NoError(t, err)
+	assert.Equal(t, "uncommitted\n", string(content))
+}
+
+func TestListFiles_HeadCommit(t *testing.T) {
+	rootDir, baseCommitHash, headCommitHash := setupRepository(t)
+
+	targetPath := filepath.Join(rootDir, "app")
+	repository, err := gitrepository.New(context.Background(), newConfig(), targetPath, &gitrepository.Context{
+		RootDir:        rootDir,
+		BaseCommitHash: baseCommitHash,
+		HeadCommitHash: headCommitHash,
+	})
+	require.NoError(t, err)
+
+	fileList, err := repository.ListFiles(nil, nil)
+	require.NoError(t, err)
+
+	// the file added after the head commit is not part of the diff
+	assert.Equal(t, []string{"main.rb"}, filePaths(fileList.Files))
+	assert.NotEqual(t, targetPath, repository.SourcePath())
+
+	content, err := os.ReadFile(filepath.Join(repository.SourcePath(), "main.rb"))
+	require.NoError(t, err)
+	assert.Equal(t, "head\n", string(content))
+
+	require.NoError(t, repository.Close())
+	assert.NoDirExists(t, repository.SourcePath())
+}
+
+func filePaths(files []files.File) []string {
+	var result []string
+	for _, file := range files {
+		result = append(result, file.FilePath)
+	}
+
+	return result
+}
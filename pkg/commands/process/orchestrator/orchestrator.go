@@ -23,7 +23,6 @@ import (
 )
 
 type Orchestrator struct {
-	repository          work.Repository
 	config              *settings.Config
 	maxWorkersSemaphore chan struct{}
 	done                chan struct{}
@@ -32,7 +31,6 @@ type Orchestrator struct {
 }
 
 func New(
-	repository work.Repository,
 	config *settings.Config,
 	stats *stats.Stats,
 	estimatedFileCount int,
@@ -41,7 +39,6 @@ func New(
 	log.Debug().Msgf("number of workers: %d", parallel)
 
 	return &Orchestrator{
-		repository:          repository,
 		config:              config,
 		maxWorkersSemaphore: make(chan struct{}, parallel),
 		done:                make(chan struct{}),
@@ -49,7 +46,10 @@ func New(
 	}, nil
 }
 
+// Scan scans the files of a repository. The repository can change between
+// scans, eg. to scan a snapshot of the base branch
 func (orchestrator *Orchestrator) Scan(
+	repository work.Repository,
 	reportPath string,
 	files []files.File,
 ) error {
@@ -69,7 +69,7 @@ func (orchestrator *Orchestrator) Scan(
 		default:
 		}
 
-		go orchestrator.scanFile(reportFile, fileComplete, file)
+		go orchestrator.scanFile(repository, reportFile, fileComplete, file)
 	}
 
 	orchestrator.waitForScan(fileComplete, len(files))
@@ -111,7 +111,12 @@ func (orchestrator *Orchestrator) waitForScan(fileComplete chan struct{}, totalC
 	}
 }
 
-func (orchestrator *Orchestrator) scanFile(reportFile *os.File, fileComplete chan struct{}, file files.File) {
+func (orchestrator *Orchestrator) scanFile(
+	repository work.Repository,
+	reportFile *os.File,
+	fileComplete chan struct{},
+	file files.File,
+) {
 	orchestrator.maxWorkersSemaphore <- struct{}{}
 	tmpReportPath := tmpfile.Create(".jsonl")
 
@@ -122,7 +127,7 @@ func (orchestrator *Orchestrator) scanFile(reportFile *os.File, fileComplete cha
 	}()
 
 	if err := orchestrator.pool.Scan(work.ProcessRequest{
-		Repository: orchestrator.repository,
+		Repository: repository,
 		File:       file,
 		ReportPath: tmpReportPath,
 	}); err != nil {
@@ -46,6 +46,12 @@ func (worker *Worker) Setup(config config.Config) error {
 	worker.skipTest = config.Scan.SkipTest
 	worker.skipGitIgnore = config.Scan.SkipGitIgnore
 
+	if slices.Contains(worker.enabledScanners, "secrets") {
+		if err := detectors.SetupSecretsDetector(config.Scan.Secrets); err != nil {
+			return err
+		}
+	}
+
 	if slices.Contains(worker.enabledScanners, "sast") {
 		if err := worker.engine.Initialize(config.LogLevel); err != nil {
 			return err
@@ -8,6 +8,7 @@ import (
 	"github.com/bearer/bearer/pkg/commands/process/settings"
 	"github.com/bearer/bearer/pkg/commands/process/settings/policies"
 	"github.com/bearer/bearer/pkg/commands/process/settings/rules"
+	"github.com/bearer/bearer/pkg/detectors/gitleaks"
 	"github.com/bearer/bearer/pkg/engine"
 	"github.com/bearer/bearer/pkg/flag"
 	flagtypes "github.com/bearer/bearer/pkg/flag/types"
@@ -26,6 +27,12 @@ func FromOptions(
 		return settings.Config{}, fmt.Errorf("failed to load policies: %w", err)
 	}
 
+	if slices.Contains(opts.ScanOptions.Scanner, flag.ScannerSecrets) {
+		if err := splitSecretSkipRules(&opts); err != nil {
+			return settings.Config{}, err
+		}
+	}
+
 	result, err := rules.Load(
 		opts.ExternalRuleDir,
 		opts.RuleOptions,
@@ -79,3 +86,27 @@ func FromOptions(
 
 	return config, nil
 }
+
+// splitSecretSkipRules moves the IDs of secret rules given to --skip-rule to the
+// secrets options, as they are skipped by the secrets scanner. This also
+// checks the secrets config before any worker loads it
+func splitSecretSkipRules(opts *flagtypes.Options) error {
+	secretsConfig, err := gitleaks.LoadConfig(opts.ScanOptions.Secrets)
+	if err != nil {
+		return err
+	}
+
+	skipRule := make(map[string]bool)
+	for id, value := range opts.RuleOptions.SkipRule {
+		if _, isSecretRule := secretsConfig.Rules[id]; isSecretRule {
+			opts.ScanOptions.Secrets.SkipRule = append(opts.ScanOptions.Secrets.SkipRule, id)
+		} else {
+			skipRule[id] = value
+		}
+	}
+
+	slices.Sort(opts.ScanOptions.Secrets.SkipRule)
+	opts.RuleOptions.SkipRule = skipRule
+
+	return nil
+}
@@ -2,7 +2,17 @@ package bearer.common
 
 import rego.v1
 
-build_item(location) := {
+# secrets are reported with the ID of the matching rule and their validity, and
+# with the commit which added them when found in the git history
+build_item(location) := object.union(
+	build_secret_item(location),
+	object.filter(location, {"secret_rule_id", "secret_validity", "commit"}),
+) if {
+	# FIXME: This is only for secret detections. Should be more explicit
+	input.rule.has_detailed_context == true
+}
+
+build_secret_item(location) := {
 	"filename": location.filename,
 	"full_filename": location.full_filename,
 	"sink": {
@@ -23,9 +33,6 @@ build_item(location) := {
 	},
 	"line_number": location.start_line_number,
 	"detailed_context": location.presence_matches[0].name,
-} if {
-	# FIXME: This is only for secret detections. Should be more explicit
-	input.rule.has_detailed_context == true
 }
 
 cat_groups := data.bearer.common.groups_for_datatypes(input.dataflow.data_types) if {
@@ -62,6 +69,7 @@ build_local_item(location, data_type) := {
 		},
 	},
 	"line_number": location.source.start_line_number,
+	"dataflow": object.get(location.source, "dataflow", []),
 } if {
 	not input.rule.has_detailed_context == true
 }
@@ -0,0 +1,275 @@
+package rules
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bearer/bearer/cmd/bearer/build"
+	"github.com/bearer/bearer/pkg/commands/process/settings"
+	flagtypes "github.com/bearer/bearer/pkg/flag/types"
+	"github.com/bearer/bearer/pkg/version_check"
+)
+
+const bundleManifestFilename = "manifest.json"
+
+// BundleManifest describes the contents of a rules bundle. Each rule package
+// is pinned by its checksum
+type BundleManifest struct {
+	Version       string                   `json:"version"`
+	BearerVersion string                   `json:"bearer_version"`
+	CreatedAt     time.Time                `json:"created_at"`
+	Packages      map[string]BundlePackage `json:"packages"`
+}
+
+type BundlePackage struct {
+	File   string `json:"file"`
+	SHA256 string `json:"sha256"`
+}
+
+// WriteBundle downloads the rule packages given by the version metadata and
+// writes them to a bundle at bundlePath. The checksum of the bundle is returned
+// so that it can be given when loading the bundle
+func WriteBundle(bundlePath string, versionMeta *version_check.VersionMeta) (*BundleManifest, string, error) {
+	if versionMeta.Rules.Version == nil || len(versionMeta.Rules.Packages) == 0 {
+		return nil, "", errors.New("no rule packages available")
+	}
+
+	manifest := &BundleManifest{
+		Version:       *versionMeta.Rules.Version,
+		BearerVersion: build.Version,
+		CreatedAt:     time.Now().UTC(),
+		Packages:      make(map[string]BundlePackage),
+	}
+
+	contents := make(map[string][]byte)
+	for _, language := range sortedKeys(versionMeta.Rules.Packages) {
+		url := versionMeta.Rules.Packages[language]
+		log.Debug().Msgf("Downloading rule package: %s", url)
+
+		content, err := downloadRulePackage(url)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to download %s rules: %w", language, err)
+		}
+
+		if _, err := readRuleDefinitionZip(make(map[string]settings.RuleDefinition), bytes.NewReader(content)); err != nil {
+			return nil, "", fmt.Errorf("invalid %s rule package: %w", language, err)
+		}
+
+		filename := fmt.Sprintf("packages/%s.tar.gz", language)
+		manifest.Packages[language] = BundlePackage{File: filename, SHA256: sha256Hex(content)}
+		contents[filename] = content
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buffer bytes.Buffer
+	gzw := gzip.NewWriter(&buffer)
+	tw := tar.NewWriter(gzw)
+
+	writeEntry := func(name string, content []byte) error {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(content)),
+			ModTime: manifest.CreatedAt,
+		}); err != nil {
+			return err
+		}
+
+		_, err := tw.Write(content)
+		return err
+	}
+
+	if err := writeEntry(bundleManifestFilename, manifestJSON); err != nil {
+		return nil, "", err
+	}
+
+	for _, filename := range sortedKeys(contents) {
+		if err := writeEntry(filename, contents[filename]); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, "", err
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	checksum := sha256Hex(buffer.Bytes())
+
+	if err := os.WriteFile(bundlePath, buffer.Bytes(), 0o644); err != nil {
+		return nil, "", err
+	}
+
+	return manifest, checksum, nil
+}
+
+// loadDefinitionsFromBundle loads the rules for the given languages from a
+// bundle. The bundle must match the expected checksum given in the options; a
+// missing checksum or any mismatch is an error, there is no fallback to
+// downloading the rules
+func loadDefinitionsFromBundle(
+	definitions map[string]settings.RuleDefinition,
+	options flagtypes.RuleOptions,
+	languageIDs []string,
+) (int, *BundleManifest, error) {
+	content, err := os.ReadFile(options.RulesBundle)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if options.RulesBundleChecksum == "" {
+		return 0, nil, errors.New("no checksum given for rules bundle; use --rules-bundle-checksum")
+	}
+
+	if checksum := sha256Hex(content); !strings.EqualFold(checksum, options.RulesBundleChecksum) {
+		return 0, nil, fmt.Errorf("checksum mismatch: expected %s, got %s", options.RulesBundleChecksum, checksum)
+	}
+
+	manifest, files, err := readBundle(content)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if manifest.BearerVersion != build.Version {
+		log.Debug().Msgf(
+			"rules bundle was created with Bearer version %s, running %s",
+			manifest.BearerVersion,
+			build.Version,
+		)
+	}
+
+	count := 0
+	for _, language := range sortedKeys(manifest.Packages) {
+		bundlePackage := manifest.Packages[language]
+
+		packageContent, exists := files[bundlePackage.File]
+		if !exists {
+			return 0, nil, fmt.Errorf("missing %s rule package %s", language, bundlePackage.File)
+		}
+
+		if packageChecksum := sha256Hex(packageContent); !strings.EqualFold(packageChecksum, bundlePackage.SHA256) {
+			return 0, nil, fmt.Errorf(
+				"checksum mismatch for %s rule package: expected %s, got %s",
+				language,
+				bundlePackage.SHA256,
+				packageChecksum,
+			)
+		}
+
+		if !slices.Contains(languageIDs, language) {
+			continue
+		}
+
+		log.Debug().Msgf("Loading %s rules from bundle", language)
+		languageCount, err := readRuleDefinitionZip(definitions, bytes.NewReader(packageContent))
+		if err != nil {
+			return 0, nil, err
+		}
+
+		count += languageCount
+	}
+
+	return count, manifest, nil
+}
+
+func readBundle(content []byte) (*BundleManifest, map[string][]byte, error) {
+	gzr, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gzr.Close()
+
+	var manifest *BundleManifest
+	files := make(map[string][]byte)
+
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read file %s: %w", header.Name, err)
+		}
+
+		if header.Name == bundleManifestFilename {
+			manifest = &BundleManifest{}
+			if err := json.Unmarshal(data, manifest); err != nil {
+				return nil, nil, fmt.Errorf("invalid manifest: %w", err)
+			}
+
+			continue
+		}
+
+		files[header.Name] = data
+	}
+
+	if manifest == nil {
+		return nil, nil, errors.New("missing manifest")
+	}
+
+	if manifest.Version == "" {
+		return nil, nil, errors.New("manifest is missing the rules version")
+	}
+
+	return manifest, files, nil
+}
+
+func downloadRulePackage(url string) ([]byte, error) {
+	httpClient := &http.Client{Timeout: 60 * time.Second}
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func sha256Hex(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
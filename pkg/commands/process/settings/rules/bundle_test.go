@@ -0,0 +1,119 @@
+package rules
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bearer/bearer/pkg/commands/process/settings"
+	flagtypes "github.com/bearer/bearer/pkg/flag/types"
+	"github.com/bearer/bearer/pkg/version_check"
+)
+
+func rulePackage(t *testing.T, ruleID string) []byte {
+	var buffer bytes.Buffer
+	gzw := gzip.NewWriter(&buffer)
+	tw := tar.NewWriter(gzw)
+
+	content := []byte("metadata:\n  id: " + ruleID + "\n")
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "rules/" + ruleID + ".yml", Mode: 0o644, Size: int64(len(content))}))
+	_, err := tw.Write(content)
+	require.NoError(t, err)
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+
+	return buffer.Bytes()
+}
+
+func writeTestBundle(t *testing.T) (string, string) {
+	packages := map[string][]byte{
+		"/ruby.tar.gz":   rulePackage(t, "ruby_lang_logger"),
+		"/python.tar.gz": rulePackage(t, "python_lang_logger"),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(packages[r.URL.Path]) // nolint: errcheck
+	}))
+	defer server.Close()
+
+	version := "v1.2.3"
+	bundlePath := filepath.Join(t.TempDir(), "rules.tar.gz")
+
+	manifest, checksum, err := WriteBundle(bundlePath, &version_check.VersionMeta{
+		Rules: version_check.RuleVersionMeta{
+			Version: &version,
+			Packages: map[string]string{
+				"ruby":   server.URL + "/ruby.tar.gz",
+				"python": server.URL + "/python.tar.gz",
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.3", manifest.Version)
+	assert.Equal(t, "packages/ruby.tar.gz", manifest.Packages["ruby"].File)
+
+	content, err := os.ReadFile(bundlePath)
+	require.NoError(t, err)
+	assert.Equal(t, sha256Hex(content), checksum)
+
+	return bundlePath, checksum
+}
+
+func TestBundle(t *testing.T) {
+	bundlePath, checksum := writeTestBundle(t)
+
+	definitions := make(map[string]settings.RuleDefinition)
+	count, manifest, err := loadDefinitionsFromBundle(
+		definitions,
+		flagtypes.RuleOptions{RulesBundle: bundlePath, RulesBundleChecksum: checksum},
+		[]string{"ruby"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.2.3", manifest.Version)
+	assert.Equal(t, 1, count)
+	assert.Contains(t, definitions, "ruby_lang_logger")
+	assert.NotContains(t, definitions, "python_lang_logger")
+}
+
+func TestBundleMissingChecksum(t *testing.T) {
+	bundlePath, _ := writeTestBundle(t)
+
+	_, _, err := loadDefinitionsFromBundle(
+		make(map[string]settings.RuleDefinition),
+		flagtypes.RuleOptions{RulesBundle: bundlePath},
+		[]string{"ruby"},
+	)
+	assert.ErrorContains(t, err, "no checksum given for rules bundle")
+}
+
+func TestBundleChecksumMismatch(t *testing.T) {
+	bundlePath, checksum := writeTestBundle(t)
+
+	_, _, err := loadDefinitionsFromBundle(
+		make(map[string]settings.RuleDefinition),
+		flagtypes.RuleOptions{RulesBundle: bundlePath, RulesBundleChecksum: "abc123"},
+		[]string{"ruby"},
+	)
+	assert.ErrorContains(t, err, "checksum mismatch: expected abc123")
+
+	content, err := os.ReadFile(bundlePath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(bundlePath, append(content, 0), 0o644))
+
+	_, _, err = loadDefinitionsFromBundle(
+		make(map[string]settings.RuleDefinition),
+		flagtypes.RuleOptions{RulesBundle: bundlePath, RulesBundleChecksum: checksum},
+		[]string{"ruby"},
+	)
+	assert.ErrorContains(t, err, "checksum mismatch: expected "+checksum)
+}
@@ -121,7 +121,7 @@ func readDefinitionsFromUrls(ruleDefinitions map[string]settings.RuleDefinition,
 	return count, nil
 }
 
-func readRuleDefinitionZip(ruleDefinitions map[string]settings.RuleDefinition, file *os.File) (int, error) {
+func readRuleDefinitionZip(ruleDefinitions map[string]settings.RuleDefinition, file io.Reader) (int, error) {
 	gzr, err := gzip.NewReader(file)
 	if err != nil {
 		return 0, err
@@ -0,0 +1,341 @@
+package rules
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/md5"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/cmd/bearer/build"
+	"github.com/bearer/bearer/pkg/commands/process/settings"
+	flagtypes "github.com/bearer/bearer/pkg/flag/types"
+	"github.com/bearer/bearer/pkg/git"
+)
+
+const packManifestFilename = "bearer-pack.yml"
+
+// PackManifest describes a rule pack. It is read from the bearer-pack.yml file
+// at the root of the pack
+type PackManifest struct {
+	Name             string   `yaml:"name"`
+	Version          string   `yaml:"version"`
+	IDPrefix         string   `yaml:"id_prefix"`
+	Languages        []string `yaml:"languages"`
+	MinBearerVersion string   `yaml:"min_bearer_version"`
+}
+
+// packReference identifies a rule pack, in one of the forms:
+//
+//	<name>@<version>           the archive <mirror>/<name>-<version>.tar.gz
+//	<path>.tar.gz              an archive
+//	<repository>//<dir>@<ref>  a directory of a git repository, at a ref
+type packReference struct {
+	value       string
+	name        string
+	version     string
+	archivePath string
+	repository  string
+	dir         string
+	ref         string
+}
+
+func loadDefinitionsFromPacks(
+	definitions map[string]settings.RuleDefinition,
+	options flagtypes.RuleOptions,
+	languageIDs []string,
+) (int, error) {
+	count := 0
+
+	for _, value := range options.RulePack {
+		reference, err := parsePackReference(value, options.RulePackMirror)
+		if err != nil {
+			return 0, err
+		}
+
+		packCount, err := loadPack(definitions, reference, languageIDs)
+		if err != nil {
+			return 0, fmt.Errorf("rule pack %s: %w", value, err)
+		}
+
+		count += packCount
+	}
+
+	return count, nil
+}
+
+func loadPack(
+	definitions map[string]settings.RuleDefinition,
+	reference *packReference,
+	languageIDs []string,
+) (int, error) {
+	dir, err := packDir(reference)
+	if err != nil {
+		return 0, err
+	}
+
+	manifest, err := readPackManifest(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := validatePackManifest(manifest, reference); err != nil {
+		return 0, err
+	}
+
+	log.Debug().Msgf("loading rule pack %s@%s from: %s", manifest.Name, manifest.Version, dir)
+
+	packDefinitions := make(map[string]settings.RuleDefinition)
+	count, err := loadCustomDefinitions(packDefinitions, false, os.DirFS(dir), languageIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	for id, definition := range packDefinitions {
+		if !strings.HasPrefix(id, manifest.IDPrefix) {
+			return 0, fmt.Errorf("rule ID %s does not have the pack prefix %s", id, manifest.IDPrefix)
+		}
+
+		for _, languageID := range definition.Languages {
+			if !slices.Contains(manifest.Languages, languageID) {
+				return 0, fmt.Errorf("rule %s has language %s which is not listed in the pack manifest", id, languageID)
+			}
+		}
+
+		if _, exists := definitions[id]; exists {
+			return 0, fmt.Errorf("duplicate rule ID %s", id)
+		}
+
+		definitions[id] = definition
+	}
+
+	return count, nil
+}
+
+func parsePackReference(value, mirror string) (*packReference, error) {
+	reference := &packReference{value: value}
+
+	resolvePath := func(path string) string {
+		if mirror == "" || filepath.IsAbs(path) {
+			return path
+		}
+
+		return filepath.Join(mirror, path)
+	}
+
+	if repository, rest, isGit := strings.Cut(value, "//"); isGit {
+		dir, ref, hasRef := strings.Cut(rest, "@")
+		if repository == "" || dir == "" || !hasRef || ref == "" {
+			return nil, fmt.Errorf("invalid rule pack reference %s, expected <repository>//<dir>@<ref>", value)
+		}
+
+		reference.repository = resolvePath(repository)
+		reference.dir = path.Clean(dir)
+		reference.ref = ref
+		return reference, nil
+	}
+
+	if strings.HasSuffix(value, ".tar.gz") || strings.HasSuffix(value, ".tgz") {
+		reference.archivePath = resolvePath(value)
+		return reference, nil
+	}
+
+	name, packVersion, hasVersion := strings.Cut(value, "@")
+	if name == "" || !hasVersion || packVersion == "" {
+		return nil, fmt.Errorf("invalid rule pack reference %s, expected <name>@<version>", value)
+	}
+
+	if mirror == "" {
+		return nil, fmt.Errorf("rule pack %s requires a rule pack mirror", value)
+	}
+
+	reference.name = name
+	reference.version = packVersion
+	reference.archivePath = filepath.Join(mirror, fmt.Sprintf("%s-%s.tar.gz", name, packVersion))
+	return reference, nil
+}
+
+// packDir returns the directory containing the extracted pack, extracting it
+// to the cache if needed
+func packDir(reference *packReference) (string, error) {
+	var cacheKey string
+	var extract func(destination string) error
+
+	if reference.repository != "" {
+		commitSHA, err := git.ResolveCommit(reference.repository, reference.ref)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve ref %s: %w", reference.ref, err)
+		}
+
+		cacheKey = fmt.Sprintf("git:%s//%s@%s", reference.repository, reference.dir, commitSHA)
+		extract = func(destination string) error {
+			return git.Archive(reference.repository, commitSHA, reference.dir, func(reader io.Reader) error {
+				if err := extractPack(tar.NewReader(reader), reference.dir, destination); err != nil {
+					return err
+				}
+
+				_, err := io.Copy(io.Discard, reader)
+				return err
+			})
+		}
+	} else {
+		content, err := os.ReadFile(reference.archivePath)
+		if err != nil {
+			return "", err
+		}
+
+		cacheKey = fmt.Sprintf("archive:%x", sha256.Sum256(content))
+		extract = func(destination string) error {
+			archive, err := os.Open(reference.archivePath)
+			if err != nil {
+				return err
+			}
+			defer archive.Close()
+
+			gzr, err := gzip.NewReader(archive)
+			if err != nil {
+				return err
+			}
+			defer gzr.Close()
+
+			return extractPack(tar.NewReader(gzr), "", destination)
+		}
+	}
+
+	packsDir := filepath.Join(bearerRulesDir(), "packs")
+	dir := filepath.Join(packsDir, fmt.Sprintf("%x", md5.Sum([]byte(cacheKey))))
+	if _, err := os.Stat(dir); err == nil {
+		log.Trace().Msgf("Using local cache for rule pack: %s", reference.value)
+		return dir, nil
+	}
+
+	if err := os.MkdirAll(packsDir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("could not create rule packs directory: %w", err)
+	}
+
+	// extract to a temporary directory first, so that a partially extracted
+	// pack is never used
+	tempDir, err := os.MkdirTemp(packsDir, "extract-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := extract(tempDir); err != nil {
+		return "", fmt.Errorf("failed to extract: %w", err)
+	}
+
+	if err := os.Rename(tempDir, dir); err != nil {
+		// another process may have extracted the same pack
+		if _, statErr := os.Stat(dir); statErr == nil {
+			return dir, nil
+		}
+
+		return "", err
+	}
+
+	return dir, nil
+}
+
+func extractPack(reader *tar.Reader, prefix string, destination string) error {
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(header.Name)
+		if prefix != "" {
+			relativeName, found := strings.CutPrefix(name, prefix+"/")
+			if !found {
+				continue
+			}
+
+			name = relativeName
+		}
+
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("invalid path %s", header.Name)
+		}
+
+		filename := filepath.Join(destination, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), os.ModePerm); err != nil {
+			return err
+		}
+
+		file, err := os.Create(filename)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(file, reader)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func readPackManifest(dir string) (*PackManifest, error) {
+	content, err := os.ReadFile(filepath.Join(dir, packManifestFilename))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("missing %s", packManifestFilename)
+	} else if err != nil {
+		return nil, err
+	}
+
+	var manifest PackManifest
+	if err := yaml.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", packManifestFilename, err)
+	}
+
+	return &manifest, nil
+}
+
+func validatePackManifest(manifest *PackManifest, reference *packReference) error {
+	if manifest.Name == "" || manifest.Version == "" || manifest.IDPrefix == "" || len(manifest.Languages) == 0 {
+		return fmt.Errorf("%s must specify the name, version, id_prefix and languages", packManifestFilename)
+	}
+
+	if reference.name != "" && (manifest.Name != reference.name || manifest.Version != reference.version) {
+		return fmt.Errorf("pack is %s@%s", manifest.Name, manifest.Version)
+	}
+
+	if manifest.MinBearerVersion == "" || build.Version == "dev" {
+		return nil
+	}
+
+	minVersion, err := version.NewVersion(manifest.MinBearerVersion)
+	if err != nil {
+		return fmt.Errorf("invalid min_bearer_version: %w", err)
+	}
+
+	currentVersion, err := version.NewVersion(build.Version)
+	if err != nil {
+		log.Debug().Msgf("could not parse Bearer version %s: %s", build.Version, err)
+		return nil
+	}
+
+	if currentVersion.LessThan(minVersion) {
+		return fmt.Errorf("pack requires Bearer version %s or later", manifest.MinBearerVersion)
+	}
+
+	return nil
+}
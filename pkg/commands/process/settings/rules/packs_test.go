@@ -0,0 +1,125 @@
+package rules
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bearer/bearer/pkg/commands/process/settings"
+	flagtypes "github.com/bearer/bearer/pkg/flag/types"
+)
+
+const testPackManifest = `name: acme
+version: 1.0.0
+id_prefix: acme_
+languages:
+  - ruby
+`
+
+func testPackRule(id string) string {
+	return "languages:\n  - ruby\npatterns:\n  - pattern: logger.info($<_>)\nmetadata:\n  id: " + id + "\n"
+}
+
+func writePackArchive(t *testing.T, archivePath string, files map[string]string) {
+	file, err := os.Create(archivePath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	gzw := gzip.NewWriter(file)
+	tw := tar.NewWriter(gzw)
+
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+}
+
+func loadTestPacks(t *testing.T, mirror string, packs ...string) (map[string]settings.RuleDefinition, error) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	definitions := make(map[string]settings.RuleDefinition)
+	_, err := loadDefinitionsFromPacks(
+		definitions,
+		flagtypes.RuleOptions{RulePack: packs, RulePackMirror: mirror},
+		[]string{"ruby"},
+	)
+
+	return definitions, err
+}
+
+func TestPackFromMirror(t *testing.T) {
+	mirror := t.TempDir()
+	writePackArchive(t, filepath.Join(mirror, "acme-1.0.0.tar.gz"), map[string]string{
+		"bearer-pack.yml":    testPackManifest,
+		"rules/acme_log.yml": testPackRule("acme_log"),
+	})
+
+	definitions, err := loadTestPacks(t, mirror, "acme@1.0.0")
+	require.NoError(t, err)
+	assert.Contains(t, definitions, "acme_log")
+
+	_, err = loadTestPacks(t, mirror, "acme@2.0.0")
+	assert.ErrorContains(t, err, "no such file")
+}
+
+func TestPackFromGit(t *testing.T) {
+	repository := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(repository, "packs", "acme"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(repository, "packs", "acme", "bearer-pack.yml"), []byte(testPackManifest), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(repository, "packs", "acme", "log.yml"), []byte(testPackRule("acme_log")), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(repository, "other.yml"), []byte(testPackRule("other_log")), 0o644))
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "rules"},
+		{"tag", "v1"},
+	} {
+		command := exec.Command("git", args...)
+		command.Dir = repository
+		output, err := command.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	definitions, err := loadTestPacks(t, filepath.Dir(repository), filepath.Base(repository)+"//packs/acme@v1")
+	require.NoError(t, err)
+	assert.Contains(t, definitions, "acme_log")
+	assert.NotContains(t, definitions, "other_log")
+}
+
+func TestPackValidation(t *testing.T) {
+	mirror := t.TempDir()
+
+	writePackArchive(t, filepath.Join(mirror, "prefix.tar.gz"), map[string]string{
+		"bearer-pack.yml": testPackManifest,
+		"log.yml":         testPackRule("other_log"),
+	})
+	_, err := loadTestPacks(t, mirror, "prefix.tar.gz")
+	assert.ErrorContains(t, err, "rule ID other_log does not have the pack prefix acme_")
+
+	writePackArchive(t, filepath.Join(mirror, "no-manifest.tar.gz"), map[string]string{
+		"log.yml": testPackRule("acme_log"),
+	})
+	_, err = loadTestPacks(t, mirror, "no-manifest.tar.gz")
+	assert.ErrorContains(t, err, "missing bearer-pack.yml")
+
+	writePackArchive(t, filepath.Join(mirror, "traversal.tar.gz"), map[string]string{
+		"bearer-pack.yml": testPackManifest,
+		"../log.yml":      testPackRule("acme_log"),
+	})
+	_, err = loadTestPacks(t, mirror, "traversal.tar.gz")
+	assert.ErrorContains(t, err, "invalid path ../log.yml")
+
+	_, err = loadTestPacks(t, "", "acme@1.0.0")
+	assert.ErrorContains(t, err, "requires a rule pack mirror")
+}
@@ -56,12 +56,22 @@ func Load(
 
 	count := 0
 
-	remoteCount, err := loadDefinitionsFromRemote(definitions, options, versionMeta)
-	if err != nil {
-		return result, fmt.Errorf("error loading remote rules: %w", err)
-	}
+	if options.RulesBundle != "" && !options.DisableDefaultRules {
+		bundleCount, manifest, err := loadDefinitionsFromBundle(definitions, options, foundLanguageIDs)
+		if err != nil {
+			return result, fmt.Errorf("error loading rules bundle %s: %w", options.RulesBundle, err)
+		}
 
-	count += remoteCount
+		result.BearerRulesVersion = manifest.Version
+		count += bundleCount
+	} else {
+		remoteCount, err := loadDefinitionsFromRemote(definitions, options, versionMeta)
+		if err != nil {
+			return result, fmt.Errorf("error loading remote rules: %w", err)
+		}
+
+		count += remoteCount
+	}
 
 	if _, err := loadCustomDefinitions(builtInDefinitions, true, builtInRulesFS, nil); err != nil {
 		return result, fmt.Errorf("error loading built-in rules: %w", err)
@@ -82,6 +92,13 @@ func Load(
 		count += externalCount
 	}
 
+	packCount, err := loadDefinitionsFromPacks(definitions, options, foundLanguageIDs)
+	if err != nil {
+		return result, err
+	}
+
+	count += packCount
+
 	if err := validateRuleOptionIDs(options, definitions, builtInDefinitions); err != nil {
 		return result, err
 	}
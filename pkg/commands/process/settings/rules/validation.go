@@ -25,6 +25,10 @@ var builtinRuleIDs = []string{
 	"datatype",
 	"insecure_url",
 	"string_literal",
+	"sql_query",
+	"sql_dynamic_where",
+	"sql_select_all",
+	"sql_datatype",
 }
 
 func validateCustomRuleSchema(entry []byte, filename string) string {
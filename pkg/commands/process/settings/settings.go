@@ -59,6 +59,9 @@ type Config struct {
 	LogLevel                   string           `mapstructure:"log_level" json:"log_level" yaml:"log_level"`
 	DebugProfile               bool             `mapstructure:"debug_profile" json:"debug_profile" yaml:"debug_profile"`
 	IgnoreGit                  bool             `mapstructure:"ignore_git" json:"ignore_git" yaml:"ignore_git"`
+	// SourceDir is the directory the scanned files are read from when it is not
+	// the target, such as a snapshot of a commit
+	SourceDir string `mapstructure:"-" json:"-" yaml:"-"`
 }
 
 type Processor struct {
@@ -98,6 +101,14 @@ const (
 	STORED_DATA_TYPES MatchOn = "stored_data_types"
 )
 
+// IaCLanguage is the language of rules which apply to data stores declared in
+// infrastructure-as-code (eg. Terraform)
+const IaCLanguage = "iac"
+
+// GraphQLLanguage is the language of rules which apply to the fields of
+// GraphQL schemas
+const GraphQLLanguage = "graphql"
+
 type RuleReferenceScope string
 
 const (
@@ -311,3 +322,11 @@ func (rule *Rule) GetSeverity() string {
 func (rule *Rule) IsSecrets() bool {
 	return rule.Languages == nil
 }
+
+func (rule *Rule) IsIaC() bool {
+	return len(rule.Languages) == 1 && rule.Languages[0] == IaCLanguage
+}
+
+func (rule *Rule) IsGraphQL() bool {
+	return len(rule.Languages) == 1 && rule.Languages[0] == GraphQLLanguage
+}
@@ -0,0 +1,158 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bearer/bearer/pkg/util/set"
+)
+
+// changes are batched until no further events occur within this interval, as
+// editors and tools often write several times when saving
+const debounceInterval = 200 * time.Millisecond
+
+var ErrClosed = errors.New("watcher closed")
+
+// SkipDirFunc returns true for directories that should not be watched
+type SkipDirFunc func(path string, info fs.FileInfo) bool
+
+// Watcher reports the files changed below a directory. Each directory is
+// watched individually, so new directories are added as they are created
+type Watcher struct {
+	rootPath  string
+	skipDir   SkipDirFunc
+	fsWatcher *fsnotify.Watcher
+}
+
+func New(rootPath string, skipDir SkipDirFunc) (*Watcher, error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	watcher := &Watcher{
+		rootPath:  rootPath,
+		skipDir:   skipDir,
+		fsWatcher: fsWatcher,
+	}
+
+	if _, err := watcher.addDir(rootPath); err != nil {
+		fsWatcher.Close()
+		return nil, err
+	}
+
+	return watcher, nil
+}
+
+// Next blocks until files have changed, and returns the absolute paths of the
+// changed files. Removed files (and directories) are included
+func (watcher *Watcher) Next(ctx context.Context) ([]string, error) {
+	changed := set.New[string]()
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err, ok := <-watcher.fsWatcher.Errors:
+			if !ok {
+				return nil, ErrClosed
+			}
+
+			return nil, err
+		case event, ok := <-watcher.fsWatcher.Events:
+			if !ok {
+				return nil, ErrClosed
+			}
+
+			if watcher.handleEvent(event, changed) {
+				debounce = time.After(debounceInterval)
+			}
+		case <-debounce:
+			paths := changed.Items()
+			sort.Strings(paths)
+
+			return paths, nil
+		}
+	}
+}
+
+func (watcher *Watcher) Close() error {
+	return watcher.fsWatcher.Close()
+}
+
+func (watcher *Watcher) handleEvent(event fsnotify.Event, changed set.Set[string]) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+
+	if event.Has(fsnotify.Create) {
+		info, err := os.Lstat(event.Name)
+		if err == nil && info.IsDir() {
+			if watcher.skip(event.Name, info) {
+				return false
+			}
+
+			files, err := watcher.addDir(event.Name)
+			if err != nil {
+				log.Debug().Msgf("failed to watch directory %s: %s", event.Name, err)
+			}
+
+			changed.AddAll(files)
+			return len(files) != 0
+		}
+	}
+
+	log.Trace().Msgf("watch event: %s", event)
+	changed.Add(event.Name)
+	return true
+}
+
+// addDir watches a directory and the directories below it, returning the
+// files found
+func (watcher *Watcher) addDir(dirPath string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dirPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// the directory may have been removed since it was created
+			if path != dirPath && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		if !d.IsDir() {
+			files = append(files, path)
+			return nil
+		}
+
+		if path != watcher.rootPath {
+			info, err := d.Info()
+			if err != nil || watcher.skip(path, info) {
+				return filepath.SkipDir
+			}
+		}
+
+		return watcher.fsWatcher.Add(path)
+	})
+
+	return files, err
+}
+
+func (watcher *Watcher) skip(path string, info fs.FileInfo) bool {
+	if info.Name() == ".git" {
+		return true
+	}
+
+	return watcher.skipDir != nil && watcher.skipDir(path, info)
+}
@@ -0,0 +1,48 @@
+package watcher_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bearer/bearer/pkg/commands/process/watcher"
+)
+
+func TestWatcher(t *testing.T) {
+	rootPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rootPath, "vendor"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, "existing.rb"), []byte("a"), 0600))
+
+	fileWatcher, err := watcher.New(rootPath, func(path string, info fs.FileInfo) bool {
+		return info.Name() == "vendor"
+	})
+	require.NoError(t, err)
+	defer fileWatcher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, "vendor", "skipped.rb"), []byte("a"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, "existing.rb"), []byte("b"), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(rootPath, "app"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, "app", "new.rb"), []byte("a"), 0600))
+
+	changed, err := fileWatcher.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(rootPath, "app", "new.rb"),
+		filepath.Join(rootPath, "existing.rb"),
+	}, changed)
+
+	require.NoError(t, os.Remove(filepath.Join(rootPath, "existing.rb")))
+
+	changed, err = fileWatcher.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(rootPath, "existing.rb")}, changed)
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bearer/bearer/pkg/commands/process/settings/rules"
+	"github.com/bearer/bearer/pkg/engine"
+	"github.com/bearer/bearer/pkg/flag"
+	"github.com/bearer/bearer/pkg/util/set"
+	"github.com/bearer/bearer/pkg/version_check"
+)
+
+func NewRulesCommand(engine engine.Engine) *cobra.Command {
+	usageTemplate := `
+Usage: bearer rules <command> [flags]
+
+Available Commands:
+    pull             Download the default rules to a bundle for offline use
+
+Examples:
+    # Download the default rules to a bundle
+    $ bearer rules pull rules.tar.gz
+
+    # Scan using the rules from the bundle
+    $ bearer scan . --rules-bundle rules.tar.gz --disable-version-check
+
+`
+
+	cmd := &cobra.Command{
+		Use:           "rules [subcommand]",
+		Short:         "Manage rule bundles",
+		Args:          cobra.NoArgs,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+
+	cmd.AddCommand(
+		newRulesPullCommand(engine),
+	)
+
+	cmd.SetUsageTemplate(usageTemplate)
+
+	return cmd
+}
+
+func newRulesPullCommand(engine engine.Engine) *cobra.Command {
+	var RulesPullFlags = flag.Flags{
+		flag.GeneralFlagGroup,
+		flag.RulesPullFlagGroup,
+	}
+	cmd := &cobra.Command{
+		Use:   "pull <bundle-path>",
+		Short: "Download the default rules to a bundle for offline use",
+		Example: `# Download the default rules for all languages to a bundle
+$ bearer rules pull rules.tar.gz
+
+# Download the default rules for Ruby and JavaScript only
+$ bearer rules pull rules.tar.gz --language=ruby,javascript`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := RulesPullFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			setLogLevel(cmd)
+
+			options, err := RulesPullFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			cmd.SilenceUsage = true
+
+			languages, err := rulesPullLanguages(engine, options.RulesPullOptions.Languages)
+			if err != nil {
+				return err
+			}
+
+			versionMeta, err := version_check.GetVersionMeta(cmd.Context(), languages)
+			if err != nil {
+				return fmt.Errorf("failed to get rules version: %w", err)
+			}
+
+			manifest, checksum, err := rules.WriteBundle(args[0], versionMeta)
+			if err != nil {
+				return fmt.Errorf("failed to write rules bundle: %w", err)
+			}
+
+			packageLanguages := make([]string, 0, len(manifest.Packages))
+			for language := range manifest.Packages {
+				packageLanguages = append(packageLanguages, language)
+			}
+			sort.Strings(packageLanguages)
+
+			cmd.Printf("Rules bundle written to %s\n\n", args[0])
+			cmd.Printf("Version:   %s\n", manifest.Version)
+			cmd.Printf("Languages: %v\n", packageLanguages)
+			cmd.Printf("SHA-256:   %s\n\n", checksum)
+			cmd.Printf("Use it with: bearer scan --rules-bundle %s --rules-bundle-checksum %s\n", args[0], checksum)
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	RulesPullFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, RulesPullFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func rulesPullLanguages(engine engine.Engine, requested []string) ([]string, error) {
+	supported := set.New[string]()
+	for _, language := range engine.GetLanguages() {
+		supported.Add(language.ID())
+	}
+
+	if len(requested) == 0 {
+		languages := supported.Items()
+		sort.Strings(languages)
+		return languages, nil
+	}
+
+	for _, language := range requested {
+		if !supported.Has(language) {
+			return nil, fmt.Errorf("unsupported language %s", language)
+		}
+	}
+
+	return requested, nil
+}
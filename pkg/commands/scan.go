@@ -1,13 +1,18 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 
 	"github.com/bearer/bearer/pkg/commands/artifact"
 	"github.com/bearer/bearer/pkg/commands/debugprofile"
+	"github.com/bearer/bearer/pkg/commands/process/filelist"
 	"github.com/bearer/bearer/pkg/engine"
 	"github.com/bearer/bearer/pkg/flag"
+	flagtypes "github.com/bearer/bearer/pkg/flag/types"
 	"github.com/bearer/bearer/pkg/util/file"
 	"github.com/bearer/bearer/pkg/util/output"
 	"github.com/rs/zerolog/log"
@@ -42,11 +47,20 @@ var ScanFlags = flag.Flags{
 
 func NewScanCommand(engine engine.Engine) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "scan [flags] <path>",
+		Use:     "scan [flags] <path>...",
 		Aliases: []string{"s"},
 		Short:   "Scan a directory or file",
 		Example: `  # Scan a local project, including language-specific files
-  $ bearer scan /path/to/your_project`,
+  $ bearer scan /path/to/your_project
+
+  # Scan only the files staged for commit
+  $ git diff --cached --name-only -z | bearer scan --files-from -
+
+  # Keep scanning a project as files change
+  $ bearer scan --watch /path/to/your_project
+
+  # Scan a project and its git history for secrets
+  $ bearer scan --scanner secrets --history /path/to/your_project`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := ScanFlags.Bind(cmd); err != nil {
 				return fmt.Errorf("flag bind error: %w", err)
@@ -78,10 +92,28 @@ func NewScanCommand(engine engine.Engine) *cobra.Command {
 				return fmt.Errorf("flag error: %w", err)
 			}
 
-			if len(args) == 0 {
+			if len(args) == 0 && options.FilesFrom == "" {
 				return cmd.Help()
-			} else {
-				options.Target = args[0]
+			}
+
+			if err := setScanTarget(&options, args, cmd.InOrStdin()); err != nil {
+				return err
+			}
+
+			if options.Watch && (options.DiffHeadCommit != "" || len(options.Files) != 0) {
+				return errors.New("--watch cannot be used with --diff-head-commit or when scanning an explicit list of files")
+			}
+
+			if options.DiffHeadCommit != "" && !options.Diff {
+				return errors.New("--diff-head-commit can only be used with --diff")
+			}
+
+			if options.Since != "" && !options.History {
+				return errors.New("--since can only be used with --history")
+			}
+
+			if options.History && !slices.Contains(options.Scanner, flag.ScannerSecrets) {
+				return errors.New("--history can only be used with the secrets scanner, e.g. --scanner=secrets")
 			}
 
 			cmd.SilenceUsage = true
@@ -106,6 +138,57 @@ func NewScanCommand(engine engine.Engine) *cobra.Command {
 	return cmd
 }
 
+// setScanTarget sets the scan target from the arguments. A single argument is
+// the path to scan. Otherwise, the arguments and any --files-from entries are
+// scanned explicitly, relative to the working directory as the project root
+func setScanTarget(options *flagtypes.Options, args []string, stdin io.Reader) error {
+	if options.FilesFrom == "" && len(args) == 1 {
+		options.Target = args[0]
+		return nil
+	}
+
+	if options.Diff {
+		return errors.New("--diff cannot be used when scanning an explicit list of files")
+	}
+
+	paths := slices.Clone(args)
+	if options.FilesFrom != "" {
+		listedPaths, err := readFilesFrom(options.FilesFrom, stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read files from %s: %w", options.FilesFrom, err)
+		}
+
+		paths = append(paths, listedPaths...)
+	}
+
+	options.Target = "."
+	options.Files = make([]string, len(paths))
+	for i, path := range paths {
+		fullPath, err := filelist.ResolvePath(path)
+		if err != nil {
+			return err
+		}
+
+		options.Files[i] = fullPath
+	}
+
+	return nil
+}
+
+func readFilesFrom(path string, stdin io.Reader) ([]string, error) {
+	if path == "-" {
+		return filelist.ReadPaths(stdin)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return filelist.ReadPaths(file)
+}
+
 func readConfig(args []string) (string, string, error) {
 	configPath := viper.GetString(flag.ConfigFileFlag.ConfigName)
 	var loadFileMessage string
@@ -0,0 +1,247 @@
+package avro
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smacker/go-tree-sitter/javascript"
+
+	"github.com/bearer/bearer/pkg/detectors/types"
+	"github.com/bearer/bearer/pkg/parser"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/schema"
+	"github.com/bearer/bearer/pkg/util/file"
+	"github.com/bearer/bearer/pkg/util/pluralize"
+	"github.com/bearer/bearer/pkg/util/stringutil"
+
+	"github.com/bearer/bearer/pkg/parser/nodeid"
+	parserschema "github.com/bearer/bearer/pkg/parser/schema"
+	reporttypes "github.com/bearer/bearer/pkg/report"
+)
+
+var (
+	language = javascript.GetLanguage()
+
+	// records nested in a field type are matched separately, so each record is
+	// reported as its own object
+	avroRecordQuery = parser.QueryMustCompile(language, `
+	(object
+		(pair
+			key: (string) @helper_type
+			value: (string) @record_type
+		)
+	) @record
+	`)
+)
+
+type detector struct {
+	idGenerator nodeid.Generator
+}
+
+func New(idGenerator nodeid.Generator) types.Detector {
+	return &detector{
+		idGenerator: idGenerator,
+	}
+}
+
+func (detector *detector) AcceptDir(dir *file.Path) (bool, error) {
+	return true, nil
+}
+
+func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, report reporttypes.Report) (bool, error) {
+	if file.Extension != ".avsc" {
+		return false, nil
+	}
+
+	err := detector.ExtractFromSchema(file, report)
+
+	return true, err
+}
+
+func (detector *detector) ExtractFromSchema(
+	file *file.FileInfo,
+	report reporttypes.Report,
+) error {
+	tree, err := parser.ParseFile(file, file.Path, language)
+	if err != nil {
+		return err
+	}
+	defer tree.Close()
+
+	uuidHolder := parserschema.NewUUIDHolder()
+
+	for _, captures := range tree.QueryConventional(avroRecordQuery) {
+		recordType := stringutil.StripQuotes(captures["record_type"].Content())
+		if recordType != "record" && recordType != "error" {
+			continue
+		}
+
+		pairs := objectPairs(captures["record"])
+		objectNode, hasName := pairs["name"]
+		fieldsNode, hasFields := pairs["fields"]
+		if !hasName || !hasFields || objectNode.Type() != "string" || fieldsNode.Type() != "array" {
+			continue
+		}
+
+		objectName := stringutil.StripQuotes(objectNode.Content())
+		objectUUID := uuidHolder.Assign(objectNode.ID(), detector.idGenerator)
+		normalizedObjectName := pluralize.Singular(strings.ToLower(objectName))
+
+		for i := 0; i < fieldsNode.ChildCount(); i++ {
+			fieldObject := fieldsNode.Child(i)
+			if fieldObject.Type() != "object" {
+				continue
+			}
+
+			fieldPairs := objectPairs(fieldObject)
+			fieldNode, hasFieldName := fieldPairs["name"]
+			typeNode, hasType := fieldPairs["type"]
+			if !hasFieldName || !hasType || fieldNode.Type() != "string" {
+				continue
+			}
+
+			fieldName := stringutil.StripQuotes(fieldNode.Content())
+			fieldUUID := uuidHolder.Assign(fieldNode.ID(), detector.idGenerator)
+			fieldType, simpleFieldType := convertType(typeNode)
+
+			currentSchema := schema.Schema{
+				ObjectName:           objectName,
+				ObjectUUID:           objectUUID,
+				FieldName:            fieldName,
+				FieldUUID:            fieldUUID,
+				FieldType:            fieldType,
+				SimpleFieldType:      simpleFieldType,
+				NormalizedObjectName: normalizedObjectName,
+				NormalizedFieldName:  pluralize.Singular(strings.ToLower(fieldName)),
+			}
+
+			if !report.SchemaGroupIsOpen() {
+				source := objectNode.Source(true)
+				report.SchemaGroupBegin(
+					detectors.DetectorAvro,
+					objectNode,
+					currentSchema,
+					&source,
+					nil,
+				)
+			}
+			source := fieldNode.Source(true)
+			report.SchemaGroupAddItem(
+				fieldNode,
+				currentSchema,
+				&source,
+			)
+		}
+
+		report.SchemaGroupEnd(detector.idGenerator)
+	}
+
+	return nil
+}
+
+// objectPairs returns the value nodes of a JSON object by key
+func objectPairs(object *parser.Node) map[string]*parser.Node {
+	pairs := make(map[string]*parser.Node)
+
+	for i := 0; i < object.ChildCount(); i++ {
+		pair := object.Child(i)
+		if pair.Type() != "pair" {
+			continue
+		}
+
+		key := pair.ChildByFieldName("key")
+		value := pair.ChildByFieldName("value")
+		if key == nil || value == nil {
+			continue
+		}
+
+		pairs[stringutil.StripQuotes(key.Content())] = value
+	}
+
+	return pairs
+}
+
+// convertType returns the textual and simple types of an Avro type
+// definition. Nullable unions (eg. `["null", "string"]`) use the type of the
+// non-null member
+func convertType(node *parser.Node) (string, string) {
+	switch node.Type() {
+	case "string":
+		typeName := stringutil.StripQuotes(node.Content())
+		return typeName, convertPrimitiveType(typeName)
+	case "array":
+		var memberTypes []string
+		simpleType := ""
+
+		for i := 0; i < node.ChildCount(); i++ {
+			member := node.Child(i)
+			if !member.IsNamed() || member.Type() == "comment" {
+				continue
+			}
+
+			memberType, memberSimpleType := convertType(member)
+			if memberType == "null" {
+				continue
+			}
+
+			memberTypes = append(memberTypes, memberType)
+			if simpleType == "" {
+				simpleType = memberSimpleType
+			}
+		}
+
+		if len(memberTypes) != 1 {
+			simpleType = schema.SimpleTypeObject
+		}
+
+		return strings.Join(memberTypes, " | "), simpleType
+	case "object":
+		pairs := objectPairs(node)
+
+		typeNode, hasType := pairs["type"]
+		if !hasType {
+			return "", schema.SimpleTypeObject
+		}
+
+		typeName, simpleType := convertType(typeNode)
+		if logicalType, hasLogicalType := pairs["logicalType"]; hasLogicalType {
+			return stringutil.StripQuotes(logicalType.Content()), simpleType
+		}
+
+		switch typeName {
+		case "record", "error", "enum", "fixed":
+			if name, hasName := pairs["name"]; hasName {
+				typeName = stringutil.StripQuotes(name.Content())
+			}
+		case "array":
+			if items, hasItems := pairs["items"]; hasItems {
+				itemsType, _ := convertType(items)
+				typeName = fmt.Sprintf("array<%s>", itemsType)
+			}
+		case "map":
+			if values, hasValues := pairs["values"]; hasValues {
+				valuesType, _ := convertType(values)
+				typeName = fmt.Sprintf("map<%s>", valuesType)
+			}
+		}
+
+		return typeName, simpleType
+	}
+
+	return "", schema.SimpleTypeObject
+}
+
+func convertPrimitiveType(value string) string {
+	switch value {
+	case "int", "long", "float", "double":
+		return schema.SimpleTypeNumber
+	case "string", "enum":
+		return schema.SimpleTypeString
+	case "bytes", "fixed":
+		return schema.SimpleTypeBinary
+	case "boolean":
+		return schema.SimpleTypeBool
+	default:
+		return schema.SimpleTypeObject
+	}
+}
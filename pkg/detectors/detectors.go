@@ -8,8 +8,10 @@ import (
 	"slices"
 
 	"github.com/rs/zerolog/log"
+	gitleaksconfig "github.com/zricethezav/gitleaks/v8/config"
 
 	"github.com/bearer/bearer/pkg/commands/process/settings"
+	"github.com/bearer/bearer/pkg/detectors/avro"
 	"github.com/bearer/bearer/pkg/detectors/beego"
 	"github.com/bearer/bearer/pkg/detectors/csharp"
 	"github.com/bearer/bearer/pkg/detectors/custom"
@@ -18,13 +20,16 @@ import (
 	"github.com/bearer/bearer/pkg/detectors/dotnet"
 	"github.com/bearer/bearer/pkg/detectors/envfile"
 	"github.com/bearer/bearer/pkg/detectors/gitleaks"
+	"github.com/bearer/bearer/pkg/detectors/gitleaks/verifier"
 	"github.com/bearer/bearer/pkg/detectors/golang"
 	"github.com/bearer/bearer/pkg/detectors/graphql"
 	"github.com/bearer/bearer/pkg/detectors/html"
+	"github.com/bearer/bearer/pkg/detectors/iac"
 	"github.com/bearer/bearer/pkg/detectors/ipynb"
 	"github.com/bearer/bearer/pkg/detectors/java"
 	"github.com/bearer/bearer/pkg/detectors/javascript"
 	"github.com/bearer/bearer/pkg/detectors/openapi"
+	"github.com/bearer/bearer/pkg/detectors/orm"
 	"github.com/bearer/bearer/pkg/detectors/php"
 	"github.com/bearer/bearer/pkg/detectors/proto"
 	"github.com/bearer/bearer/pkg/detectors/python"
@@ -37,6 +42,7 @@ import (
 	"github.com/bearer/bearer/pkg/detectors/tsx"
 	"github.com/bearer/bearer/pkg/detectors/typescript"
 	"github.com/bearer/bearer/pkg/detectors/yamlconfig"
+	flagtypes "github.com/bearer/bearer/pkg/flag/types"
 	"github.com/bearer/bearer/pkg/scanner"
 	"github.com/bearer/bearer/pkg/scanner/stats"
 	"github.com/bearer/bearer/pkg/util/file"
@@ -66,6 +72,31 @@ func SetupLegacyDetector(config map[string]*settings.Rule) error {
 	return detector.CompileRules(config)
 }
 
+// secretsConfig is the config of the secrets scanner, once set up
+var secretsConfig *gitleaksconfig.Config
+var secretsVerifier verifier.Verifier
+
+func SetupSecretsDetector(options flagtypes.SecretsOptions) error {
+	config, err := gitleaks.LoadConfig(options)
+	if err != nil {
+		return err
+	}
+
+	secretsConfig = &config
+	if options.Verify {
+		secretsVerifier = verifier.New(options.Verifiers)
+	}
+	return nil
+}
+
+func getSecretsConfig() gitleaksconfig.Config {
+	if secretsConfig == nil {
+		return gitleaks.DefaultConfig()
+	}
+
+	return *secretsConfig
+}
+
 func Registrations(scanners []string) []InitializedDetector {
 	// The order of these is important, the first one to claim a file will win
 	detectors := []InitializedDetector{}
@@ -75,7 +106,7 @@ func Registrations(scanners []string) []InitializedDetector {
 		detectors = append(
 			detectors,
 			InitializedDetector{
-				reportdetectors.DetectorGitleaks, gitleaks.New(&nodeid.UUIDGenerator{}),
+				reportdetectors.DetectorGitleaks, gitleaks.New(&nodeid.UUIDGenerator{}, getSecretsConfig(), secretsVerifier),
 			},
 		)
 	}
@@ -88,6 +119,17 @@ func Registrations(scanners []string) []InitializedDetector {
 
 				{reportdetectors.DetectorDependencies, dependencies.New()},
 
+				// ORM schemas don't consume files, so these must come before the
+				// language detectors
+				{reportdetectors.DetectorPrisma, orm.NewPrisma(&nodeid.UUIDGenerator{})},
+				{reportdetectors.DetectorTypeORM, orm.NewTypeORM(&nodeid.UUIDGenerator{})},
+				{reportdetectors.DetectorSQLAlchemy, orm.NewSQLAlchemy(&nodeid.UUIDGenerator{})},
+				{reportdetectors.DetectorAlembic, orm.NewAlembic(&nodeid.UUIDGenerator{})},
+				{reportdetectors.DetectorEnt, orm.NewEnt(&nodeid.UUIDGenerator{})},
+
+				// GraphQL resolvers don't consume files either
+				{reportdetectors.DetectorGraphQLResolvers, graphql.NewResolvers(&nodeid.UUIDGenerator{})},
+
 				{reportdetectors.DetectorBeego, beego.New()},
 				{reportdetectors.DetectorGo, golang.New(&nodeid.UUIDGenerator{})},
 
@@ -114,10 +156,12 @@ func Registrations(scanners []string) []InitializedDetector {
 				{reportdetectors.DetectorSymfony, symfony.New()},
 				{reportdetectors.DetectorPHP, php.New(&nodeid.UUIDGenerator{})},
 
+				{reportdetectors.DetectorIaC, iac.New()},
 				{reportdetectors.DetectorYamlConfig, yamlconfig.New()},
 
 				{reportdetectors.DetectorSQL, sql.New(&nodeid.UUIDGenerator{})},
 				{reportdetectors.DetectorProto, proto.New(&nodeid.UUIDGenerator{})},
+				{reportdetectors.DetectorAvro, avro.New(&nodeid.UUIDGenerator{})},
 				{reportdetectors.DetectorGraphQL, graphql.New(&nodeid.UUIDGenerator{})},
 
 				{reportdetectors.DetectorHTML, html.New(&nodeid.UUIDGenerator{})},
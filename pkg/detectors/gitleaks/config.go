@@ -0,0 +1,167 @@
+package gitleaks
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"slices"
+
+	toml "github.com/pelletier/go-toml/v2"
+	zerolog "github.com/rs/zerolog/log"
+	"github.com/zricethezav/gitleaks/v8/config"
+
+	flagtypes "github.com/bearer/bearer/pkg/flag/types"
+)
+
+// DefaultConfig returns the config of the built-in patterns
+func DefaultConfig() config.Config {
+	cfg, err := LoadConfig(flagtypes.SecretsOptions{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return cfg
+}
+
+// LoadConfig merges the built-in patterns with the rules and allowlists given
+// by the user. A user rule replaces the built-in rule with the same ID
+func LoadConfig(options flagtypes.SecretsOptions) (config.Config, error) {
+	var vc config.ViperConfig
+	toml.Unmarshal(RawConfig, &vc) //nolint:all,errcheck
+
+	if options.Config != "" {
+		content, err := os.ReadFile(options.Config)
+		if err != nil {
+			return config.Config{}, fmt.Errorf("failed to read secrets config: %w", err)
+		}
+
+		var userConfig config.ViperConfig
+		if err := toml.Unmarshal(content, &userConfig); err != nil {
+			return config.Config{}, fmt.Errorf("failed to parse secrets config %s: %w", options.Config, err)
+		}
+
+		if userConfig.Extend.Path != "" || userConfig.Extend.URL != "" || userConfig.Extend.UseDefault {
+			zerolog.Warn().Msgf("[extend] is not supported in secrets config %s and will be ignored", options.Config)
+		}
+
+		mergeConfig(&vc, userConfig)
+	}
+
+	if len(options.Rules) != 0 || len(options.Allowlists) != 0 {
+		userConfig, err := fromOptions(options)
+		if err != nil {
+			return config.Config{}, err
+		}
+
+		mergeConfig(&vc, userConfig)
+	}
+
+	if err := validateRegexes(vc); err != nil {
+		return config.Config{}, err
+	}
+
+	cfg, err := vc.Translate()
+	if err != nil {
+		return config.Config{}, fmt.Errorf("invalid secrets config: %w", err)
+	}
+
+	for _, ruleID := range options.SkipRule {
+		delete(cfg.Rules, ruleID)
+	}
+	cfg.OrderedRules = slices.DeleteFunc(cfg.OrderedRules, func(ruleID string) bool {
+		return slices.Contains(options.SkipRule, ruleID)
+	})
+
+	return cfg, nil
+}
+
+// fromOptions converts the secrets section of the bearer config into a gitleaks
+// config, which has the same fields
+func fromOptions(options flagtypes.SecretsOptions) (config.ViperConfig, error) {
+	content, err := toml.Marshal(struct {
+		Rules      []flagtypes.SecretRule      `toml:"rules"`
+		Allowlists []flagtypes.SecretAllowlist `toml:"allowlists"`
+	}{
+		Rules:      options.Rules,
+		Allowlists: options.Allowlists,
+	})
+	if err != nil {
+		return config.ViperConfig{}, err
+	}
+
+	var result config.ViperConfig
+	if err := toml.Unmarshal(content, &result); err != nil {
+		return config.ViperConfig{}, err
+	}
+
+	return result, nil
+}
+
+func mergeConfig(base *config.ViperConfig, extension config.ViperConfig) {
+	// the older [allowlist] format can't be combined with [[allowlists]]
+	if base.AllowList != nil {
+		base.Allowlists = append(base.Allowlists, base.AllowList)
+		base.AllowList = nil
+	}
+
+	for _, rule := range extension.Rules {
+		replaced := false
+		for i := range base.Rules {
+			if base.Rules[i].ID == rule.ID {
+				base.Rules[i] = rule
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			base.Rules = append(base.Rules, rule)
+		}
+	}
+
+	if extension.AllowList != nil {
+		base.Allowlists = append(base.Allowlists, extension.AllowList)
+	}
+	base.Allowlists = append(base.Allowlists, extension.Allowlists...)
+}
+
+// validateRegexes checks the patterns of the config, as gitleaks panics on
+// invalid ones
+func validateRegexes(vc config.ViperConfig) error {
+	validate := func(context string, patterns ...string) error {
+		for _, pattern := range patterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("invalid secrets config: %s: %w", context, err)
+			}
+		}
+
+		return nil
+	}
+
+	for _, rule := range vc.Rules {
+		if err := validate(rule.ID, rule.Regex, rule.Path); err != nil {
+			return err
+		}
+
+		if rule.AllowList != nil {
+			if err := validate(rule.ID+" allowlist", slices.Concat(rule.AllowList.Regexes, rule.AllowList.Paths)...); err != nil {
+				return err
+			}
+		}
+
+		for _, allowlist := range rule.Allowlists {
+			if err := validate(rule.ID+" allowlist", slices.Concat(allowlist.Regexes, allowlist.Paths)...); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, allowlist := range vc.Allowlists {
+		if err := validate("allowlist", slices.Concat(allowlist.Regexes, allowlist.Paths)...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
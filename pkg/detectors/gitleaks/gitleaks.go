@@ -2,19 +2,22 @@ package gitleaks
 
 import (
 	"context"
+	"crypto/sha256"
 	_ "embed"
-	"log"
+	"encoding/hex"
 	"strings"
 
+	"github.com/bearer/bearer/pkg/detectors/gitleaks/verifier"
 	"github.com/bearer/bearer/pkg/detectors/types"
 	"github.com/bearer/bearer/pkg/parser/nodeid"
 	"github.com/bearer/bearer/pkg/report"
 	"github.com/bearer/bearer/pkg/report/secret"
 	"github.com/bearer/bearer/pkg/report/source"
 	"github.com/bearer/bearer/pkg/util/file"
-	toml "github.com/pelletier/go-toml/v2"
+	"github.com/rs/zerolog/log"
 	"github.com/zricethezav/gitleaks/v8/config"
 	"github.com/zricethezav/gitleaks/v8/detect"
+	gitleaksreport "github.com/zricethezav/gitleaks/v8/report"
 	"github.com/zricethezav/gitleaks/v8/sources"
 )
 
@@ -24,22 +27,17 @@ var RawConfig []byte
 type detector struct {
 	gitleaksDetector *detect.Detector
 	config           config.Config
+	verifier         verifier.Verifier
 	idGenerator      nodeid.Generator
 }
 
-func New(idGenerator nodeid.Generator) types.Detector {
-	var vc config.ViperConfig
-	toml.Unmarshal(RawConfig, &vc) //nolint:all,errcheck
-	cfg, err := vc.Translate()
-	if err != nil {
-		log.Fatal(err)
-	}
-
+func New(idGenerator nodeid.Generator, cfg config.Config, secretVerifier verifier.Verifier) types.Detector {
 	gitleaksDetector := detect.NewDetector(cfg)
 
 	return &detector{
 		gitleaksDetector: gitleaksDetector,
 		config:           cfg,
+		verifier:         secretVerifier,
 		idGenerator:      idGenerator,
 	}
 }
@@ -66,6 +64,9 @@ func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, repor
 		text := strings.TrimPrefix(finding.Line, "\n")
 		report.AddSecretLeak(secret.Secret{
 			Description: finding.Description,
+			RuleID:      finding.RuleID,
+			Fingerprint: fingerprint(finding),
+			Validity:    validity(detector.verifier, finding, file.Path.RelativePath),
 		}, source.Source{
 			Filename:          file.Path.RelativePath,
 			StartLineNumber:   &finding.StartLine,
@@ -78,3 +79,30 @@ func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, repor
 
 	return false, nil
 }
+
+// fingerprint identifies a secret by its rule and value, so that the same
+// secret can be matched across files and commits without storing it
+func fingerprint(finding gitleaksreport.Finding) string {
+	hash := sha256.Sum256([]byte(finding.RuleID + ":" + finding.Secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// validity returns the validity of the secret, or an empty string when secrets
+// aren't verified
+func validity(secretVerifier verifier.Verifier, finding gitleaksreport.Finding, filename string) string {
+	if secretVerifier == nil {
+		return ""
+	}
+
+	result, err := secretVerifier.Verify(verifier.Candidate{
+		RuleID:   finding.RuleID,
+		Secret:   finding.Secret,
+		Filename: filename,
+	})
+	if err != nil {
+		log.Warn().Msgf("failed to verify %s secret in %s: %s", finding.RuleID, filename, err)
+		return secret.ValidityUnknown
+	}
+
+	return result
+}
@@ -0,0 +1,81 @@
+package gitleaks
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/zricethezav/gitleaks/v8/config"
+	"github.com/zricethezav/gitleaks/v8/detect"
+
+	"github.com/bearer/bearer/pkg/detectors/gitleaks/verifier"
+	"github.com/bearer/bearer/pkg/git"
+	"github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/report/secret"
+	"github.com/bearer/bearer/pkg/report/source"
+)
+
+// ScanHistory reports the secrets added by the commits of the repository at
+// rootDir, after sinceRef when given. A secret added by several commits is only
+// reported for the first of them
+func ScanHistory(
+	rootDir,
+	sinceRef string,
+	cfg config.Config,
+	secretVerifier verifier.Verifier,
+	report report.Report,
+) error {
+	gitleaksDetector := detect.NewDetector(cfg)
+	seen := make(map[string]bool)
+	commitCount := 0
+	lastSHA := ""
+
+	err := git.History(rootDir, sinceRef, func(commit git.Commit, added git.AddedLines) error {
+		if commit.SHA != lastSHA {
+			commitCount++
+			lastSHA = commit.SHA
+		}
+
+		findings := gitleaksDetector.Detect(detect.Fragment{
+			Raw:       strings.Join(added.Lines, "\n"),
+			FilePath:  filepath.ToSlash(added.Path),
+			CommitSHA: commit.SHA,
+			StartLine: added.LineNumber,
+		})
+
+		for _, finding := range findings {
+			fingerprint := fingerprint(finding)
+			if seen[fingerprint] {
+				continue
+			}
+			seen[fingerprint] = true
+
+			text := strings.TrimPrefix(finding.Line, "\n")
+			report.AddSecretLeak(secret.Secret{
+				Description: finding.Description,
+				RuleID:      finding.RuleID,
+				Fingerprint: fingerprint,
+				Validity:    validity(secretVerifier, finding, added.Path),
+				Commit: &secret.Commit{
+					SHA:    commit.SHA,
+					Author: commit.AuthorName + " <" + commit.AuthorEmail + ">",
+					Date:   commit.Date.UTC().Format(time.RFC3339),
+				},
+			}, source.Source{
+				Filename:          added.Path,
+				StartLineNumber:   &finding.StartLine,
+				StartColumnNumber: &finding.StartColumn,
+				EndLineNumber:     &finding.EndLine,
+				EndColumnNumber:   &finding.EndColumn,
+				Text:              &text,
+			})
+		}
+
+		return nil
+	})
+
+	log.Debug().Msgf("scanned %d commits for secrets", commitCount)
+
+	return err
+}
@@ -0,0 +1,61 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bearer/bearer/pkg/report/secret"
+)
+
+const commandTimeout = 10 * time.Second
+
+// commandVerifier runs a command given by the user. The command receives the
+// candidate as JSON on its standard input, and prints the validity of the
+// secret on its standard output
+type commandVerifier struct {
+	command []string
+	ruleIDs []string
+}
+
+func (verifier *commandVerifier) Verify(candidate Candidate) (string, error) {
+	if len(verifier.command) == 0 {
+		return "", errors.New("verifier command is empty")
+	}
+
+	if len(verifier.ruleIDs) != 0 && !slices.Contains(verifier.ruleIDs, candidate.RuleID) {
+		return secret.ValidityUnknown, nil
+	}
+
+	input, err := json.Marshal(candidate)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	command := exec.CommandContext(ctx, verifier.command[0], verifier.command[1:]...)
+	command.Stdin = bytes.NewReader(input)
+	command.Stderr = &stderr
+
+	output, err := command.Output()
+	if err != nil {
+		return "", fmt.Errorf("verifier command %s failed: %w %s", verifier.command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	validity := strings.TrimSpace(string(output))
+	switch validity {
+	case secret.ValidityLikelyLive, secret.ValidityLikelyFake, secret.ValidityUnknown:
+		return validity, nil
+	default:
+		return "", fmt.Errorf("verifier command %s returned unexpected output %q", verifier.command[0], validity)
+	}
+}
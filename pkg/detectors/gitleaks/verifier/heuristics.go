@@ -0,0 +1,94 @@
+package verifier
+
+import (
+	"hash/crc32"
+	"regexp"
+	"strings"
+
+	"github.com/bearer/bearer/pkg/report/secret"
+	"github.com/bearer/bearer/pkg/util/entropy"
+)
+
+var placeholderRegex = regexp.MustCompile(
+	`(?i)example|sample|dummy|placeholder|changeme|redacted|your[_-]?(api[_-]?)?(key|token|secret)|` +
+		`x{6,}|\*{4,}|0{8,}|123456789|abcdefgh|<[^>]+>|\$\{[^}]+\}|\{\{[^}]+\}\}`,
+)
+
+// placeholderVerifier reports the example and template values, such as the
+// ones found in documentation
+type placeholderVerifier struct{}
+
+func (placeholderVerifier) Verify(candidate Candidate) (string, error) {
+	if placeholderRegex.MatchString(candidate.Secret) || isRepeated(candidate.Secret) {
+		return secret.ValidityLikelyFake, nil
+	}
+
+	return secret.ValidityUnknown, nil
+}
+
+func isRepeated(value string) bool {
+	return len(value) > 1 && strings.Count(value, value[:1]) == len(value)
+}
+
+// checksumPrefixes are the token formats ending with a CRC32 checksum of their
+// random part, encoded as 6 base62 characters
+var checksumPrefixes = []string{"ghp_", "gho_", "ghu_", "ghs_", "ghr_", "npm_"}
+
+const (
+	checksumRandomLength = 30
+	checksumLength       = 6
+	base62Alphabet       = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// checksumVerifier checks the check digits of the token formats which have
+// them. A token with a valid checksum is unlikely to have been made up
+type checksumVerifier struct{}
+
+func (checksumVerifier) Verify(candidate Candidate) (string, error) {
+	for _, prefix := range checksumPrefixes {
+		token, hasPrefix := strings.CutPrefix(candidate.Secret, prefix)
+		if !hasPrefix || len(token) != checksumRandomLength+checksumLength {
+			continue
+		}
+
+		random := token[:checksumRandomLength]
+		if base62(crc32.ChecksumIEEE([]byte(random)), checksumLength) == token[checksumRandomLength:] {
+			return secret.ValidityLikelyLive, nil
+		}
+
+		return secret.ValidityLikelyFake, nil
+	}
+
+	return secret.ValidityUnknown, nil
+}
+
+func base62(value uint32, length int) string {
+	result := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		result[i] = base62Alphabet[value%62]
+		value /= 62
+	}
+
+	return string(result)
+}
+
+const (
+	// entropyMinimumLength is the length below which the entropy of a secret
+	// isn't meaningful
+	entropyMinimumLength = 16
+	// entropyThreshold is the Shannon entropy, in bits per character, below
+	// which a secret is unlikely to be random
+	entropyThreshold = 3.0
+)
+
+// entropyVerifier reports the secrets which don't look random enough to be
+// generated credentials
+type entropyVerifier struct{}
+
+func (entropyVerifier) Verify(candidate Candidate) (string, error) {
+	if len(candidate.Secret) >= entropyMinimumLength && entropy.Shannon(candidate.Secret) < entropyThreshold {
+		return secret.ValidityLikelyFake, nil
+	}
+
+	return secret.ValidityUnknown, nil
+}
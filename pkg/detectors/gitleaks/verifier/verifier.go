@@ -0,0 +1,56 @@
+// Package verifier triages the secrets found by the secrets scanner, telling
+// apart the credentials which are likely to be live from the fake ones
+package verifier
+
+import (
+	"github.com/rs/zerolog/log"
+
+	flagtypes "github.com/bearer/bearer/pkg/flag/types"
+	"github.com/bearer/bearer/pkg/report/secret"
+)
+
+// Candidate is a secret found by a rule
+type Candidate struct {
+	RuleID   string `json:"rule_id"`
+	Secret   string `json:"secret"`
+	Filename string `json:"filename"`
+}
+
+type Verifier interface {
+	// Verify returns the validity of the secret, one of the secret.Validity*
+	// values
+	Verify(candidate Candidate) (string, error)
+}
+
+type chain []Verifier
+
+// New returns a verifier running the commands given by the user, and then the
+// built-in offline heuristics. The first conclusive result is used
+func New(options []flagtypes.SecretVerifier) Verifier {
+	var result chain
+	for _, verifierOptions := range options {
+		result = append(result, &commandVerifier{
+			command: verifierOptions.Command,
+			ruleIDs: verifierOptions.Rules,
+		})
+	}
+
+	return append(result, placeholderVerifier{}, checksumVerifier{}, entropyVerifier{})
+}
+
+func (verifiers chain) Verify(candidate Candidate) (string, error) {
+	for _, verifier := range verifiers {
+		validity, err := verifier.Verify(candidate)
+		if err != nil {
+			// a failing verifier shouldn't fail the scan
+			log.Warn().Msgf("failed to verify %s secret in %s: %s", candidate.RuleID, candidate.Filename, err)
+			continue
+		}
+
+		if validity != secret.ValidityUnknown {
+			return validity, nil
+		}
+	}
+
+	return secret.ValidityUnknown, nil
+}
@@ -0,0 +1,87 @@
+package graphql
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bearer/bearer/pkg/parser"
+	reporttypes "github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/frameworks/graphql"
+)
+
+var (
+	// authDirectivePattern matches the names of directives which restrict
+	// access to a type or field, eg. `@auth(requires: ADMIN)`, `@hasRole` or the
+	// AppSync `@aws_cognito_user_pools`
+	authDirectivePattern = regexp.MustCompile(`(?i)auth($|[^o])|authori[sz]|role|permission|scope|policy|login|protect|^aws_(iam|cognito|oidc|lambda)`)
+
+	// authCodePattern matches code which checks authorization, in resolvers and
+	// middleware. `author` is a common field name, and doesn't count
+	authCodePattern = regexp.MustCompile(`(?i)auth($|[^o])|authori[sz]|current_?user|login_required|require_?login|permission|has_?role|is_?admin|forbidden|jwt`)
+)
+
+// reportObjectType reports an object type (or type extension) with its fields
+func reportObjectType(objectNode, nameNode *parser.Node, report reporttypes.Report) {
+	declaration := graphql.Declaration{
+		Kind:       graphql.KindObjectType,
+		TypeName:   nameNode.Content(),
+		Authorized: hasAuthDirective(objectNode),
+	}
+
+	for i := 0; i < objectNode.ChildCount(); i++ {
+		fields := objectNode.Child(i)
+		if fields.Type() != "fields_definition" {
+			continue
+		}
+
+		for j := 0; j < fields.ChildCount(); j++ {
+			field := fields.Child(j)
+			if field.Type() != "field_definition" {
+				continue
+			}
+
+			name := childOfType(field, "name")
+			typeNode := childOfType(field, "type")
+			if name == nil || typeNode == nil {
+				continue
+			}
+
+			declaration.Fields = append(declaration.Fields, graphql.Field{
+				Name:       name.Content(),
+				Type:       strings.Trim(typeNode.Content(), "[]! \t\n"),
+				Authorized: hasAuthDirective(field),
+			})
+		}
+	}
+
+	report.AddFramework(detectors.DetectorGraphQL, graphql.TypeDeclaration, declaration, nameNode.Source(false))
+}
+
+// hasAuthDirective returns true when the type or field has an authorization
+// directive
+func hasAuthDirective(node *parser.Node) bool {
+	directives := childOfType(node, "directives")
+	if directives == nil {
+		return false
+	}
+
+	for i := 0; i < directives.ChildCount(); i++ {
+		name := childOfType(directives.Child(i), "name")
+		if name != nil && authDirectivePattern.MatchString(name.Content()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func childOfType(node *parser.Node, nodeType string) *parser.Node {
+	for i := 0; i < node.ChildCount(); i++ {
+		if child := node.Child(i); child.Type() == nodeType {
+			return child
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package graphql
 
 import (
+	"os"
 	"strings"
 
 	"github.com/bearer/bearer/pkg/detectors/types"
@@ -30,6 +31,13 @@ var (
 			)
 	)
 	`)
+
+	graphqlObjectQuery = parser.QueryMustCompile(language, `
+	[
+		(object_type_definition (name) @object_name) @object
+		(object_type_extension (name) @object_name) @object
+	]
+	`)
 )
 
 type detector struct {
@@ -60,7 +68,24 @@ func (detector *detector) ExtractFromSchema(
 	file *file.FileInfo,
 	report reporttypes.Report,
 ) error {
-	tree, err := parser.ParseFile(file, file.Path, language)
+	input, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return err
+	}
+
+	return extractSchema(detector.idGenerator, file, input, 0, report)
+}
+
+// extractSchema reports the object types of an SDL schema, which may be
+// embedded in another file starting after the given line offset
+func extractSchema(
+	idGenerator nodeid.Generator,
+	file *file.FileInfo,
+	input []byte,
+	lineOffset int,
+	report reporttypes.Report,
+) error {
+	tree, err := parser.ParseBytes(file, file.Path, input, language, lineOffset)
 	if err != nil {
 		return err
 	}
@@ -76,8 +101,8 @@ func (detector *detector) ExtractFromSchema(
 		fieldNode := captures["field_name"]
 		fieldName := stripQuotes(fieldNode.Content())
 
-		objectUUID := uuidHolder.Assign(objectNode.ID(), detector.idGenerator)
-		fieldUUID := uuidHolder.Assign(fieldNode.ID(), detector.idGenerator)
+		objectUUID := uuidHolder.Assign(objectNode.ID(), idGenerator)
+		fieldUUID := uuidHolder.Assign(fieldNode.ID(), idGenerator)
 
 		normalizedObjectName := ""
 		normalizedFieldName := ""
@@ -96,7 +121,7 @@ func (detector *detector) ExtractFromSchema(
 		}
 
 		if report.SchemaGroupShouldClose(objectName) {
-			report.SchemaGroupEnd(detector.idGenerator)
+			report.SchemaGroupEnd(idGenerator)
 		}
 
 		if !report.SchemaGroupIsOpen() {
@@ -119,9 +144,15 @@ func (detector *detector) ExtractFromSchema(
 		return nil
 	})
 
-	report.SchemaGroupEnd(detector.idGenerator)
+	report.SchemaGroupEnd(idGenerator)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return tree.Query(graphqlObjectQuery, func(captures parser.Captures) error {
+		reportObjectType(captures["object"], captures["object_name"], report)
+		return nil
+	})
 }
 
 func stripQuotes(value string) string {
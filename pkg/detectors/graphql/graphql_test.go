@@ -22,3 +22,20 @@ func TestBuildReportSchema(t *testing.T) {
 
 	cupaloy.SnapshotT(t, detectorReport.Detections)
 }
+
+func TestBuildReportDeclarations(t *testing.T) {
+	detectorReport := testhelper.Extract(t, filepath.Join("testdata", "declarations"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, detectorReport.Frameworks)
+}
+
+func TestBuildReportResolvers(t *testing.T) {
+	resolversRegistrations := []detectors.InitializedDetector{{
+		Type:     detectortypes.DetectorGraphQLResolvers,
+		Detector: graphql.NewResolvers(&nodeid.IntGenerator{Counter: 0}),
+	}}
+
+	detectorReport := testhelper.Extract(t, filepath.Join("testdata", "resolvers"), resolversRegistrations, detectortypes.DetectorGraphQLResolvers)
+
+	cupaloy.SnapshotT(t, detectorReport.Frameworks)
+}
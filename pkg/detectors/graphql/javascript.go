@@ -0,0 +1,112 @@
+package graphql
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+
+	"github.com/bearer/bearer/pkg/parser"
+	"github.com/bearer/bearer/pkg/parser/nodeid"
+	reporttypes "github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+// javascriptQueries are the queries for each of the JavaScript grammars
+type javascriptQueries struct {
+	language *sitter.Language
+	// schema matches SDL in a tagged template, eg. gql`type Query { ... }`
+	schema *sitter.Query
+	// typeResolvers matches the resolvers of a type in a resolver map (or the
+	// rules of a graphql-shield permission map), eg. `{ Query: { user: ... } }`
+	typeResolvers *sitter.Query
+	calls         *sitter.Query
+}
+
+var javascriptLanguages = map[string]*javascriptQueries{
+	"JavaScript": newJavascriptQueries(javascript.GetLanguage()),
+	"TypeScript": newJavascriptQueries(typescript.GetLanguage()),
+	"TSX":        newJavascriptQueries(tsx.GetLanguage()),
+}
+
+func newJavascriptQueries(language *sitter.Language) *javascriptQueries {
+	return &javascriptQueries{
+		language: language,
+		schema: parser.QueryMustCompile(language, `
+		(call_expression
+			function: (identifier) @tag
+			arguments: (template_string) @schema)
+		`),
+		typeResolvers: parser.QueryMustCompile(language, `
+		(pair
+			key: [(property_identifier) (string)] @type_name
+			value: (object) @resolvers)
+		`),
+		calls: parser.QueryMustCompile(language, `
+		(call_expression arguments: (arguments) @arguments) @call
+		`),
+	}
+}
+
+func extractJavascript(
+	idGenerator nodeid.Generator,
+	file *file.FileInfo,
+	input []byte,
+	report reporttypes.Report,
+) error {
+	queries := javascriptLanguages[file.Language]
+
+	tree, err := parser.ParseBytes(file, file.Path, input, queries.language, 0)
+	if err != nil {
+		return err
+	}
+	defer tree.Close()
+
+	err = tree.Query(queries.schema, func(captures parser.Captures) error {
+		switch captures["tag"].Content() {
+		case "gql", "graphql":
+			schema := captures["schema"]
+			content := schema.Content()
+			return extractEmbeddedSchema(idGenerator, file, schema, content[1:len(content)-1], report)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tree.Query(queries.typeResolvers, func(captures parser.Captures) error {
+		typeName := stripQuotes(captures["type_name"].Content())
+		if !isTypeName(typeName) {
+			return nil
+		}
+
+		for _, resolver := range namedChildren(captures["resolvers"]) {
+			switch resolver.Type() {
+			case "pair":
+				key := resolver.ChildByFieldName("key")
+				value := resolver.ChildByFieldName("value")
+				reportResolver(key, typeName, stripQuotes(key.Content()), authCodePattern.MatchString(value.Content()), report)
+			case "method_definition":
+				name := resolver.ChildByFieldName("name")
+				reportResolver(name, typeName, stripQuotes(name.Content()), authCodePattern.MatchString(resolver.Content()), report)
+			case "shorthand_property_identifier":
+				reportResolver(resolver, typeName, resolver.Content(), false, report)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return tree.Query(queries.calls, func(captures parser.Captures) error {
+		if isAuthenticatedEndpoint(namedChildren(captures["arguments"])) {
+			reportMiddleware(captures["call"], report)
+		}
+
+		return nil
+	})
+}
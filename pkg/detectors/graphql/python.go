@@ -0,0 +1,206 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/smacker/go-tree-sitter/python"
+
+	"github.com/bearer/bearer/pkg/parser"
+	"github.com/bearer/bearer/pkg/parser/nodeid"
+	reporttypes "github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+var (
+	pythonLanguage = python.GetLanguage()
+
+	// pythonSchemaQuery matches SDL passed to Ariadne's `gql`
+	pythonSchemaQuery = parser.QueryMustCompile(pythonLanguage, `
+	(call
+		function: (identifier) @function
+		arguments: (argument_list . (string (string_content) @schema)))
+	`)
+
+	// pythonBindableQuery matches Ariadne bindables, eg. `user = ObjectType("User")`
+	pythonBindableQuery = parser.QueryMustCompile(pythonLanguage, `
+	(assignment
+		left: (identifier) @name
+		right: (call
+			function: [(identifier) (attribute)] @constructor
+			arguments: (argument_list) @arguments))
+	`)
+
+	// pythonAriadneResolverQuery matches Ariadne resolvers, eg.
+	// `@query.field("user")`
+	pythonAriadneResolverQuery = parser.QueryMustCompile(pythonLanguage, `
+	(decorated_definition
+		(decorator
+			(call
+				function: (attribute
+					object: (identifier) @bindable
+					attribute: (identifier) @method)
+				arguments: (argument_list . (string (string_content) @field_name)))) @decorator
+		definition: (function_definition)) @resolver
+	`)
+
+	// pythonClassQuery matches classes which may be Graphene or Strawberry types
+	pythonClassQuery = parser.QueryMustCompile(pythonLanguage, `
+	(class_definition
+		name: (identifier) @type_name
+		body: (block) @body)
+	`)
+
+	pythonCallQuery = parser.QueryMustCompile(pythonLanguage, `
+	(call arguments: (argument_list) @arguments) @call
+	`)
+
+	// ariadneRootTypes are the bindables for the root types
+	ariadneRootTypes = map[string]string{
+		"QueryType":        "Query",
+		"MutationType":     "Mutation",
+		"SubscriptionType": "Subscription",
+	}
+)
+
+func extractPython(
+	idGenerator nodeid.Generator,
+	file *file.FileInfo,
+	input []byte,
+	report reporttypes.Report,
+) error {
+	tree, err := parser.ParseBytes(file, file.Path, input, pythonLanguage, 0)
+	if err != nil {
+		return err
+	}
+	defer tree.Close()
+
+	err = tree.Query(pythonSchemaQuery, func(captures parser.Captures) error {
+		if captures["function"].Content() != "gql" {
+			return nil
+		}
+
+		schema := captures["schema"]
+		return extractEmbeddedSchema(idGenerator, file, schema, schema.Content(), report)
+	})
+	if err != nil {
+		return err
+	}
+
+	bindables := make(map[string]string)
+	err = tree.Query(pythonBindableQuery, func(captures parser.Captures) error {
+		constructor := captures["constructor"].Content()
+		constructor = constructor[strings.LastIndex(constructor, ".")+1:]
+
+		if typeName, isRoot := ariadneRootTypes[constructor]; isRoot {
+			bindables[captures["name"].Content()] = typeName
+			return nil
+		}
+
+		if constructor == "ObjectType" || constructor == "InterfaceType" {
+			arguments := captures["arguments"]
+			if arguments.ChildCount() != 0 && arguments.Child(0).Type() == "string" {
+				bindables[captures["name"].Content()] = stripQuotes(arguments.Child(0).Content())
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tree.Query(pythonAriadneResolverQuery, func(captures parser.Captures) error {
+		typeName, isBindable := bindables[captures["bindable"].Content()]
+		if !isBindable || captures["method"].Content() != "field" {
+			return nil
+		}
+
+		fieldName := captures["field_name"]
+		reportResolver(
+			fieldName,
+			typeName,
+			fieldName.Content(),
+			isAuthorizedPythonResolver(captures["resolver"], captures["decorator"]),
+			report,
+		)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tree.Query(pythonClassQuery, func(captures parser.Captures) error {
+		extractPythonClassResolvers(captures["type_name"].Content(), captures["body"], report)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return tree.Query(pythonCallQuery, func(captures parser.Captures) error {
+		if isAuthenticatedEndpoint(namedChildren(captures["arguments"])) {
+			reportMiddleware(captures["call"], report)
+		}
+
+		return nil
+	})
+}
+
+// extractPythonClassResolvers reports the resolvers of a Graphene type
+// (`resolve_<field>` methods) or a Strawberry type (methods decorated with
+// `@strawberry.field`)
+func extractPythonClassResolvers(typeName string, body *parser.Node, report reporttypes.Report) {
+	for _, statement := range namedChildren(body) {
+		definition := statement
+		var decorators []*parser.Node
+
+		if statement.Type() == "decorated_definition" {
+			definition = statement.ChildByFieldName("definition")
+			for _, child := range namedChildren(statement) {
+				if child.Type() == "decorator" {
+					decorators = append(decorators, child)
+				}
+			}
+		}
+
+		if definition == nil || definition.Type() != "function_definition" {
+			continue
+		}
+
+		name := definition.ChildByFieldName("name")
+		fieldName, isResolver := strings.CutPrefix(name.Content(), "resolve_")
+		if !isResolver {
+			fieldName = name.Content()
+
+			for _, decorator := range decorators {
+				if strings.HasPrefix(decorator.Content(), "@strawberry.field") {
+					isResolver = true
+				}
+			}
+		}
+
+		if isResolver {
+			reportResolver(name, typeName, fieldName, isAuthorizedPythonResolver(statement, nil), report)
+		}
+	}
+}
+
+// isAuthorizedPythonResolver returns true when the resolver has an
+// authorization decorator (other than the one binding the resolver), or checks
+// authorization in its body
+func isAuthorizedPythonResolver(resolver *parser.Node, bindingDecorator *parser.Node) bool {
+	definition := resolver
+	if resolver.Type() == "decorated_definition" {
+		definition = resolver.ChildByFieldName("definition")
+
+		for _, child := range namedChildren(resolver) {
+			if child.Type() == "decorator" && !child.Equal(bindingDecorator) && authCodePattern.MatchString(child.Content()) {
+				return true
+			}
+		}
+	}
+
+	body := definition.ChildByFieldName("body")
+	return body != nil && authCodePattern.MatchString(body.Content())
+}
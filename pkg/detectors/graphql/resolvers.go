@@ -0,0 +1,151 @@
+package graphql
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/bearer/bearer/pkg/detectors/types"
+	"github.com/bearer/bearer/pkg/parser"
+	"github.com/bearer/bearer/pkg/parser/nodeid"
+	reporttypes "github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/frameworks/graphql"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+// resolverMarkerPattern matches files which may contain GraphQL resolvers,
+// middleware or schemas, to avoid parsing every file
+var resolverMarkerPattern = regexp.MustCompile(`(?i:graphql|gql|graphene|ariadne|strawberry)|Query|resolve_|Types::`)
+
+type extractFunc func(
+	idGenerator nodeid.Generator,
+	file *file.FileInfo,
+	input []byte,
+	report reporttypes.Report,
+) error
+
+type resolversDetector struct {
+	idGenerator nodeid.Generator
+}
+
+// NewResolvers detects the GraphQL resolvers and middleware of JavaScript,
+// TypeScript, Python and Ruby code, along with any SDL schema embedded in it
+// (eg. in a gql`...` template). It never consumes a file, so that the language
+// detectors still process it afterwards
+func NewResolvers(idGenerator nodeid.Generator) types.Detector {
+	return &resolversDetector{idGenerator: idGenerator}
+}
+
+func (detector *resolversDetector) AcceptDir(dir *file.Path) (bool, error) {
+	return true, nil
+}
+
+func (detector *resolversDetector) ProcessFile(file *file.FileInfo, dir *file.Path, report reporttypes.Report) (bool, error) {
+	var extract extractFunc
+
+	switch file.Language {
+	case "JavaScript", "TypeScript", "TSX":
+		extract = extractJavascript
+	case "Python":
+		extract = extractPython
+	case "Ruby":
+		extract = extractRuby
+	default:
+		return false, nil
+	}
+
+	input, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return false, err
+	}
+
+	if !resolverMarkerPattern.Match(input) {
+		return false, nil
+	}
+
+	return false, extract(detector.idGenerator, file, input, report)
+}
+
+// extractEmbeddedSchema reports the object types of an SDL schema embedded in
+// a string literal
+func extractEmbeddedSchema(
+	idGenerator nodeid.Generator,
+	file *file.FileInfo,
+	stringNode *parser.Node,
+	content string,
+	report reporttypes.Report,
+) error {
+	if !strings.Contains(content, "type ") {
+		return nil
+	}
+
+	return extractSchema(idGenerator, file, []byte(content), stringNode.StartLineNumber()-1, report)
+}
+
+func reportResolver(node *parser.Node, typeName, fieldName string, authorized bool, report reporttypes.Report) {
+	report.AddFramework(
+		detectors.DetectorGraphQLResolvers,
+		graphql.TypeDeclaration,
+		graphql.Declaration{
+			Kind:       graphql.KindResolver,
+			TypeName:   typeName,
+			FieldName:  fieldName,
+			Authorized: authorized,
+		},
+		node.Source(false),
+	)
+}
+
+// isAuthenticatedEndpoint returns true when the arguments of a call mount the
+// GraphQL endpoint behind authentication, eg.
+// `app.use("/graphql", authenticate, graphqlHTTP(...))`
+func isAuthenticatedEndpoint(arguments []*parser.Node) bool {
+	isGraphQL := false
+	authorized := false
+
+	for _, argument := range arguments {
+		switch argument.Type() {
+		case "string", "template_string":
+			if strings.Contains(strings.ToLower(argument.Content()), "graphql") {
+				isGraphQL = true
+			}
+		case "call_expression", "call":
+			if function := argument.ChildByFieldName("function"); function != nil && authCodePattern.MatchString(function.Content()) {
+				authorized = true
+			}
+		case "identifier", "member_expression", "attribute":
+			if authCodePattern.MatchString(argument.Content()) {
+				authorized = true
+			}
+		}
+	}
+
+	return isGraphQL && authorized
+}
+
+// reportMiddleware reports middleware authenticating all GraphQL requests
+func reportMiddleware(node *parser.Node, report reporttypes.Report) {
+	report.AddFramework(
+		detectors.DetectorGraphQLResolvers,
+		graphql.TypeDeclaration,
+		graphql.Declaration{
+			Kind:       graphql.KindMiddleware,
+			Authorized: true,
+		},
+		node.Source(false),
+	)
+}
+
+func namedChildren(node *parser.Node) []*parser.Node {
+	children := make([]*parser.Node, node.ChildCount())
+	for i := range children {
+		children[i] = node.Child(i)
+	}
+
+	return children
+}
+
+func isTypeName(name string) bool {
+	return name != "" && name[0] >= 'A' && name[0] <= 'Z'
+}
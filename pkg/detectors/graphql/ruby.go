@@ -0,0 +1,95 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/smacker/go-tree-sitter/ruby"
+
+	"github.com/bearer/bearer/pkg/parser"
+	"github.com/bearer/bearer/pkg/parser/nodeid"
+	reporttypes "github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+var (
+	rubyLanguage = ruby.GetLanguage()
+
+	rubyClassQuery = parser.QueryMustCompile(rubyLanguage, `
+	(class
+		name: [(constant) (scope_resolution)] @class_name
+		superclass: (superclass)
+		body: (body_statement) @body)
+	`)
+)
+
+func extractRuby(
+	idGenerator nodeid.Generator,
+	file *file.FileInfo,
+	input []byte,
+	report reporttypes.Report,
+) error {
+	tree, err := parser.ParseBytes(file, file.Path, input, rubyLanguage, 0)
+	if err != nil {
+		return err
+	}
+	defer tree.Close()
+
+	return tree.Query(rubyClassQuery, func(captures parser.Captures) error {
+		className := captures["class_name"]
+		name := className.Content()
+		body := captures["body"]
+
+		switch {
+		case strings.HasSuffix(name, "Type"):
+			extractRubyTypeResolvers(className, body, report)
+		case strings.Contains(strings.ToLower(name), "graphql") && strings.HasSuffix(name, "Controller"):
+			extractRubyControllerMiddleware(body, report)
+		}
+
+		return nil
+	})
+}
+
+// extractRubyTypeResolvers reports the resolver methods of a graphql-ruby
+// type. A type which defines `self.authorized?` checks authorization for all
+// of its fields
+func extractRubyTypeResolvers(className *parser.Node, body *parser.Node, report reporttypes.Report) {
+	typeName := className.Content()
+
+	for _, statement := range namedChildren(body) {
+		name := statement.ChildByFieldName("name")
+		if name == nil {
+			continue
+		}
+
+		switch statement.Type() {
+		case "method":
+			reportResolver(name, typeName, name.Content(), authCodePattern.MatchString(statement.Content()), report)
+		case "singleton_method":
+			if name.Content() == "authorized?" {
+				reportResolver(className, typeName, "", true, report)
+			}
+		}
+	}
+}
+
+// extractRubyControllerMiddleware reports the controller serving GraphQL
+// requests when it authenticates them, eg. with
+// `before_action :authenticate_user!`
+func extractRubyControllerMiddleware(body *parser.Node, report reporttypes.Report) {
+	for _, statement := range namedChildren(body) {
+		if statement.Type() != "call" {
+			continue
+		}
+
+		method := statement.ChildByFieldName("method")
+		arguments := statement.ChildByFieldName("arguments")
+		if method == nil || method.Content() != "before_action" || arguments == nil {
+			continue
+		}
+
+		if authCodePattern.MatchString(arguments.Content()) {
+			reportMiddleware(statement, report)
+		}
+	}
+}
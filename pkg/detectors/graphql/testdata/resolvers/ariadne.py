@@ -0,0 +1,21 @@
+from ariadne import gql, QueryType, ObjectType
+
+type_defs = gql("""
+    type Query {
+        me: User
+    }
+""")
+
+query = QueryType()
+user = ObjectType("User")
+
+@query.field("me")
+@login_required
+def resolve_me(_, info):
+    return info.context["user"]
+
+@user.field("first_name")
+def resolve_first_name(obj, info):
+    return obj.first_name
+
+urlpatterns = [path("graphql", login_required(GraphQLView.as_view()))]
@@ -0,0 +1,19 @@
+import graphene
+import strawberry
+
+class Query(graphene.ObjectType):
+    user = graphene.Field(User)
+
+    def resolve_user(root, info, id):
+        return User.get(id)
+
+    def resolve_account(root, info):
+        if not info.context.user.is_authenticated:
+            raise PermissionDenied()
+        return info.context.user.account
+
+@strawberry.type
+class Mutation:
+    @strawberry.field(permission_classes=[IsAuthenticated])
+    def update_user(self) -> User:
+        return update()
@@ -0,0 +1,23 @@
+module Types
+  class QueryType < Types::BaseObject
+    field :user, UserType, null: true
+
+    def user(id:)
+      User.find(id)
+    end
+
+    def current_account
+      context[:current_user].account
+    end
+  end
+
+  class AccountType < Types::BaseObject
+    def self.authorized?(object, context)
+      context[:current_user].present?
+    end
+  end
+end
+
+class GraphqlController < ApplicationController
+  before_action :authenticate_user!
+end
@@ -0,0 +1,29 @@
+const typeDefs = gql`
+  type Query {
+    user(id: ID!): User
+  }
+`;
+
+const resolvers = {
+  Query: {
+    user: (parent, args, context) => db.users.find(args.id),
+    me(parent, args, context) {
+      if (!context.currentUser) throw new AuthenticationError("not logged in");
+      return context.currentUser;
+    },
+    "account": getAccount,
+    posts,
+  },
+  Post: {
+    author: (post) => db.users.find(post.authorId),
+  },
+};
+
+const permissions = shield({
+  Query: {
+    account: isAuthenticated,
+  },
+});
+
+app.use("/graphql", authenticate, graphqlHTTP({ schema }));
+app.use("/public", graphqlHTTP({ schema }));
@@ -0,0 +1,5 @@
+const resolvers: Resolvers = {
+  Query: {
+    user: async (_parent, { id }, { dataSources }) => dataSources.users.find(id),
+  },
+};
@@ -0,0 +1,56 @@
+package iac
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/frameworks/iac"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+func isCloudFormation(document *yaml.Node) bool {
+	if mappingValue(document, "AWSTemplateFormatVersion") != nil {
+		return true
+	}
+
+	resources := mappingValue(document, "Resources")
+	if resources == nil || resources.Kind != yaml.MappingNode {
+		return false
+	}
+
+	for i := 1; i < len(resources.Content); i += 2 {
+		if strings.HasPrefix(scalarValue(resources.Content[i], "Type"), "AWS::") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func extractCloudFormation(file *file.FileInfo, document *yaml.Node, report report.Report) {
+	resources := mappingValue(document, "Resources")
+	if resources == nil || resources.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(resources.Content); i += 2 {
+		nameNode := resources.Content[i]
+		resource := resources.Content[i+1]
+
+		resourceType := scalarValue(resource, "Type")
+		spec, supported := cloudFormationResources[resourceType]
+		if !supported {
+			continue
+		}
+
+		report.AddFramework(
+			detectors.DetectorIaC,
+			iac.TypeDataStore,
+			dataStore(nameNode.Value, resourceType, spec, yamlAttributes{node: mappingValue(resource, "Properties")}),
+			yamlSource(file, nameNode),
+		)
+	}
+}
@@ -0,0 +1,73 @@
+package iac
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/pkg/detectors/types"
+	"github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+var (
+	cloudFormationMarker = []byte("AWS::")
+	kubernetesMarker     = []byte("apiVersion")
+)
+
+type detector struct{}
+
+func New() types.Detector {
+	return &detector{}
+}
+
+func (detector *detector) AcceptDir(dir *file.Path) (bool, error) {
+	return true, nil
+}
+
+func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, report report.Report) (bool, error) {
+	switch filepath.Ext(file.Base) {
+	case ".tf":
+		return true, extractTerraform(file, report)
+	case ".yml", ".yaml", ".json", ".template":
+		// Allow other detectors (eg. "yaml_config") to process the file
+		return false, extractYAML(file, report)
+	default:
+		return false, nil
+	}
+}
+
+func extractYAML(file *file.FileInfo, report report.Report) error {
+	content, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Contains(content, cloudFormationMarker) && !bytes.Contains(content, kubernetesMarker) {
+		return nil
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var root yaml.Node
+		if err := decoder.Decode(&root); err != nil {
+			// either the end of the file, or not valid YAML (eg. a template) and so
+			// not a manifest we can read
+			return nil
+		}
+
+		if len(root.Content) == 0 {
+			continue
+		}
+
+		document := root.Content[0]
+		switch {
+		case isCloudFormation(document):
+			extractCloudFormation(file, document, report)
+		case isKubernetes(document):
+			extractKubernetes(file, document, report)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package iac_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/pkg/detectors"
+	detectortypes "github.com/bearer/bearer/pkg/report/detectors"
+
+	"github.com/bearer/bearer/pkg/detectors/iac"
+	"github.com/bearer/bearer/pkg/detectors/internal/testhelper"
+)
+
+var detectorType = detectortypes.DetectorIaC
+var (
+	registrations = []detectors.InitializedDetector{{Type: detectorType, Detector: iac.New()}}
+)
+
+func TestBuildReportTerraform(t *testing.T) {
+	report := testhelper.Extract(t, filepath.Join("testdata", "terraform"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, report.Frameworks)
+}
+
+func TestBuildReportCloudFormation(t *testing.T) {
+	report := testhelper.Extract(t, filepath.Join("testdata", "cloudformation"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, report.Frameworks)
+}
+
+func TestBuildReportKubernetes(t *testing.T) {
+	report := testhelper.Extract(t, filepath.Join("testdata", "kubernetes"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, report.Frameworks)
+}
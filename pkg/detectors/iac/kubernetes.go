@@ -0,0 +1,86 @@
+package iac
+
+import (
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/frameworks/iac"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+var storageClassSpec = resourceSpec{
+	kind:       iac.KindVolume,
+	encryption: []setting{{path: "parameters.encrypted"}},
+}
+
+func isKubernetes(document *yaml.Node) bool {
+	return scalarValue(document, "apiVersion") != "" && scalarValue(document, "kind") != ""
+}
+
+func extractKubernetes(file *file.FileInfo, document *yaml.Node, report report.Report) {
+	kind := scalarValue(document, "kind")
+	metadata := mappingValue(document, "metadata")
+	nameNode := mappingValue(metadata, "name")
+	if nameNode == nil {
+		return
+	}
+
+	switch kind {
+	case "StorageClass":
+		report.AddFramework(
+			detectors.DetectorIaC,
+			iac.TypeDataStore,
+			dataStore(nameNode.Value, kind, storageClassSpec, yamlAttributes{node: document}),
+			yamlSource(file, nameNode),
+		)
+	case "StatefulSet", "Deployment":
+		podSpec := mappingValue(mappingValue(mappingValue(document, "spec"), "template"), "spec")
+		containers := mappingValue(podSpec, "containers")
+		if containers == nil || containers.Kind != yaml.SequenceNode {
+			return
+		}
+
+		for _, container := range containers.Content {
+			imageNode := mappingValue(container, "image")
+			if imageNode == nil {
+				continue
+			}
+
+			engine := imageEngine(imageNode.Value)
+			if engine == "" {
+				continue
+			}
+
+			dataStoreKind := iac.KindDatabase
+			if engine == "redis" || engine == "memcached" {
+				dataStoreKind = iac.KindCache
+			}
+
+			report.AddFramework(
+				detectors.DetectorIaC,
+				iac.TypeDataStore,
+				iac.DataStore{
+					Name:         nameNode.Value,
+					ResourceType: kind,
+					Kind:         dataStoreKind,
+					Engine:       engine,
+				},
+				yamlSource(file, imageNode),
+			)
+		}
+	}
+}
+
+// imageEngine returns the engine of a data store container image, eg.
+// `bitnami/postgresql:15` is postgres
+func imageEngine(image string) string {
+	name := path.Base(image)
+	name = strings.SplitN(name, "@", 2)[0]
+	name = strings.SplitN(name, ":", 2)[0]
+
+	return kubernetesImages[name]
+}
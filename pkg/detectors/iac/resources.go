@@ -0,0 +1,332 @@
+package iac
+
+import (
+	"strings"
+
+	"github.com/bearer/bearer/pkg/report/frameworks/iac"
+)
+
+// setting is an attribute of a resource which enables encryption or public
+// access
+type setting struct {
+	// path to the attribute, nested blocks/properties are separated by "."
+	path string
+	// values which enable the setting. When empty, a boolean true enables it
+	values []string
+	// present is true when the presence of the attribute enables the setting,
+	// whatever its value
+	present bool
+}
+
+type resourceSpec struct {
+	kind string
+	// engine is the path to the attribute holding the engine of the store
+	engine string
+	// defaultEngine is used when the engine attribute is absent, or when the
+	// resource type implies the engine
+	defaultEngine string
+	encryption    []setting
+	// encryptedByDefault is the encryption of the store when none of the
+	// encryption settings are present. nil when the default is unknown
+	encryptedByDefault *bool
+	public             []setting
+	publicByDefault    *bool
+}
+
+var (
+	enabled  = true
+	disabled = false
+)
+
+var terraformResources = map[string]resourceSpec{
+	"aws_db_instance": {
+		kind:               iac.KindDatabase,
+		engine:             "engine",
+		encryption:         []setting{{path: "storage_encrypted"}},
+		encryptedByDefault: &disabled,
+		public:             []setting{{path: "publicly_accessible"}},
+		publicByDefault:    &disabled,
+	},
+	"aws_rds_cluster": {
+		kind:               iac.KindDatabase,
+		engine:             "engine",
+		defaultEngine:      "aurora-mysql",
+		encryption:         []setting{{path: "storage_encrypted"}},
+		encryptedByDefault: &disabled,
+	},
+	"aws_docdb_cluster": {
+		kind:               iac.KindDatabase,
+		defaultEngine:      "docdb",
+		encryption:         []setting{{path: "storage_encrypted"}},
+		encryptedByDefault: &disabled,
+	},
+	"aws_redshift_cluster": {
+		kind:       iac.KindDatabase,
+		encryption: []setting{{path: "encrypted"}},
+		public:     []setting{{path: "publicly_accessible"}},
+	},
+	"aws_dynamodb_table": {
+		kind:               iac.KindDatabase,
+		encryptedByDefault: &enabled,
+	},
+	"aws_elasticache_replication_group": {
+		kind:               iac.KindCache,
+		engine:             "engine",
+		defaultEngine:      "redis",
+		encryption:         []setting{{path: "at_rest_encryption_enabled"}},
+		encryptedByDefault: &disabled,
+	},
+	"aws_elasticache_cluster": {
+		kind:               iac.KindCache,
+		engine:             "engine",
+		encryptedByDefault: &disabled,
+	},
+	"aws_s3_bucket": {
+		kind:               iac.KindObjectStorage,
+		encryption:         []setting{{path: "server_side_encryption_configuration", present: true}},
+		encryptedByDefault: &enabled,
+		public:             []setting{{path: "acl", values: []string{"public-read", "public-read-write"}}},
+		publicByDefault:    &disabled,
+	},
+	"aws_sqs_queue": {
+		kind: iac.KindMessageQueue,
+		encryption: []setting{
+			{path: "kms_master_key_id", present: true},
+			{path: "sqs_managed_sse_enabled"},
+		},
+	},
+	"aws_sns_topic": {
+		kind:               iac.KindMessageQueue,
+		encryption:         []setting{{path: "kms_master_key_id", present: true}},
+		encryptedByDefault: &disabled,
+	},
+	"aws_kinesis_stream": {
+		kind:               iac.KindMessageQueue,
+		encryption:         []setting{{path: "encryption_type", values: []string{"KMS"}}},
+		encryptedByDefault: &disabled,
+	},
+	"aws_ebs_volume": {
+		kind:               iac.KindVolume,
+		encryption:         []setting{{path: "encrypted"}},
+		encryptedByDefault: &disabled,
+	},
+	"aws_efs_file_system": {
+		kind:               iac.KindVolume,
+		encryption:         []setting{{path: "encrypted"}},
+		encryptedByDefault: &disabled,
+	},
+	"google_sql_database_instance": {
+		kind:               iac.KindDatabase,
+		engine:             "database_version",
+		encryptedByDefault: &enabled,
+		public: []setting{{
+			path:   "settings.ip_configuration.authorized_networks.value",
+			values: []string{"0.0.0.0/0"},
+		}},
+		publicByDefault: &disabled,
+	},
+	"google_storage_bucket": {
+		kind:               iac.KindObjectStorage,
+		encryptedByDefault: &enabled,
+	},
+	"azurerm_storage_account": {
+		kind:               iac.KindObjectStorage,
+		encryptedByDefault: &enabled,
+		public: []setting{
+			{path: "allow_nested_items_to_be_public"},
+			{path: "allow_blob_public_access"},
+		},
+	},
+	"azurerm_mssql_server": {
+		kind:               iac.KindDatabase,
+		defaultEngine:      "sqlserver",
+		encryptedByDefault: &enabled,
+		public:             []setting{{path: "public_network_access_enabled"}},
+		publicByDefault:    &enabled,
+	},
+	"azurerm_postgresql_server": {
+		kind:               iac.KindDatabase,
+		defaultEngine:      "postgres",
+		encryptedByDefault: &enabled,
+		public:             []setting{{path: "public_network_access_enabled"}},
+		publicByDefault:    &enabled,
+	},
+	"azurerm_mysql_server": {
+		kind:               iac.KindDatabase,
+		defaultEngine:      "mysql",
+		encryptedByDefault: &enabled,
+		public:             []setting{{path: "public_network_access_enabled"}},
+		publicByDefault:    &enabled,
+	},
+}
+
+var cloudFormationResources = map[string]resourceSpec{
+	"AWS::RDS::DBInstance": {
+		kind:               iac.KindDatabase,
+		engine:             "Engine",
+		encryption:         []setting{{path: "StorageEncrypted"}},
+		encryptedByDefault: &disabled,
+		public:             []setting{{path: "PubliclyAccessible"}},
+		publicByDefault:    &disabled,
+	},
+	"AWS::RDS::DBCluster": {
+		kind:               iac.KindDatabase,
+		engine:             "Engine",
+		encryption:         []setting{{path: "StorageEncrypted"}},
+		encryptedByDefault: &disabled,
+	},
+	"AWS::DocDB::DBCluster": {
+		kind:               iac.KindDatabase,
+		defaultEngine:      "docdb",
+		encryption:         []setting{{path: "StorageEncrypted"}},
+		encryptedByDefault: &disabled,
+	},
+	"AWS::Redshift::Cluster": {
+		kind:       iac.KindDatabase,
+		encryption: []setting{{path: "Encrypted"}},
+		public:     []setting{{path: "PubliclyAccessible"}},
+	},
+	"AWS::DynamoDB::Table": {
+		kind:               iac.KindDatabase,
+		encryptedByDefault: &enabled,
+	},
+	"AWS::ElastiCache::ReplicationGroup": {
+		kind:               iac.KindCache,
+		engine:             "Engine",
+		defaultEngine:      "redis",
+		encryption:         []setting{{path: "AtRestEncryptionEnabled"}},
+		encryptedByDefault: &disabled,
+	},
+	"AWS::ElastiCache::CacheCluster": {
+		kind:               iac.KindCache,
+		engine:             "Engine",
+		encryptedByDefault: &disabled,
+	},
+	"AWS::S3::Bucket": {
+		kind:               iac.KindObjectStorage,
+		encryption:         []setting{{path: "BucketEncryption", present: true}},
+		encryptedByDefault: &enabled,
+		public:             []setting{{path: "AccessControl", values: []string{"PublicRead", "PublicReadWrite"}}},
+		publicByDefault:    &disabled,
+	},
+	"AWS::SQS::Queue": {
+		kind: iac.KindMessageQueue,
+		encryption: []setting{
+			{path: "KmsMasterKeyId", present: true},
+			{path: "SqsManagedSseEnabled"},
+		},
+	},
+	"AWS::SNS::Topic": {
+		kind:               iac.KindMessageQueue,
+		encryption:         []setting{{path: "KmsMasterKeyId", present: true}},
+		encryptedByDefault: &disabled,
+	},
+	"AWS::Kinesis::Stream": {
+		kind:               iac.KindMessageQueue,
+		encryption:         []setting{{path: "StreamEncryption", present: true}},
+		encryptedByDefault: &disabled,
+	},
+	"AWS::EC2::Volume": {
+		kind:               iac.KindVolume,
+		encryption:         []setting{{path: "Encrypted"}},
+		encryptedByDefault: &disabled,
+	},
+	"AWS::EFS::FileSystem": {
+		kind:               iac.KindVolume,
+		encryption:         []setting{{path: "Encrypted"}},
+		encryptedByDefault: &disabled,
+	},
+}
+
+// kubernetesImages maps the container images of data stores deployed to a
+// cluster to their engine
+var kubernetesImages = map[string]string{
+	"postgres":      "postgres",
+	"postgresql":    "postgres",
+	"mysql":         "mysql",
+	"mariadb":       "mariadb",
+	"mongo":         "mongodb",
+	"mongodb":       "mongodb",
+	"redis":         "redis",
+	"memcached":     "memcached",
+	"elasticsearch": "elasticsearch",
+}
+
+// attributes gives access to the settings of a resource
+type attributes interface {
+	// lookup returns the value of the attribute at the path. known is false
+	// when the value isn't a literal (eg. a variable reference). found is false
+	// when the attribute is absent
+	lookup(path string) (value string, known bool, found bool)
+}
+
+func dataStore(name, resourceType string, spec resourceSpec, resource attributes) iac.DataStore {
+	engine := spec.defaultEngine
+	if spec.engine != "" {
+		if value, known, found := resource.lookup(spec.engine); found && known {
+			engine = value
+		}
+	}
+
+	return iac.DataStore{
+		Name:         name,
+		ResourceType: resourceType,
+		Kind:         spec.kind,
+		Engine:       engine,
+		Encrypted:    evaluate(resource, spec.encryption, spec.encryptedByDefault),
+		Public:       evaluate(resource, spec.public, spec.publicByDefault),
+	}
+}
+
+// evaluate returns whether any of the settings are enabled. The result is nil
+// if it can't be determined
+func evaluate(resource attributes, settings []setting, defaultValue *bool) *bool {
+	anyFound := false
+	anyUnknown := false
+
+	for _, setting := range settings {
+		value, known, found := resource.lookup(setting.path)
+		if !found {
+			continue
+		}
+
+		anyFound = true
+
+		if setting.present {
+			return &enabled
+		}
+
+		if !known {
+			anyUnknown = true
+			continue
+		}
+
+		if isEnabled(setting, value) {
+			return &enabled
+		}
+	}
+
+	if anyUnknown {
+		return nil
+	}
+
+	if anyFound {
+		return &disabled
+	}
+
+	return defaultValue
+}
+
+func isEnabled(setting setting, value string) bool {
+	if len(setting.values) == 0 {
+		return strings.EqualFold(value, "true")
+	}
+
+	for _, enabledValue := range setting.values {
+		if value == enabledValue {
+			return true
+		}
+	}
+
+	return false
+}
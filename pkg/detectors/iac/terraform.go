@@ -0,0 +1,146 @@
+package iac
+
+import (
+	"strings"
+
+	"github.com/smacker/go-tree-sitter/hcl"
+
+	"github.com/bearer/bearer/pkg/parser"
+	"github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/frameworks/iac"
+	"github.com/bearer/bearer/pkg/report/source"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+var (
+	hclLanguage = hcl.GetLanguage()
+
+	resourceQuery = parser.QueryMustCompile(hclLanguage, `
+		(block
+			(identifier) @keyword
+			.
+			(string_lit (template_literal) @type)
+			.
+			(string_lit (template_literal) @name) @label
+			(body) @body
+			(#eq? @keyword "resource")) @block
+	`)
+)
+
+func extractTerraform(file *file.FileInfo, report report.Report) error {
+	tree, err := parser.ParseFile(file, file.Path, hclLanguage)
+	if err != nil {
+		return err
+	}
+	defer tree.Close()
+
+	return tree.Query(resourceQuery, func(captures parser.Captures) error {
+		resourceType := captures["type"].Content()
+		spec, supported := terraformResources[resourceType]
+		if !supported {
+			return nil
+		}
+
+		block := captures["block"]
+		label := captures["label"]
+
+		report.AddFramework(
+			detectors.DetectorIaC,
+			iac.TypeDataStore,
+			dataStore(captures["name"].Content(), resourceType, spec, hclAttributes{body: captures["body"]}),
+			source.New(
+				file,
+				file.Path,
+				block.StartLineNumber(),
+				block.StartColumnNumber(),
+				label.EndLineNumber(),
+				label.EndColumnNumber(),
+				"",
+			),
+		)
+
+		return nil
+	})
+}
+
+type hclAttributes struct {
+	body *parser.Node
+}
+
+func (attributes hclAttributes) lookup(path string) (string, bool, bool) {
+	return lookupHCL(attributes.body, strings.Split(path, "."))
+}
+
+func lookupHCL(body *parser.Node, path []string) (string, bool, bool) {
+	for i := 0; i < body.ChildCount(); i++ {
+		child := body.Child(i)
+		if child.ChildCount() == 0 || child.Child(0).Content() != path[0] {
+			continue
+		}
+
+		switch child.Type() {
+		case "attribute":
+			if len(path) == 1 {
+				value, known := hclValue(child.Child(child.ChildCount() - 1))
+				return value, known, true
+			}
+		case "block":
+			if len(path) == 1 {
+				return "", false, true
+			}
+
+			nestedBody := hclBlockBody(child)
+			if nestedBody == nil {
+				continue
+			}
+
+			if value, known, found := lookupHCL(nestedBody, path[1:]); found {
+				return value, known, found
+			}
+		}
+	}
+
+	return "", false, false
+}
+
+func hclBlockBody(block *parser.Node) *parser.Node {
+	for i := 0; i < block.ChildCount(); i++ {
+		if child := block.Child(i); child.Type() == "body" {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// hclValue returns the value of a literal expression
+func hclValue(expression *parser.Node) (string, bool) {
+	if expression.Type() != "expression" || expression.ChildCount() != 1 {
+		return "", false
+	}
+
+	literal := expression.Child(0)
+	if literal.Type() != "literal_value" || literal.ChildCount() != 1 {
+		return "", false
+	}
+
+	value := literal.Child(0)
+	if value.Type() != "string_lit" {
+		return value.Content(), true
+	}
+
+	content := ""
+	for i := 0; i < value.ChildCount(); i++ {
+		switch child := value.Child(i); child.Type() {
+		case "template_literal":
+			content = child.Content()
+		case "quoted_template_start", "quoted_template_end":
+		default:
+			// eg. an interpolation
+			return "", false
+		}
+	}
+
+	return content, true
+}
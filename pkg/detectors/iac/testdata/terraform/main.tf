@@ -0,0 +1,46 @@
+variable "public" {
+  type = bool
+}
+
+resource "aws_db_instance" "users" {
+  engine              = "postgres"
+  instance_class      = "db.t3.micro"
+  storage_encrypted   = false
+  publicly_accessible = true
+}
+
+resource "aws_db_instance" "orders" {
+  engine              = "mysql"
+  storage_encrypted   = true
+  publicly_accessible = var.public
+}
+
+resource "aws_s3_bucket" "uploads" {
+  bucket = "uploads"
+  acl    = "public-read"
+}
+
+resource "aws_sqs_queue" "events" {
+  name              = "events"
+  kms_master_key_id = aws_kms_key.events.arn
+}
+
+resource "aws_elasticache_replication_group" "sessions" {
+  replication_group_id = "sessions"
+}
+
+resource "google_sql_database_instance" "analytics" {
+  database_version = "POSTGRES_14"
+
+  settings {
+    ip_configuration {
+      authorized_networks {
+        value = "0.0.0.0/0"
+      }
+    }
+  }
+}
+
+resource "aws_instance" "web" {
+  ami = "ami-123"
+}
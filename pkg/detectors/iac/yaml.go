@@ -0,0 +1,79 @@
+package iac
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/pkg/report/source"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+type yamlAttributes struct {
+	node *yaml.Node
+}
+
+func (attributes yamlAttributes) lookup(path string) (string, bool, bool) {
+	node := attributes.node
+	for _, key := range strings.Split(path, ".") {
+		node = mappingValue(node, key)
+		if node == nil {
+			return "", false, false
+		}
+	}
+
+	value, known := yamlValue(node)
+	return value, known, true
+}
+
+// mappingValue returns the value for the key of a mapping node, or nil if the
+// key is absent
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func scalarValue(node *yaml.Node, key string) string {
+	value := mappingValue(node, key)
+	if value == nil || value.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return value.Value
+}
+
+// yamlValue returns the value of a literal scalar. Custom tags (eg. `!Ref`) and
+// intrinsic functions (eg. `Fn::GetAtt`) are not literals
+func yamlValue(node *yaml.Node) (string, bool) {
+	if node.Kind != yaml.ScalarNode {
+		return "", false
+	}
+
+	switch node.Tag {
+	case "!!str", "!!bool", "!!int", "!!float":
+		return node.Value, true
+	default:
+		return "", false
+	}
+}
+
+func yamlSource(fileInfo *file.FileInfo, node *yaml.Node) source.Source {
+	return source.New(
+		fileInfo,
+		fileInfo.Path,
+		node.Line,
+		node.Column,
+		node.Line,
+		node.Column+len(node.Value),
+		"",
+	)
+}
@@ -0,0 +1,250 @@
+// Package endpoints links the operations of an OpenAPI spec to the parameters
+// and schema properties of their requests and responses
+package endpoints
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	reporttypes "github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/frameworks/openapi"
+	"github.com/bearer/bearer/pkg/report/operations"
+	"github.com/bearer/bearer/pkg/report/source"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+var httpMethods = map[string]string{
+	"get":     operations.TypeGet,
+	"post":    operations.TypePost,
+	"put":     operations.TypePut,
+	"delete":  operations.TypeDelete,
+	"patch":   operations.TypePatch,
+	"head":    operations.TypeHead,
+	"options": operations.TypeOptions,
+	"trace":   operations.TypeTrace,
+}
+
+// schemaKeys are the keys of a schema holding nested schemas
+var schemaKeys = []string{"items", "additionalProperties", "not"}
+
+// schemaListKeys are the keys of a schema holding lists of schemas
+var schemaListKeys = []string{"allOf", "anyOf", "oneOf"}
+
+type extractor struct {
+	document *yaml.Node
+	endpoint *openapi.Endpoint
+	visited  map[visit]bool
+}
+
+// visit is a schema visited for a direction, to add the fields of schemas used
+// by both the request and the response, and to stop at recursive schemas
+type visit struct {
+	direction string
+	schema    *yaml.Node
+}
+
+// ProcessFile reports an endpoint for each operation of the spec. Both JSON and
+// YAML specs are parsed as YAML, which gives the positions of the fields
+func ProcessFile(file *file.FileInfo, report reporttypes.Report) error {
+	input, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return err
+	}
+
+	// YAML doesn't allow tabs for indentation, which is common in JSON
+	input = bytes.ReplaceAll(input, []byte("\t"), []byte(" "))
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(input, &root); err != nil {
+		return err
+	}
+
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	document := root.Content[0]
+	paths := mappingValue(document, "paths")
+	if paths == nil || paths.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		path := paths.Content[i]
+		pathItem := resolve(document, paths.Content[i+1])
+		if pathItem == nil || pathItem.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for j := 0; j+1 < len(pathItem.Content); j += 2 {
+			methodNode := pathItem.Content[j]
+			method, isOperation := httpMethods[strings.ToLower(methodNode.Value)]
+			if !isOperation {
+				continue
+			}
+
+			extractor := &extractor{
+				document: document,
+				endpoint: &openapi.Endpoint{HttpMethod: method, Path: path.Value},
+				visited:  make(map[visit]bool),
+			}
+
+			operation := resolve(document, pathItem.Content[j+1])
+			extractor.parameters(mappingValue(pathItem, "parameters"))
+			extractor.parameters(mappingValue(operation, "parameters"))
+			extractor.content(openapi.DirectionRequest, mappingValue(operation, "requestBody"))
+			extractor.responses(mappingValue(operation, "responses"))
+
+			report.AddFramework(
+				detectors.DetectorOpenAPI,
+				openapi.TypeEndpoint,
+				*extractor.endpoint,
+				yamlSource(file, methodNode),
+			)
+		}
+	}
+
+	return nil
+}
+
+// parameters adds the request parameters. The name of a parameter is its field,
+// except for OpenAPI 2 body parameters which have a schema
+func (extractor *extractor) parameters(parameters *yaml.Node) {
+	if parameters == nil || parameters.Kind != yaml.SequenceNode {
+		return
+	}
+
+	for _, parameter := range parameters.Content {
+		parameter = resolve(extractor.document, parameter)
+
+		if scalarValue(parameter, "in") == "body" {
+			extractor.schema(openapi.DirectionRequest, mappingValue(parameter, "schema"))
+			continue
+		}
+
+		if name := mappingValue(parameter, "name"); name != nil {
+			extractor.addField(openapi.DirectionRequest, name)
+		}
+	}
+}
+
+func (extractor *extractor) responses(responses *yaml.Node) {
+	if responses == nil || responses.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 1; i < len(responses.Content); i += 2 {
+		response := resolve(extractor.document, responses.Content[i])
+
+		// OpenAPI 2 responses have a schema instead of content
+		extractor.schema(openapi.DirectionResponse, mappingValue(response, "schema"))
+		extractor.content(openapi.DirectionResponse, response)
+	}
+}
+
+// content adds the schemas of each media type of an OpenAPI 3 request body or
+// response
+func (extractor *extractor) content(direction string, node *yaml.Node) {
+	content := mappingValue(resolve(extractor.document, node), "content")
+	if content == nil || content.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 1; i < len(content.Content); i += 2 {
+		extractor.schema(direction, mappingValue(content.Content[i], "schema"))
+	}
+}
+
+func (extractor *extractor) schema(direction string, schema *yaml.Node) {
+	schema = resolve(extractor.document, schema)
+	if schema == nil || schema.Kind != yaml.MappingNode || extractor.visited[visit{direction, schema}] {
+		return
+	}
+	extractor.visited[visit{direction, schema}] = true
+
+	if properties := mappingValue(schema, "properties"); properties != nil && properties.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(properties.Content); i += 2 {
+			extractor.addField(direction, properties.Content[i])
+			extractor.schema(direction, properties.Content[i+1])
+		}
+	}
+
+	for _, key := range schemaKeys {
+		extractor.schema(direction, mappingValue(schema, key))
+	}
+
+	for _, key := range schemaListKeys {
+		if schemas := mappingValue(schema, key); schemas != nil && schemas.Kind == yaml.SequenceNode {
+			for _, child := range schemas.Content {
+				extractor.schema(direction, child)
+			}
+		}
+	}
+}
+
+func (extractor *extractor) addField(direction string, name *yaml.Node) {
+	extractor.endpoint.Fields = append(extractor.endpoint.Fields, openapi.Field{
+		Direction:    direction,
+		LineNumber:   name.Line,
+		ColumnNumber: name.Column,
+	})
+}
+
+// resolve follows a local reference, eg. `$ref: "#/components/schemas/User"`.
+// References to other files are not followed
+func resolve(document *yaml.Node, node *yaml.Node) *yaml.Node {
+	for depth := 0; depth < 16; depth++ {
+		reference := scalarValue(node, "$ref")
+		pointer, isLocal := strings.CutPrefix(reference, "#/")
+		if !isLocal {
+			return node
+		}
+
+		node = document
+		for _, key := range strings.Split(pointer, "/") {
+			key = strings.ReplaceAll(strings.ReplaceAll(key, "~1", "/"), "~0", "~")
+			node = mappingValue(node, key)
+		}
+	}
+
+	return node
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func scalarValue(node *yaml.Node, key string) string {
+	value := mappingValue(node, key)
+	if value == nil || value.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return value.Value
+}
+
+func yamlSource(fileInfo *file.FileInfo, node *yaml.Node) source.Source {
+	return source.New(
+		fileInfo,
+		fileInfo.Path,
+		node.Line,
+		node.Column,
+		node.Line,
+		node.Column+len(node.Value),
+		"",
+	)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 
+	"github.com/bearer/bearer/pkg/detectors/openapi/endpoints"
 	"github.com/bearer/bearer/pkg/detectors/openapi/v2json"
 	"github.com/bearer/bearer/pkg/parser/nodeid"
 	"github.com/bearer/bearer/pkg/util/file"
@@ -49,18 +50,27 @@ func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, repor
 		return false, nil
 	}
 
+	var processed bool
 	switch fileType {
 	case detectors.OpenApi2JSONFile:
-		return v2json.ProcessFile(detector.idGenerator, file, report)
+		processed, err = v2json.ProcessFile(detector.idGenerator, file, report)
 	case detectors.OpenApi2YAMLFile:
-		return v2yaml.ProcessFile(detector.idGenerator, file, report)
+		processed, err = v2yaml.ProcessFile(detector.idGenerator, file, report)
 	case detectors.OpenApi3JSONFile:
-		return v3json.ProcessFile(detector.idGenerator, file, report)
+		processed, err = v3json.ProcessFile(detector.idGenerator, file, report)
 	case detectors.OpenApi3YAMLFile:
-		return v3yaml.ProcessFile(detector.idGenerator, file, report)
+		processed, err = v3yaml.ProcessFile(detector.idGenerator, file, report)
 	}
 
-	return false, nil
+	if !processed || err != nil {
+		return processed, err
+	}
+
+	if err := endpoints.ProcessFile(file, report); err != nil {
+		log.Debug().Msgf("error extracting OpenAPI endpoints: %s", err.Error())
+	}
+
+	return true, nil
 }
 
 func getFileType(file *file.FileInfo) (detectors.OpenAPIFileType, error) {
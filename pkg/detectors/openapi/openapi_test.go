@@ -46,3 +46,15 @@ func TestOtherjson(t *testing.T) {
 
 	cupaloy.SnapshotT(t, report.Detections)
 }
+
+func TestEndpointsV3yaml(t *testing.T) {
+	report := testhelper.Extract(t, filepath.Join("testdata", "v3yaml"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, report.Frameworks)
+}
+
+func TestEndpointsV2yaml(t *testing.T) {
+	report := testhelper.Extract(t, filepath.Join("testdata", "v2yaml"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, report.Frameworks)
+}
@@ -0,0 +1,109 @@
+package orm
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/bearer/bearer/pkg/parser"
+	"github.com/bearer/bearer/pkg/parser/nodeid"
+	reporttypes "github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+var alembicOperationQuery = parser.QueryMustCompile(pythonLanguage, `
+	(call
+		function: (attribute
+			object: (identifier) @object
+			attribute: (identifier) @operation)
+		arguments: (argument_list . (string) @table_name) @arguments) @call
+`)
+
+// extractAlembic reads the columns of the tables created or altered by a
+// migration, eg.
+//
+//	op.create_table("users", sa.Column("email", sa.String()))
+//	op.add_column("users", sa.Column("phone", sa.String()))
+//
+// Columns added to the same table are grouped together
+func extractAlembic(
+	idGenerator nodeid.Generator,
+	file *file.FileInfo,
+	report reporttypes.Report,
+) error {
+	input, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Contains(input, []byte("alembic")) {
+		return nil
+	}
+
+	tree, err := parser.ParseBytes(file, file.Path, input, pythonLanguage, 0)
+	if err != nil {
+		return err
+	}
+	defer tree.Close()
+
+	var tables []*table
+	tablesByName := make(map[string]*table)
+
+	err = tree.Query(alembicOperationQuery, func(captures parser.Captures) error {
+		if captures["object"].Content() != "op" {
+			return nil
+		}
+
+		operation := captures["operation"].Content()
+		if operation != "create_table" && operation != "add_column" {
+			return nil
+		}
+
+		tableNode := captures["table_name"]
+		tableName := stripQuotes(tableNode.Content())
+
+		currentTable, exists := tablesByName[tableName]
+		if !exists {
+			currentTable = &table{node: tableNode, name: tableName, parent: captures["call"]}
+			tablesByName[tableName] = currentTable
+			tables = append(tables, currentTable)
+		}
+
+		arguments := captures["arguments"]
+		for i := 0; i < arguments.ChildCount(); i++ {
+			call := arguments.Child(i)
+			if call.Type() != "call" || pythonFunctionName(call) != "Column" {
+				continue
+			}
+
+			columnArguments := call.ChildByFieldName("arguments")
+			if columnArguments == nil || columnArguments.ChildCount() == 0 {
+				continue
+			}
+
+			nameNode := columnArguments.Child(0)
+			if nameNode.Type() != "string" {
+				continue
+			}
+
+			columnType := pythonColumnType(columnArguments)
+			currentTable.columns = append(currentTable.columns, column{
+				node:       nameNode,
+				name:       stripQuotes(nameNode.Content()),
+				columnType: columnType,
+				simpleType: convertSQLAlchemyType(columnType),
+			})
+		}
+
+		return nil
+	})
+
+	result := make([]table, len(tables))
+	for i, table := range tables {
+		result[i] = *table
+	}
+
+	reportTables(idGenerator, report, detectors.DetectorAlembic, result)
+
+	return err
+}
@@ -0,0 +1,124 @@
+package orm
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/smacker/go-tree-sitter/golang"
+
+	"github.com/bearer/bearer/pkg/parser"
+	"github.com/bearer/bearer/pkg/parser/nodeid"
+	reporttypes "github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/schema"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+var (
+	goLanguage = golang.GetLanguage()
+
+	entFieldsQuery = parser.QueryMustCompile(goLanguage, `
+	(method_declaration
+		receiver: (parameter_list
+			(parameter_declaration type: (type_identifier) @object_name))
+		name: (field_identifier) @method_name
+		body: (block) @body) @method
+	`)
+
+	entFieldQuery = parser.QueryMustCompile(goLanguage, `
+	(call_expression
+		function: (selector_expression
+			operand: (identifier) @package
+			field: (field_identifier) @field_type)
+		arguments: (argument_list . (interpreted_string_literal) @field_name))
+	`)
+)
+
+// extractEnt reads the fields of Ent schemas, eg.
+//
+//	func (User) Fields() []ent.Field {
+//		return []ent.Field{
+//			field.String("email").Unique(),
+//		}
+//	}
+func extractEnt(
+	idGenerator nodeid.Generator,
+	file *file.FileInfo,
+	report reporttypes.Report,
+) error {
+	input, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Contains(input, []byte("entgo.io/ent")) {
+		return nil
+	}
+
+	tree, err := parser.ParseBytes(file, file.Path, input, goLanguage, 0)
+	if err != nil {
+		return err
+	}
+	defer tree.Close()
+
+	var tables []table
+	err = tree.Query(entFieldsQuery, func(captures parser.Captures) error {
+		if captures["method_name"].Content() != "Fields" {
+			return nil
+		}
+
+		table := table{
+			node:   captures["object_name"],
+			name:   captures["object_name"].Content(),
+			parent: captures["method"],
+		}
+
+		err := captures["body"].Query(entFieldQuery, func(fieldCaptures parser.Captures) error {
+			if fieldCaptures["package"].Content() != "field" {
+				return nil
+			}
+
+			nameNode := fieldCaptures["field_name"]
+			columnType := fieldCaptures["field_type"].Content()
+
+			table.columns = append(table.columns, column{
+				node:       nameNode,
+				name:       stripQuotes(nameNode.Content()),
+				columnType: columnType,
+				simpleType: convertEntType(columnType),
+			})
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		tables = append(tables, table)
+
+		return nil
+	})
+
+	reportTables(idGenerator, report, detectors.DetectorEnt, tables)
+
+	return err
+}
+
+func convertEntType(value string) string {
+	switch value {
+	case "String", "Text", "Enum", "UUID":
+		return schema.SimpleTypeString
+	case "Int", "Int8", "Int16", "Int32", "Int64",
+		"Uint", "Uint8", "Uint16", "Uint32", "Uint64",
+		"Float", "Float32":
+		return schema.SimpleTypeNumber
+	case "Bool":
+		return schema.SimpleTypeBool
+	case "Time":
+		return schema.SimpleTypeDate
+	case "Bytes":
+		return schema.SimpleTypeBinary
+	default:
+		return schema.SimpleTypeObject
+	}
+}
@@ -0,0 +1,170 @@
+// Package orm extracts the tables and columns declared by ORM models,
+// schema files and migrations. These are reported as stored data types.
+//
+// The detectors never consume a file, so that the language detectors still
+// process it afterwards
+package orm
+
+import (
+	"strings"
+
+	"github.com/bearer/bearer/pkg/detectors/types"
+	"github.com/bearer/bearer/pkg/parser"
+	"github.com/bearer/bearer/pkg/parser/nodeid"
+	parserschema "github.com/bearer/bearer/pkg/parser/schema"
+	reporttypes "github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/schema"
+	"github.com/bearer/bearer/pkg/util/file"
+	"github.com/bearer/bearer/pkg/util/pluralize"
+)
+
+type extractFunc func(
+	idGenerator nodeid.Generator,
+	file *file.FileInfo,
+	report reporttypes.Report,
+) error
+
+type detector struct {
+	idGenerator nodeid.Generator
+	accept      func(file *file.FileInfo) bool
+	extract     extractFunc
+}
+
+// NewPrisma detects the models of Prisma schema files
+func NewPrisma(idGenerator nodeid.Generator) types.Detector {
+	return &detector{
+		idGenerator: idGenerator,
+		accept:      func(file *file.FileInfo) bool { return file.Extension == ".prisma" },
+		extract:     extractPrisma,
+	}
+}
+
+// NewTypeORM detects TypeORM and Sequelize entities declared with decorators
+func NewTypeORM(idGenerator nodeid.Generator) types.Detector {
+	return &detector{
+		idGenerator: idGenerator,
+		accept:      func(file *file.FileInfo) bool { return file.Language == "TypeScript" },
+		extract:     extractTypeORM,
+	}
+}
+
+// NewSQLAlchemy detects SQLAlchemy declarative models
+func NewSQLAlchemy(idGenerator nodeid.Generator) types.Detector {
+	return &detector{
+		idGenerator: idGenerator,
+		accept:      func(file *file.FileInfo) bool { return file.Language == "Python" },
+		extract:     extractSQLAlchemy,
+	}
+}
+
+// NewAlembic detects the tables created or altered by Alembic migrations
+func NewAlembic(idGenerator nodeid.Generator) types.Detector {
+	return &detector{
+		idGenerator: idGenerator,
+		accept:      func(file *file.FileInfo) bool { return file.Language == "Python" },
+		extract:     extractAlembic,
+	}
+}
+
+// NewEnt detects the fields of Ent schemas
+func NewEnt(idGenerator nodeid.Generator) types.Detector {
+	return &detector{
+		idGenerator: idGenerator,
+		accept:      func(file *file.FileInfo) bool { return file.Language == "Go" },
+		extract:     extractEnt,
+	}
+}
+
+func (detector *detector) AcceptDir(dir *file.Path) (bool, error) {
+	return true, nil
+}
+
+func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, report reporttypes.Report) (bool, error) {
+	if !detector.accept(file) {
+		return false, nil
+	}
+
+	return false, detector.extract(detector.idGenerator, file, report)
+}
+
+type column struct {
+	node       *parser.Node
+	name       string
+	columnType string
+	simpleType string
+}
+
+type table struct {
+	node    *parser.Node
+	name    string
+	columns []column
+	// parent is the declaration of the whole table, used as the source of the
+	// columns
+	parent *parser.Node
+}
+
+func reportTables(
+	idGenerator nodeid.Generator,
+	report reporttypes.Report,
+	detectorType detectors.Type,
+	tables []table,
+) {
+	uuidHolder := parserschema.NewUUIDHolder()
+
+	for _, table := range tables {
+		if len(table.columns) == 0 {
+			continue
+		}
+
+		objectUUID := uuidHolder.Assign(table.node.ID(), idGenerator)
+		normalizedObjectName := pluralize.Singular(strings.ToLower(table.name))
+
+		for _, column := range table.columns {
+			currentSchema := schema.Schema{
+				ObjectName:           table.name,
+				ObjectUUID:           objectUUID,
+				FieldName:            column.name,
+				FieldUUID:            uuidHolder.Assign(column.node.ID(), idGenerator),
+				FieldType:            column.columnType,
+				SimpleFieldType:      column.simpleType,
+				NormalizedObjectName: normalizedObjectName,
+				NormalizedFieldName:  pluralize.Singular(strings.ToLower(column.name)),
+			}
+
+			if !report.SchemaGroupIsOpen() {
+				source := table.node.Source(false)
+				report.SchemaGroupBegin(
+					detectorType,
+					table.node,
+					currentSchema,
+					&source,
+					table.parent,
+				)
+			}
+			source := column.node.Source(false)
+			report.SchemaGroupAddItem(
+				column.node,
+				currentSchema,
+				&source,
+			)
+		}
+
+		report.SchemaGroupEnd(idGenerator)
+	}
+}
+
+func childOfType(node *parser.Node, nodeType string) *parser.Node {
+	for i := 0; i < node.ChildCount(); i++ {
+		child := node.Child(i)
+		if child.Type() == nodeType {
+			return child
+		}
+	}
+
+	return nil
+}
+
+func stripQuotes(value string) string {
+	return strings.Trim(value, "\"'`")
+}
@@ -0,0 +1,51 @@
+package orm_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/pkg/detectors"
+	"github.com/bearer/bearer/pkg/detectors/internal/testhelper"
+	"github.com/bearer/bearer/pkg/detectors/orm"
+	"github.com/bearer/bearer/pkg/detectors/types"
+	"github.com/bearer/bearer/pkg/parser/nodeid"
+	detectortypes "github.com/bearer/bearer/pkg/report/detectors"
+)
+
+func TestPrisma(t *testing.T) {
+	runTest(t, "prisma", detectortypes.DetectorPrisma, orm.NewPrisma)
+}
+
+func TestTypeORM(t *testing.T) {
+	runTest(t, "typeorm", detectortypes.DetectorTypeORM, orm.NewTypeORM)
+}
+
+func TestSQLAlchemy(t *testing.T) {
+	runTest(t, "sqlalchemy", detectortypes.DetectorSQLAlchemy, orm.NewSQLAlchemy)
+}
+
+func TestAlembic(t *testing.T) {
+	runTest(t, "alembic", detectortypes.DetectorAlembic, orm.NewAlembic)
+}
+
+func TestEnt(t *testing.T) {
+	runTest(t, "ent", detectortypes.DetectorEnt, orm.NewEnt)
+}
+
+func runTest(
+	t *testing.T,
+	project string,
+	detectorType detectortypes.Type,
+	newDetector func(idGenerator nodeid.Generator) types.Detector,
+) {
+	registrations := []detectors.InitializedDetector{{
+		Type:     detectorType,
+		Detector: newDetector(&nodeid.IntGenerator{Counter: 0}),
+	}}
+
+	detectorReport := testhelper.Extract(t, filepath.Join("testdata", project), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, detectorReport.Detections)
+}
@@ -0,0 +1,150 @@
+package orm
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+
+	"github.com/bearer/bearer/pkg/parser"
+	"github.com/bearer/bearer/pkg/parser/nodeid"
+	"github.com/bearer/bearer/pkg/parser/sitter/graphql"
+	reporttypes "github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/schema"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+var (
+	graphqlLanguage = graphql.GetLanguage()
+
+	prismaModelQuery = parser.QueryMustCompile(graphqlLanguage, `
+	(object_type_definition
+		(name) @object_name
+		(fields_definition) @fields) @model
+	`)
+
+	prismaBlockStartPattern = regexp.MustCompile(`^\s*(model|type)(\s+\w+\s*\{)`)
+	prismaFieldPattern      = regexp.MustCompile(`^(\s*\w+)(\s)(\s*)(\w+)`)
+)
+
+// extractPrisma reads the models of a Prisma schema. There is no tree-sitter
+// grammar for Prisma, so the models are first rewritten as GraphQL object
+// types. Everything else is replaced with whitespace so that the positions of
+// the names are unchanged, eg.
+//
+//	model User {                          type  User {
+//	  email String? @unique       =>        email:String
+//	  @@map("users")
+//	}                                     }
+func extractPrisma(
+	idGenerator nodeid.Generator,
+	file *file.FileInfo,
+	report reporttypes.Report,
+) error {
+	input, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return err
+	}
+
+	tree, err := parser.ParseBytes(file, file.Path, prismaToGraphQL(input), graphqlLanguage, 0)
+	if err != nil {
+		return err
+	}
+	defer tree.Close()
+
+	var tables []table
+	err = tree.Query(prismaModelQuery, func(captures parser.Captures) error {
+		fields := captures["fields"]
+
+		table := table{
+			node:   captures["object_name"],
+			name:   captures["object_name"].Content(),
+			parent: captures["model"],
+		}
+
+		for i := 0; i < fields.ChildCount(); i++ {
+			field := fields.Child(i)
+			if field.Type() != "field_definition" {
+				continue
+			}
+
+			nameNode := childOfType(field, "name")
+			typeNode := childOfType(field, "type")
+			if nameNode == nil || typeNode == nil {
+				continue
+			}
+
+			columnType := typeNode.Content()
+			table.columns = append(table.columns, column{
+				node:       nameNode,
+				name:       nameNode.Content(),
+				columnType: columnType,
+				simpleType: convertPrismaType(columnType),
+			})
+		}
+
+		tables = append(tables, table)
+
+		return nil
+	})
+
+	reportTables(idGenerator, report, detectors.DetectorPrisma, tables)
+
+	return err
+}
+
+func prismaToGraphQL(input []byte) []byte {
+	lines := bytes.Split(input, []byte("\n"))
+	inBlock := false
+
+	for i, line := range lines {
+		result := bytes.Repeat([]byte(" "), len(line))
+
+		if !inBlock {
+			if match := prismaBlockStartPattern.FindSubmatchIndex(line); match != nil {
+				copy(result[match[2]:], "type")
+				copy(result[match[4]:], line[match[4]:match[5]])
+				inBlock = true
+			}
+
+			lines[i] = result
+			continue
+		}
+
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte("}")) {
+			inBlock = false
+			copy(result, line)
+			lines[i] = result
+			continue
+		}
+
+		// field name, separator and base type. Modifiers (`?` and `[]`),
+		// attributes and comments are dropped
+		if match := prismaFieldPattern.FindSubmatchIndex(line); match != nil {
+			copy(result, line[:match[3]])
+			result[match[4]] = ':'
+			copy(result[match[8]:], line[match[8]:match[9]])
+		}
+
+		lines[i] = result
+	}
+
+	return bytes.Join(lines, []byte("\n"))
+}
+
+func convertPrismaType(value string) string {
+	switch value {
+	case "String":
+		return schema.SimpleTypeString
+	case "Int", "BigInt", "Float", "Decimal":
+		return schema.SimpleTypeNumber
+	case "Boolean":
+		return schema.SimpleTypeBool
+	case "DateTime":
+		return schema.SimpleTypeDate
+	case "Bytes":
+		return schema.SimpleTypeBinary
+	default:
+		return schema.SimpleTypeObject
+	}
+}
@@ -0,0 +1,184 @@
+package orm
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/smacker/go-tree-sitter/python"
+
+	"github.com/bearer/bearer/pkg/parser"
+	"github.com/bearer/bearer/pkg/parser/nodeid"
+	reporttypes "github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/schema"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+var (
+	pythonLanguage = python.GetLanguage()
+
+	sqlAlchemyClassQuery = parser.QueryMustCompile(pythonLanguage, `
+	(class_definition
+		name: (identifier) @object_name
+		body: (block) @body) @class
+	`)
+)
+
+// extractSQLAlchemy reads the columns of declarative models, eg.
+//
+//	class User(Base):
+//	    email = Column(String)
+//	    name: Mapped[str] = mapped_column()
+func extractSQLAlchemy(
+	idGenerator nodeid.Generator,
+	file *file.FileInfo,
+	report reporttypes.Report,
+) error {
+	input, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Contains(input, []byte("Column(")) && !bytes.Contains(input, []byte("mapped_column(")) {
+		return nil
+	}
+
+	tree, err := parser.ParseBytes(file, file.Path, input, pythonLanguage, 0)
+	if err != nil {
+		return err
+	}
+	defer tree.Close()
+
+	var tables []table
+	err = tree.Query(sqlAlchemyClassQuery, func(captures parser.Captures) error {
+		table := table{
+			node:   captures["object_name"],
+			name:   captures["object_name"].Content(),
+			parent: captures["class"],
+		}
+
+		body := captures["body"]
+		for i := 0; i < body.ChildCount(); i++ {
+			statement := body.Child(i)
+			if statement.Type() != "expression_statement" || statement.ChildCount() == 0 {
+				continue
+			}
+
+			assignment := statement.Child(0)
+			if assignment.Type() != "assignment" {
+				continue
+			}
+
+			nameNode := assignment.ChildByFieldName("left")
+			call := assignment.ChildByFieldName("right")
+			if nameNode == nil || nameNode.Type() != "identifier" || call == nil || call.Type() != "call" {
+				continue
+			}
+
+			functionName := pythonFunctionName(call)
+			if functionName != "Column" && functionName != "mapped_column" {
+				continue
+			}
+
+			columnType := pythonColumnType(call.ChildByFieldName("arguments"))
+			if columnType == "" {
+				// eg. `name: Mapped[str] = mapped_column()`
+				columnType = mappedType(assignment.ChildByFieldName("type"))
+			}
+
+			table.columns = append(table.columns, column{
+				node:       nameNode,
+				name:       nameNode.Content(),
+				columnType: columnType,
+				simpleType: convertSQLAlchemyType(columnType),
+			})
+		}
+
+		tables = append(tables, table)
+
+		return nil
+	})
+
+	reportTables(idGenerator, report, detectors.DetectorSQLAlchemy, tables)
+
+	return err
+}
+
+// pythonFunctionName returns the name of the function called, ignoring the
+// module or object it is called on (eg. `Column` for `db.Column(...)`)
+func pythonFunctionName(call *parser.Node) string {
+	function := call.ChildByFieldName("function")
+	if function == nil {
+		return ""
+	}
+
+	if function.Type() == "attribute" {
+		function = function.ChildByFieldName("attribute")
+	}
+
+	if function == nil || function.Type() != "identifier" {
+		return ""
+	}
+
+	return function.Content()
+}
+
+// pythonColumnType returns the name of the type of a column, ignoring any
+// column name given before it (eg. `String` for `Column("name", sa.String(255))`)
+func pythonColumnType(arguments *parser.Node) string {
+	if arguments == nil {
+		return ""
+	}
+
+	for i := 0; i < arguments.ChildCount(); i++ {
+		argument := arguments.Child(i)
+
+		switch argument.Type() {
+		case "call":
+			return pythonFunctionName(argument)
+		case "attribute":
+			return argument.ChildByFieldName("attribute").Content()
+		case "identifier":
+			return argument.Content()
+		case "keyword_argument":
+			return ""
+		}
+	}
+
+	return ""
+}
+
+// mappedType returns the type of a `Mapped[...]` annotation
+func mappedType(annotation *parser.Node) string {
+	if annotation == nil {
+		return ""
+	}
+
+	value := annotation.Content()
+	if !strings.HasPrefix(value, "Mapped[") {
+		return ""
+	}
+
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "Mapped["), "]")
+	value = strings.TrimPrefix(value, "Optional[")
+
+	return strings.TrimSuffix(value, "]")
+}
+
+func convertSQLAlchemyType(value string) string {
+	switch strings.ToLower(value) {
+	case "string", "text", "unicode", "unicodetext", "varchar", "char", "enum", "uuid", "uuidtype", "str":
+		return schema.SimpleTypeString
+	case "integer", "biginteger", "smallinteger", "float", "numeric", "decimal", "int":
+		return schema.SimpleTypeNumber
+	case "boolean", "bool":
+		return schema.SimpleTypeBool
+	case "date", "datetime", "time", "timestamp":
+		return schema.SimpleTypeDate
+	case "largebinary", "binary", "bytes":
+		return schema.SimpleTypeBinary
+	default:
+		return schema.SimpleTypeObject
+	}
+}
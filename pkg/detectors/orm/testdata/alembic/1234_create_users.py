@@ -0,0 +1,10 @@
+import sqlalchemy as sa
+from alembic import op
+
+def upgrade():
+    op.create_table(
+        "users",
+        sa.Column("id", sa.Integer(), nullable=False),
+        sa.Column("email", sa.String(length=255), nullable=False),
+    )
+    op.add_column("users", sa.Column("phone_number", sa.String()))
@@ -0,0 +1,18 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+type User struct {
+	ent.Schema
+}
+
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("email").Unique(),
+		field.Int("age").Positive(),
+		field.Time("created_at"),
+	}
+}
@@ -0,0 +1,13 @@
+from sqlalchemy import Column, Integer, String
+from sqlalchemy.orm import Mapped, mapped_column, relationship
+
+class User(Base):
+    __tablename__ = "users"
+
+    id = Column(Integer, primary_key=True)
+    email = Column(String(255), unique=True)
+    first_name: Mapped[str] = mapped_column(String(50))
+    addresses = relationship("Address")
+
+class Patient(db.Model):
+    date_of_birth = db.Column(db.Date)
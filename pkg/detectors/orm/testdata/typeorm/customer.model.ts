@@ -0,0 +1,10 @@
+import { Table, Column, Model, DataType } from "sequelize-typescript"
+
+@Table({ tableName: "customers" })
+export class Customer extends Model {
+  @Column(DataType.STRING)
+  email!: string
+
+  @Column
+  phoneNumber?: string
+}
@@ -0,0 +1,20 @@
+import { Entity, Column, PrimaryGeneratedColumn, OneToMany } from "typeorm"
+
+@Entity("users")
+export class User {
+  @PrimaryGeneratedColumn()
+  id: number
+
+  @Column({ unique: true })
+  email: string
+
+  @Column()
+  firstName: string
+
+  @OneToMany(() => Post, (post) => post.author)
+  posts: Post[]
+
+  fullName(): string {
+    return this.firstName
+  }
+}
@@ -0,0 +1,193 @@
+package orm
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+
+	"github.com/bearer/bearer/pkg/parser"
+	"github.com/bearer/bearer/pkg/parser/nodeid"
+	reporttypes "github.com/bearer/bearer/pkg/report"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/schema"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+var (
+	typescriptLanguage = typescript.GetLanguage()
+
+	typeORMClassQuery = parser.QueryMustCompile(typescriptLanguage, `
+	(class_declaration
+		name: (type_identifier) @object_name
+		body: (class_body) @body) @class
+	`)
+
+	// class decorators, by the detector of the ORM they belong to
+	entityDecorators = map[string]detectors.Type{
+		"Entity": detectors.DetectorTypeORM,
+		"Table":  detectors.DetectorSequelize,
+	}
+
+	columnDecorators = map[string]struct{}{
+		"Column":                 {},
+		"PrimaryColumn":          {},
+		"PrimaryGeneratedColumn": {},
+		"CreateDateColumn":       {},
+		"UpdateDateColumn":       {},
+		"DeleteDateColumn":       {},
+		"VersionColumn":          {},
+		"ObjectIdColumn":         {},
+	}
+)
+
+func extractTypeORM(
+	idGenerator nodeid.Generator,
+	file *file.FileInfo,
+	report reporttypes.Report,
+) error {
+	input, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Contains(input, []byte("@Entity")) && !bytes.Contains(input, []byte("@Table")) {
+		return nil
+	}
+
+	tree, err := parser.ParseBytes(file, file.Path, input, typescriptLanguage, 0)
+	if err != nil {
+		return err
+	}
+	defer tree.Close()
+
+	tablesByDetector := make(map[detectors.Type][]table)
+	err = tree.Query(typeORMClassQuery, func(captures parser.Captures) error {
+		class := captures["class"]
+
+		detectorType, isEntity := entityDetectorType(class)
+		if !isEntity {
+			return nil
+		}
+
+		table := table{
+			node:   captures["object_name"],
+			name:   captures["object_name"].Content(),
+			parent: class,
+		}
+
+		body := captures["body"]
+		for i := 0; i < body.ChildCount(); i++ {
+			field := body.Child(i)
+			if field.Type() != "public_field_definition" || !hasColumnDecorator(field) {
+				continue
+			}
+
+			nameNode := field.ChildByFieldName("name")
+			if nameNode == nil {
+				continue
+			}
+
+			columnType := ""
+			if typeNode := field.ChildByFieldName("type"); typeNode != nil {
+				columnType = strings.TrimSpace(strings.TrimPrefix(typeNode.Content(), ":"))
+			}
+
+			table.columns = append(table.columns, column{
+				node:       nameNode,
+				name:       stripQuotes(nameNode.Content()),
+				columnType: columnType,
+				simpleType: convertTypeScriptType(columnType),
+			})
+		}
+
+		tablesByDetector[detectorType] = append(tablesByDetector[detectorType], table)
+
+		return nil
+	})
+
+	reportTables(idGenerator, report, detectors.DetectorTypeORM, tablesByDetector[detectors.DetectorTypeORM])
+	reportTables(idGenerator, report, detectors.DetectorSequelize, tablesByDetector[detectors.DetectorSequelize])
+
+	return err
+}
+
+// entityDetectorType returns the ORM of an entity class. When the class is
+// exported, the decorators belong to the export statement
+func entityDetectorType(class *parser.Node) (detectors.Type, bool) {
+	decorated := []*parser.Node{class}
+	if parent := class.Parent(); parent != nil && parent.Type() == "export_statement" {
+		decorated = append(decorated, parent)
+	}
+
+	for _, node := range decorated {
+		for i := 0; i < node.ChildCount(); i++ {
+			if detectorType, isEntity := entityDecorators[decoratorName(node.Child(i))]; isEntity {
+				return detectorType, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func hasColumnDecorator(field *parser.Node) bool {
+	for i := 0; i < field.ChildCount(); i++ {
+		if _, isColumn := columnDecorators[decoratorName(field.Child(i))]; isColumn {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decoratorName returns the name of a decorator, with or without arguments
+// (eg. `@Column` or `@Column()`)
+func decoratorName(node *parser.Node) string {
+	if node.Type() != "decorator" || node.ChildCount() == 0 {
+		return ""
+	}
+
+	expression := node.Child(0)
+	if expression.Type() == "call_expression" {
+		expression = expression.ChildByFieldName("function")
+	}
+
+	if expression == nil || expression.Type() != "identifier" {
+		return ""
+	}
+
+	return expression.Content()
+}
+
+// convertTypeScriptType converts the type of a property. Nullable types (eg.
+// `string | null`) use the type of the non-null member
+func convertTypeScriptType(value string) string {
+	var members []string
+	for _, member := range strings.Split(value, "|") {
+		member = strings.TrimSpace(member)
+		if member != "null" && member != "undefined" {
+			members = append(members, member)
+		}
+	}
+
+	if len(members) != 1 {
+		return schema.SimpleTypeObject
+	}
+
+	switch members[0] {
+	case "string":
+		return schema.SimpleTypeString
+	case "number", "bigint":
+		return schema.SimpleTypeNumber
+	case "boolean":
+		return schema.SimpleTypeBool
+	case "Date":
+		return schema.SimpleTypeDate
+	case "Buffer":
+		return schema.SimpleTypeBinary
+	default:
+		return schema.SimpleTypeObject
+	}
+}
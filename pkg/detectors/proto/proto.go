@@ -1,6 +1,7 @@
 package proto
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/smacker/go-tree-sitter/protobuf"
@@ -8,7 +9,9 @@ import (
 	"github.com/bearer/bearer/pkg/detectors/types"
 	"github.com/bearer/bearer/pkg/parser"
 	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/interfaces"
 	"github.com/bearer/bearer/pkg/report/schema"
+	"github.com/bearer/bearer/pkg/report/values"
 	"github.com/bearer/bearer/pkg/util/file"
 	"github.com/bearer/bearer/pkg/util/pluralize"
 
@@ -18,21 +21,25 @@ import (
 )
 
 var (
-	language         = protobuf.GetLanguage()
+	language = protobuf.GetLanguage()
+
+	// nested messages are matched separately, so each message is reported as
+	// its own object
 	protoSchemaQuery = parser.QueryMustCompile(language, `
 	(
 		message
 		(message_name (identifier) @object_name)
-			(message_body
-				(
-					field (
-						(type) @field_type
-						(identifier) @field_name
-					)
-				)
-			)
+		(message_body) @body
 	)
 	`)
+
+	protoPackageQuery = parser.QueryMustCompile(language, `
+	(package (full_ident) @package)
+	`)
+
+	protoServiceQuery = parser.QueryMustCompile(language, `
+	(service (service_name (identifier) @service_name)) @service
+	`)
 )
 
 type detector struct {
@@ -74,58 +81,166 @@ func (detector *detector) ExtractFromSchema(
 	err = tree.Query(protoSchemaQuery, func(captures parser.Captures) error {
 		objectNode := captures["object_name"]
 		objectName := stripQuotes(objectNode.Content())
+		objectUUID := uuidHolder.Assign(objectNode.ID(), detector.idGenerator)
+		normalizedObjectName := pluralize.Singular(strings.ToLower(objectName))
 
-		columnType := stripQuotes(captures["field_type"].Content())
+		for _, field := range messageFields(captures["body"]) {
+			fieldNode := field.nameNode
+			fieldName := stripQuotes(fieldNode.Content())
+			fieldUUID := uuidHolder.Assign(fieldNode.ID(), detector.idGenerator)
 
-		fieldNode := captures["field_name"]
-		fieldName := stripQuotes(fieldNode.Content())
+			currentSchema := schema.Schema{
+				ObjectName:           objectName,
+				ObjectUUID:           objectUUID,
+				FieldName:            fieldName,
+				FieldUUID:            fieldUUID,
+				FieldType:            field.fieldType,
+				SimpleFieldType:      field.simpleType,
+				NormalizedObjectName: normalizedObjectName,
+				NormalizedFieldName:  pluralize.Singular(strings.ToLower(fieldName)),
+			}
 
-		objectUUID := uuidHolder.Assign(objectNode.ID(), detector.idGenerator)
-		fieldUUID := uuidHolder.Assign(fieldNode.ID(), detector.idGenerator)
-
-		normalizedObjectName := ""
-		normalizedFieldName := ""
-		normalizedObjectName = pluralize.Singular(strings.ToLower(objectName))
-		normalizedFieldName = pluralize.Singular(strings.ToLower(fieldName))
-
-		currentSchema := schema.Schema{
-			ObjectName:           objectName,
-			ObjectUUID:           objectUUID,
-			FieldName:            fieldName,
-			FieldUUID:            fieldUUID,
-			FieldType:            columnType,
-			SimpleFieldType:      convertToSimpleType(columnType),
-			NormalizedObjectName: normalizedObjectName,
-			NormalizedFieldName:  normalizedFieldName,
+			if !report.SchemaGroupIsOpen() {
+				source := objectNode.Source(true)
+				report.SchemaGroupBegin(
+					detectors.DetectorProto,
+					objectNode,
+					currentSchema,
+					&source,
+					nil,
+				)
+			}
+			source := fieldNode.Source(true)
+			report.SchemaGroupAddItem(
+				fieldNode,
+				currentSchema,
+				&source,
+			)
+		}
+
+		report.SchemaGroupEnd(detector.idGenerator)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return addServices(tree, report)
+}
+
+type messageField struct {
+	nameNode   *parser.Node
+	fieldType  string
+	simpleType string
+}
+
+func messageFields(body *parser.Node) []messageField {
+	var fields []messageField
+
+	for i := 0; i < body.ChildCount(); i++ {
+		child := body.Child(i)
+
+		switch child.Type() {
+		case "field":
+			if field := newMessageField(child); field != nil {
+				fields = append(fields, *field)
+			}
+		case "map_field":
+			if field := newMessageField(child); field != nil {
+				keyType := childOfType(child, "key_type")
+				if keyType != nil {
+					field.fieldType = fmt.Sprintf("map<%s, %s>", keyType.Content(), field.fieldType)
+				}
+				field.simpleType = schema.SimpleTypeObject
+				fields = append(fields, *field)
+			}
+		case "oneof":
+			for j := 0; j < child.ChildCount(); j++ {
+				oneofField := child.Child(j)
+				if oneofField.Type() != "oneof_field" {
+					continue
+				}
+
+				if field := newMessageField(oneofField); field != nil {
+					fields = append(fields, *field)
+				}
+			}
 		}
+	}
+
+	return fields
+}
+
+func newMessageField(node *parser.Node) *messageField {
+	typeNode := childOfType(node, "type")
+	nameNode := childOfType(node, "identifier")
+	if typeNode == nil || nameNode == nil {
+		return nil
+	}
+
+	fieldType := stripQuotes(typeNode.Content())
 
-		if report.SchemaGroupShouldClose(objectName) {
-			report.SchemaGroupEnd(detector.idGenerator)
+	return &messageField{
+		nameNode:   nameNode,
+		fieldType:  fieldType,
+		simpleType: convertToSimpleType(fieldType),
+	}
+}
+
+// addServices reports each gRPC method as an interface, using the path that
+// the call is made with over HTTP/2 (eg. `/acme.users.v1.UserService/GetUser`).
+// Streaming methods are called in the same way
+func addServices(tree *parser.Tree, report reporttypes.Report) error {
+	packageName := ""
+	err := tree.Query(protoPackageQuery, func(captures parser.Captures) error {
+		packageName = captures["package"].Content()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return tree.Query(protoServiceQuery, func(captures parser.Captures) error {
+		serviceName := captures["service_name"].Content()
+		if packageName != "" {
+			serviceName = packageName + "." + serviceName
 		}
 
-		if !report.SchemaGroupIsOpen() {
-			source := objectNode.Source(true)
-			report.SchemaGroupBegin(
-				detectors.DetectorProto,
-				objectNode,
-				currentSchema,
-				&source,
-				nil,
-			)
+		service := captures["service"]
+		for i := 0; i < service.ChildCount(); i++ {
+			rpc := service.Child(i)
+			if rpc.Type() != "rpc" {
+				continue
+			}
+
+			rpcName := childOfType(rpc, "rpc_name")
+			if rpcName == nil {
+				continue
+			}
+
+			value := values.New()
+			value.AppendString(fmt.Sprintf("/%s/%s", serviceName, rpcName.Content()))
+
+			report.AddInterface(detectors.DetectorProto, interfaces.Interface{
+				Type:  interfaces.TypePath,
+				Value: value,
+			}, rpcName.Source(true))
 		}
-		source := fieldNode.Source(true)
-		report.SchemaGroupAddItem(
-			fieldNode,
-			currentSchema,
-			&source,
-		)
 
 		return nil
 	})
+}
 
-	report.SchemaGroupEnd(detector.idGenerator)
+func childOfType(node *parser.Node, nodeType string) *parser.Node {
+	for i := 0; i < node.ChildCount(); i++ {
+		child := node.Child(i)
+		if child.Type() == nodeType {
+			return child
+		}
+	}
 
-	return err
+	return nil
 }
 
 func stripQuotes(value string) string {
@@ -133,7 +248,7 @@ func stripQuotes(value string) string {
 }
 
 func convertToSimpleType(value string) string {
-	numberMap := []string{"double", "float", "int32", "in64", "uint32", "uint64", "sint32", "sint64", "fixed32", "fixed64", "sfixed32", "sfixed64"}
+	numberMap := []string{"double", "float", "int32", "int64", "uint32", "uint64", "sint32", "sint64", "fixed32", "fixed64", "sfixed32", "sfixed64"}
 	for _, typeValue := range numberMap {
 		if typeValue == value {
 			return schema.SimpleTypeNumber
@@ -13,7 +13,7 @@ import (
 	"github.com/bradleyjkemp/cupaloy"
 )
 
-var detectorType = detectortypes.DetectorGraphQL
+var detectorType = detectortypes.DetectorProto
 var (
 	registrations = []detectors.InitializedDetector{{Type: detectorType, Detector: proto.New(&nodeid.IntGenerator{Counter: 0})}}
 )
@@ -23,3 +23,9 @@ func TestBuildReportSchema(t *testing.T) {
 
 	cupaloy.SnapshotT(t, detectorReport.Detections)
 }
+
+func TestBuildReportServices(t *testing.T) {
+	detectorReport := testhelper.Extract(t, filepath.Join("testdata", "services"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, detectorReport.Detections)
+}
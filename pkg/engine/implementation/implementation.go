@@ -50,13 +50,13 @@ func (engine *implementation) Scan(
 ) error {
 	if engine.orchestrator == nil {
 		var err error
-		engine.orchestrator, err = orchestrator.New(work.Repository{Dir: targetPath}, config, stats, len(files))
+		engine.orchestrator, err = orchestrator.New(config, stats, len(files))
 		if err != nil {
 			return err
 		}
 	}
 
-	return engine.orchestrator.Scan(reportPath, files)
+	return engine.orchestrator.Scan(work.Repository{Dir: targetPath}, reportPath, files)
 }
 
 func (engine *implementation) Close() {
@@ -0,0 +1,44 @@
+package flag
+
+import flagtypes "github.com/bearer/bearer/pkg/flag/types"
+
+type compareFlagGroup struct{ flagGroupBase }
+
+var CompareFlagGroup = &compareFlagGroup{flagGroupBase{name: "Compare"}}
+
+var (
+	CompareFormatFlag = CompareFlagGroup.add(flagtypes.Flag{
+		Name:       "format",
+		ConfigName: "compare.format",
+		Shorthand:  "f",
+		Value:      FormatEmpty,
+		Usage:      "Specify report format (json, html)",
+	})
+	CompareOutputFlag = CompareFlagGroup.add(flagtypes.Flag{
+		Name:       "output",
+		ConfigName: "compare.output",
+		Value:      "",
+		Usage:      "Specify the output path for the report.",
+	})
+)
+
+type CompareOptions struct {
+	OutputFormat string `mapstructure:"compare_format" json:"compare_format" yaml:"compare_format"`
+	OutputPath   string `mapstructure:"compare_output" json:"compare_output" yaml:"compare_output"`
+}
+
+func (compareFlagGroup) SetOptions(options *flagtypes.Options, args []string) error {
+	format := getString(CompareFormatFlag)
+	switch format {
+	case FormatEmpty, FormatJSON, FormatHTML:
+	default:
+		return ErrInvalidFormatCompare
+	}
+
+	options.CompareOptions = flagtypes.CompareOptions{
+		OutputFormat: format,
+		OutputPath:   getString(CompareOutputFlag),
+	}
+
+	return nil
+}
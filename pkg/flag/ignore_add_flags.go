@@ -22,6 +22,13 @@ var (
 		Usage:      "Add a comment to this ignored finding.",
 	})
 
+	IgnoreAddOwnerFlag = IgnoreAddFlagGroup.add(flagtypes.Flag{
+		Name:       "owner",
+		ConfigName: "ignore_add.owner",
+		Value:      FormatEmpty,
+		Usage:      "Add the owner who accepted the risk of this ignored finding e.g. --owner=@acme/payments.",
+	})
+
 	FalsePositiveFlag = IgnoreAddFlagGroup.add(flagtypes.Flag{
 		Name:       "false-positive",
 		ConfigName: "ignore_add.false-positive",
@@ -40,6 +47,7 @@ var (
 type IgnoreAddOptions struct {
 	Author        string `mapstructure:"author" json:"author" yaml:"author"`
 	Comment       string `mapstructure:"comment" json:"comment" yaml:"comment"`
+	Owner         string `mapstructure:"ignore_add_owner" json:"ignore_add_owner" yaml:"ignore_add_owner"`
 	FalsePositive bool   `mapstructure:"false_positive" json:"false_positive" yaml:"false_positive"`
 	Force         bool   `mapstructure:"ignore_add_force" json:"ignore_add_force" yaml:"ignore_add_force"`
 }
@@ -48,6 +56,7 @@ func (ignoreAddFlagGroup) SetOptions(options *flagtypes.Options, args []string)
 	options.IgnoreAddOptions = flagtypes.IgnoreAddOptions{
 		Author:        getString(AuthorFlag),
 		Comment:       getString(CommentFlag),
+		Owner:         getString(IgnoreAddOwnerFlag),
 		FalsePositive: getBool(FalsePositiveFlag),
 		Force:         getBool(IgnoreAddForceFlag),
 	}
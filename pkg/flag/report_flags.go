@@ -11,15 +11,17 @@ import (
 )
 
 var (
-	FormatReviewDog  = "rdjson"
-	FormatGitLabSast = "gitlab-sast"
-	FormatSarif      = "sarif"
-	FormatJSON       = "json"
-	FormatJSONV2     = "jsonv2"
-	FormatYAML       = "yaml"
-	FormatHTML       = "html"
-	FormatCSV        = "csv"
-	FormatEmpty      = ""
+	FormatReviewDog   = "rdjson"
+	FormatGitLabSast  = "gitlab-sast"
+	FormatJUnit       = "junit"
+	FormatCodeClimate = "codeclimate"
+	FormatSarif       = "sarif"
+	FormatJSON        = "json"
+	FormatJSONV2      = "jsonv2"
+	FormatYAML        = "yaml"
+	FormatHTML        = "html"
+	FormatCSV         = "csv"
+	FormatEmpty       = ""
 
 	ReportPrivacy   = "privacy"
 	ReportSecurity  = "security"
@@ -30,12 +32,14 @@ var (
 )
 
 var (
-	ErrInvalidFormatSecurity = errors.New("invalid format argument for security report; supported values: json, yaml, sarif, gitlab-sast, rdjson, html, jsonv2")
+	ErrInvalidFormatSecurity = errors.New("invalid format argument for security report; supported values: json, yaml, sarif, gitlab-sast, rdjson, html, jsonv2, junit, codeclimate")
 	ErrInvalidFormatPrivacy  = errors.New("invalid format argument for privacy report; supported values: csv, json, yaml, html")
 	ErrInvalidFormatDefault  = errors.New("invalid format argument; supported values: json, yaml")
 	ErrInvalidReport         = errors.New("invalid report argument; supported values: security, privacy")
 	ErrInvalidSeverity       = errors.New("invalid severity argument; supported values: " + strings.Join(globaltypes.Severities, ", "))
 	ErrInvalidFailOnSeverity = errors.New("invalid fail-on-severity argument; supported values: " + strings.Join(globaltypes.Severities, ", "))
+	ErrInvalidCompareTo      = errors.New("--compare-to can only be used with the security report")
+	ErrInvalidFormatCompare  = errors.New("invalid format argument for comparison; supported values: json, html")
 )
 
 type reportFlagGroup struct{ flagGroupBase }
@@ -48,7 +52,7 @@ var (
 		ConfigName: "report.format",
 		Shorthand:  "f",
 		Value:      FormatEmpty,
-		Usage:      "Specify report format (json, yaml, sarif, gitlab-sast, rdjson, html)",
+		Usage:      "Specify report format (json, yaml, sarif, gitlab-sast, rdjson, html, junit, codeclimate)",
 	})
 	ReportFlag = ReportFlagGroup.add(flagtypes.Flag{
 		Name:       "report",
@@ -101,6 +105,30 @@ var (
 		Value:      false,
 		Usage:      "Include language usage statistics in reports that support them.",
 	})
+	IncludeIgnoredFlag = ReportFlagGroup.add(flagtypes.Flag{
+		Name:       "include-ignored",
+		ConfigName: "report.include-ignored",
+		Value:      false,
+		Usage:      "Include ignored findings in the security report, marked as suppressed and with their ignore metadata.",
+	})
+	BlameFlag = ReportFlagGroup.add(flagtypes.Flag{
+		Name:       "blame",
+		ConfigName: "report.blame",
+		Value:      false,
+		Usage:      "Add the commit which last modified each finding, with its author and date, to the security report.",
+	})
+	OwnerFlag = ReportFlagGroup.add(flagtypes.Flag{
+		Name:       "owner",
+		ConfigName: "report.owner",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated owners from the CODEOWNERS file whose findings you would like to report e.g. --owner=@acme/payments.",
+	})
+	CompareToFlag = ReportFlagGroup.add(flagtypes.Flag{
+		Name:       "compare-to",
+		ConfigName: "report.compare-to",
+		Value:      "",
+		Usage:      "Specify the path of a previous security report in json or jsonv2 format, to report the new, fixed and unchanged findings since that report.",
+	})
 )
 
 type ReportOptions struct {
@@ -142,7 +170,7 @@ func (reportFlagGroup) SetOptions(options *flagtypes.Options, args []string) err
 		if report != ReportPrivacy {
 			return invalidFormat
 		}
-	case FormatSarif, FormatGitLabSast, FormatReviewDog, FormatJSONV2:
+	case FormatSarif, FormatGitLabSast, FormatReviewDog, FormatJSONV2, FormatJUnit, FormatCodeClimate:
 		if report != ReportSecurity {
 			return invalidFormat
 		}
@@ -150,6 +178,17 @@ func (reportFlagGroup) SetOptions(options *flagtypes.Options, args []string) err
 		return invalidFormat
 	}
 
+	compareTo := getString(CompareToFlag)
+	if compareTo != "" {
+		if report != ReportSecurity {
+			return ErrInvalidCompareTo
+		}
+
+		if format != FormatEmpty && format != FormatJSON && format != FormatHTML {
+			return ErrInvalidFormatCompare
+		}
+	}
+
 	severity := getSeverities(SeverityFlag)
 	if severity == nil {
 		return ErrInvalidSeverity
@@ -176,6 +215,10 @@ func (reportFlagGroup) SetOptions(options *flagtypes.Options, args []string) err
 		NoExtract:          getBool(NoExtractFlag),
 		NoRuleMeta:         getBool(NoRuleMetaFlag),
 		IncludeStats:       getBool(IncludeStatsFlag),
+		IncludeIgnored:     getBool(IncludeIgnoredFlag),
+		Blame:              getBool(BlameFlag),
+		Owner:              getStringSlice(OwnerFlag),
+		CompareTo:          compareTo,
 	}
 
 	return nil
@@ -79,6 +79,14 @@ var (
 		DisableInConfig: true,
 		Hide:            true,
 	})
+	DiffHeadCommitFlag = RepositoryFlagGroup.add(flagtypes.Flag{
+		Name:            "diff-head-commit",
+		ConfigName:      "repository.diff-head-commit",
+		Value:           "",
+		Usage:           "The hash of the commit to compare with the base for diff scanning, instead of the working tree.",
+		DisableInConfig: true,
+		Hide:            true,
+	})
 	GithubTokenFlag = RepositoryFlagGroup.add(flagtypes.Flag{
 		Name:       "github-token",
 		ConfigName: "repository.github-token",
@@ -134,6 +142,7 @@ func (repositoryFlagGroup) SetOptions(options *flagtypes.Options, args []string)
 		DefaultBranch:     getString(DefaultBranchFlag),
 		DiffBaseBranch:    getString(DiffBaseBranchFlag),
 		DiffBaseCommit:    getString(DiffBaseCommitFlag),
+		DiffHeadCommit:    getString(DiffHeadCommitFlag),
 		GithubToken:       getString(GithubTokenFlag),
 		GithubRepository:  getString(GithubRepositoryFlag),
 		GithubAPIURL:      getString(GithubAPIURLFlag),
@@ -1,11 +1,17 @@
 package flag
 
-import flagtypes "github.com/bearer/bearer/pkg/flag/types"
+import (
+	"errors"
+
+	flagtypes "github.com/bearer/bearer/pkg/flag/types"
+)
 
 type ruleFlagGroup struct{ flagGroupBase }
 
 var RuleFlagGroup = &ruleFlagGroup{flagGroupBase{name: "Rule"}}
 
+var ErrMissingRulesBundleChecksum = errors.New("missing rules-bundle-checksum argument; required when using a rules bundle")
+
 var (
 	DisableDefaultRulesFlag = RuleFlagGroup.add(flagtypes.Flag{
 		Name:       "disable-default-rules",
@@ -25,19 +31,55 @@ var (
 		Value:      []string{},
 		Usage:      "Specify the comma-separated ids of the rules you would like to run. Skips all other rules.",
 	})
+	RulesBundleFlag = RuleFlagGroup.add(flagtypes.Flag{
+		Name:       "rules-bundle",
+		ConfigName: "rule.rules-bundle",
+		Value:      "",
+		Usage:      "Load the default rules from a bundle created by 'bearer rules pull', instead of downloading them.",
+	})
+	RulesBundleChecksumFlag = RuleFlagGroup.add(flagtypes.Flag{
+		Name:       "rules-bundle-checksum",
+		ConfigName: "rule.rules-bundle-checksum",
+		Value:      "",
+		Usage:      "Specify the expected SHA-256 checksum of the rules bundle, as shown by 'bearer rules pull'. Required when using --rules-bundle.",
+	})
+	RulePackFlag = RuleFlagGroup.add(flagtypes.Flag{
+		Name:       "rule-pack",
+		ConfigName: "rule.rule-pack",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated rule packs to load e.g. --rule-pack=acme-rules@1.2.0,rules.git//ruby@v2.",
+	})
+	RulePackMirrorFlag = RuleFlagGroup.add(flagtypes.Flag{
+		Name:       "rule-pack-mirror",
+		ConfigName: "rule.rule-pack-mirror",
+		Value:      "",
+		Usage:      "Specify the local directory to resolve rule packs from.",
+	})
 )
 
 type RuleOptions struct {
 	DisableDefaultRules bool            `mapstructure:"disable-default-rules" json:"disable-default-rules" yaml:"disable-default-rules"`
 	SkipRule            map[string]bool `mapstructure:"skip-rule" json:"skip-rule" yaml:"skip-rule"`
 	OnlyRule            map[string]bool `mapstructure:"only-rule" json:"only-rule" yaml:"only-rule"`
+	RulesBundle         string          `mapstructure:"rules-bundle" json:"rules-bundle" yaml:"rules-bundle"`
+	RulesBundleChecksum string          `mapstructure:"rules-bundle-checksum" json:"rules-bundle-checksum" yaml:"rules-bundle-checksum"`
+	RulePack            []string        `mapstructure:"rule-pack" json:"rule-pack" yaml:"rule-pack"`
+	RulePackMirror      string          `mapstructure:"rule-pack-mirror" json:"rule-pack-mirror" yaml:"rule-pack-mirror"`
 }
 
 func (ruleFlagGroup) SetOptions(options *flagtypes.Options, args []string) error {
+	if getString(RulesBundleFlag) != "" && getString(RulesBundleChecksumFlag) == "" {
+		return ErrMissingRulesBundleChecksum
+	}
+
 	options.RuleOptions = flagtypes.RuleOptions{
 		DisableDefaultRules: getBool(DisableDefaultRulesFlag),
 		SkipRule:            argsToMap(SkipRuleFlag),
 		OnlyRule:            argsToMap(OnlyRuleFlag),
+		RulesBundle:         getString(RulesBundleFlag),
+		RulesBundleChecksum: getString(RulesBundleChecksumFlag),
+		RulePack:            getStringSlice(RulePackFlag),
+		RulePackMirror:      getString(RulePackMirrorFlag),
 	}
 
 	return nil
@@ -0,0 +1,28 @@
+package flag
+
+import flagtypes "github.com/bearer/bearer/pkg/flag/types"
+
+type rulesPullFlagGroup struct{ flagGroupBase }
+
+var RulesPullFlagGroup = &rulesPullFlagGroup{flagGroupBase{name: "Rules Pull"}}
+
+var (
+	RulesPullLanguageFlag = RulesPullFlagGroup.add(flagtypes.Flag{
+		Name:       "language",
+		ConfigName: "rules_pull.language",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated languages to include in the bundle e.g. --language=ruby,python. Includes all languages by default.",
+	})
+)
+
+type RulesPullOptions struct {
+	Languages []string `mapstructure:"rules_pull_language" json:"rules_pull_language" yaml:"rules_pull_language"`
+}
+
+func (rulesPullFlagGroup) SetOptions(options *flagtypes.Options, args []string) error {
+	options.RulesPullOptions = flagtypes.RulesPullOptions{
+		Languages: getStringSlice(RulesPullLanguageFlag),
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package flag
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"strings"
 	"time"
@@ -16,11 +17,16 @@ const (
 
 	ScannerSAST    = "sast"
 	ScannerSecrets = "secrets"
+
+	DiffScopeFile    = "file"
+	DiffScopeHunk    = "hunk"
+	DiffScopeContext = "context"
 )
 
 var (
-	ErrInvalidContext = errors.New("invalid context argument; supported values: health")
-	ErrInvalidScanner = errors.New("invalid scanner argument; supported values: sast, secrets")
+	ErrInvalidContext   = errors.New("invalid context argument; supported values: health")
+	ErrInvalidScanner   = errors.New("invalid scanner argument; supported values: sast, secrets")
+	ErrInvalidDiffScope = errors.New("invalid diff scope argument; supported values: file, hunk, context")
 )
 
 type scanFlagGroup struct{ flagGroupBase }
@@ -133,6 +139,50 @@ var (
 		Usage:           "Only report differences in findings relative to a base branch.",
 		DisableInConfig: true,
 	})
+	DiffScopeFlag = ScanFlagGroup.add(flagtypes.Flag{
+		Name:       "diff-scope",
+		ConfigName: "scan.diff-scope",
+		Value:      DiffScopeFile,
+		Usage:      "Specify which new findings a diff scan reports (file, hunk, context). Only findings whose source or sink is on a changed line are reported for hunk, and also those whose dataflow passes through a changed line for context.",
+	})
+	FilesFromFlag = ScanFlagGroup.add(flagtypes.Flag{
+		Name:            "files-from",
+		ConfigName:      "scan.files-from",
+		Value:           "",
+		Usage:           "Only scan the files listed in the given file, separated by newlines or NUL characters. Use - to read the list from stdin.",
+		DisableInConfig: true,
+	})
+	WatchFlag = ScanFlagGroup.add(flagtypes.Flag{
+		Name:            "watch",
+		ConfigName:      "scan.watch",
+		Value:           false,
+		Usage:           "Keep running after the scan, re-scanning changed files and updating the report.",
+		DisableInConfig: true,
+	})
+	HistoryFlag = ScanFlagGroup.add(flagtypes.Flag{
+		Name:       "history",
+		ConfigName: "scan.history",
+		Value:      false,
+		Usage:      "Also scan the git history of the project for secrets, including secrets which have since been removed. Requires the secrets scanner.",
+	})
+	SinceFlag = ScanFlagGroup.add(flagtypes.Flag{
+		Name:       "since",
+		ConfigName: "scan.since",
+		Value:      "",
+		Usage:      "Only scan the git history after the given commit, branch or tag e.g. --since=v1.2.0. Requires --history.",
+	})
+	SecretsConfigFlag = ScanFlagGroup.add(flagtypes.Flag{
+		Name:       "secrets-config",
+		ConfigName: "secrets.config",
+		Value:      "",
+		Usage:      "Use a gitleaks config file to add rules and allowlists to the secrets scanner.",
+	})
+	VerifySecretsFlag = ScanFlagGroup.add(flagtypes.Flag{
+		Name:       "verify-secrets",
+		ConfigName: "secrets.verify",
+		Value:      false,
+		Usage:      "Check whether secrets are likely to be live credentials, and raise or lower their severity accordingly.",
+	})
 )
 
 type ScanOptions struct {
@@ -176,6 +226,20 @@ func (scanFlagGroup) SetOptions(options *flagtypes.Options, args []string) error
 		}
 	}
 
+	var secrets flagtypes.SecretsOptions
+	if err := viper.UnmarshalKey("secrets", &secrets); err != nil {
+		return fmt.Errorf("invalid secrets configuration: %w", err)
+	}
+	secrets.Config = getString(SecretsConfigFlag)
+	secrets.Verify = getBool(VerifySecretsFlag)
+
+	diffScope := getString(DiffScopeFlag)
+	switch diffScope {
+	case DiffScopeFile, DiffScopeHunk, DiffScopeContext:
+	default:
+		return ErrInvalidDiffScope
+	}
+
 	// DIFF_BASE_BRANCH is used for backwards compatibilty
 	diff := getBool(DiffFlag) || os.Getenv("DIFF_BASE_BRANCH") != ""
 
@@ -198,6 +262,12 @@ func (scanFlagGroup) SetOptions(options *flagtypes.Options, args []string) error
 		Parallel:                viper.GetInt(ParallelFlag.ConfigName),
 		ExitCode:                viper.GetInt(ExitCodeFlag.ConfigName),
 		Diff:                    diff,
+		DiffScope:               diffScope,
+		FilesFrom:               getString(FilesFromFlag),
+		Watch:                   getBool(WatchFlag),
+		History:                 getBool(HistoryFlag),
+		Since:                   getString(SinceFlag),
+		Secrets:                 secrets,
 	}
 
 	return nil
@@ -55,34 +55,90 @@ type Options struct {
 	IgnoreAddOptions
 	IgnoreShowOptions
 	IgnoreMigrateOptions
+	RulesPullOptions
+	CompareOptions
 	WorkerOptions
 }
 
 type ScanOptions struct {
-	Target                  string        `mapstructure:"target" json:"target" yaml:"target"`
-	SkipTest                bool          `mapstructure:"skip-test" json:"skip-test" yaml:"skip-test"`
-	SkipGitIgnore           bool          `mapstructure:"skip-git-ignore" json:"skip-git-ignore" yaml:"skip-git-ignore"`
-	SkipPath                []string      `mapstructure:"skip-path" json:"skip-path" yaml:"skip-path"`
-	DisableDomainResolution bool          `mapstructure:"disable-domain-resolution" json:"disable-domain-resolution" yaml:"disable-domain-resolution"`
-	DomainResolutionTimeout time.Duration `mapstructure:"domain-resolution-timeout" json:"domain-resolution-timeout" yaml:"domain-resolution-timeout"`
-	InternalDomains         []string      `mapstructure:"internal-domains" json:"internal-domains" yaml:"internal-domains"`
-	Context                 Context       `mapstructure:"context" json:"context" yaml:"context"`
-	DataSubjectMapping      string        `mapstructure:"data_subject_mapping" json:"data_subject_mapping" yaml:"data_subject_mapping"`
-	Quiet                   bool          `mapstructure:"quiet" json:"quiet" yaml:"quiet"`
-	HideProgressBar         bool          `mapstructure:"hide_progress_bar" json:"hide_progress_bar" yaml:"hide_progress_bar"`
-	Force                   bool          `mapstructure:"force" json:"force" yaml:"force"`
-	ExternalRuleDir         []string      `mapstructure:"external-rule-dir" json:"external-rule-dir" yaml:"external-rule-dir"`
-	Scanner                 []string      `mapstructure:"scanner" json:"scanner" yaml:"scanner"`
-	Language                []string      `mapstructure:"language" json:"language" yaml:"language"`
-	Parallel                int           `mapstructure:"parallel" json:"parallel" yaml:"parallel"`
-	ExitCode                int           `mapstructure:"exit-code" json:"exit-code" yaml:"exit-code"`
-	Diff                    bool          `mapstructure:"diff" json:"diff" yaml:"diff"`
+	Target                  string         `mapstructure:"target" json:"target" yaml:"target"`
+	SkipTest                bool           `mapstructure:"skip-test" json:"skip-test" yaml:"skip-test"`
+	SkipGitIgnore           bool           `mapstructure:"skip-git-ignore" json:"skip-git-ignore" yaml:"skip-git-ignore"`
+	SkipPath                []string       `mapstructure:"skip-path" json:"skip-path" yaml:"skip-path"`
+	DisableDomainResolution bool           `mapstructure:"disable-domain-resolution" json:"disable-domain-resolution" yaml:"disable-domain-resolution"`
+	DomainResolutionTimeout time.Duration  `mapstructure:"domain-resolution-timeout" json:"domain-resolution-timeout" yaml:"domain-resolution-timeout"`
+	InternalDomains         []string       `mapstructure:"internal-domains" json:"internal-domains" yaml:"internal-domains"`
+	Context                 Context        `mapstructure:"context" json:"context" yaml:"context"`
+	DataSubjectMapping      string         `mapstructure:"data_subject_mapping" json:"data_subject_mapping" yaml:"data_subject_mapping"`
+	Quiet                   bool           `mapstructure:"quiet" json:"quiet" yaml:"quiet"`
+	HideProgressBar         bool           `mapstructure:"hide_progress_bar" json:"hide_progress_bar" yaml:"hide_progress_bar"`
+	Force                   bool           `mapstructure:"force" json:"force" yaml:"force"`
+	ExternalRuleDir         []string       `mapstructure:"external-rule-dir" json:"external-rule-dir" yaml:"external-rule-dir"`
+	Scanner                 []string       `mapstructure:"scanner" json:"scanner" yaml:"scanner"`
+	Language                []string       `mapstructure:"language" json:"language" yaml:"language"`
+	Parallel                int            `mapstructure:"parallel" json:"parallel" yaml:"parallel"`
+	ExitCode                int            `mapstructure:"exit-code" json:"exit-code" yaml:"exit-code"`
+	Diff                    bool           `mapstructure:"diff" json:"diff" yaml:"diff"`
+	DiffScope               string         `mapstructure:"diff-scope" json:"diff-scope" yaml:"diff-scope"`
+	FilesFrom               string         `mapstructure:"files-from" json:"files-from" yaml:"files-from"`
+	Files                   []string       `mapstructure:"files" json:"files" yaml:"files"`
+	Watch                   bool           `mapstructure:"watch" json:"watch" yaml:"watch"`
+	History                 bool           `mapstructure:"history" json:"history" yaml:"history"`
+	Since                   string         `mapstructure:"since" json:"since" yaml:"since"`
+	Secrets                 SecretsOptions `mapstructure:"secrets" json:"secrets" yaml:"secrets"`
+}
+
+// SecretsOptions extend the patterns of the secrets scanner
+type SecretsOptions struct {
+	// Config is the path to a gitleaks config file
+	Config     string            `mapstructure:"config" json:"config" yaml:"config"`
+	Rules      []SecretRule      `mapstructure:"rules" json:"rules" yaml:"rules"`
+	Allowlists []SecretAllowlist `mapstructure:"allowlists" json:"allowlists" yaml:"allowlists"`
+	Verifiers  []SecretVerifier  `mapstructure:"verifiers" json:"verifiers" yaml:"verifiers"`
+	// Verify is whether to check the validity of secrets, and grade their
+	// severity by it
+	Verify bool `mapstructure:"verify" json:"verify" yaml:"verify"`
+	// SkipRule are the IDs of secret rules given to --skip-rule
+	SkipRule []string `mapstructure:"-" json:"skip-rule" yaml:"-"`
+}
+
+// SecretRule is a gitleaks rule
+type SecretRule struct {
+	ID          string   `mapstructure:"id" json:"id" yaml:"id" toml:"id"`
+	Description string   `mapstructure:"description" json:"description" yaml:"description" toml:"description"`
+	Regex       string   `mapstructure:"regex" json:"regex" yaml:"regex" toml:"regex"`
+	SecretGroup int      `mapstructure:"secret_group" json:"secret_group" yaml:"secret_group" toml:"secretGroup"`
+	Entropy     float64  `mapstructure:"entropy" json:"entropy" yaml:"entropy" toml:"entropy"`
+	Keywords    []string `mapstructure:"keywords" json:"keywords" yaml:"keywords" toml:"keywords"`
+	Path        string   `mapstructure:"path" json:"path" yaml:"path" toml:"path"`
+}
+
+// SecretVerifier is a command which checks the validity of secrets
+type SecretVerifier struct {
+	Command []string `mapstructure:"command" json:"command" yaml:"command"`
+	// Rules are the IDs of the secret rules to check. All rules when empty
+	Rules []string `mapstructure:"rules" json:"rules" yaml:"rules"`
+}
+
+// SecretAllowlist is a gitleaks allowlist. Secrets matching any of its
+// criteria are not reported
+type SecretAllowlist struct {
+	Description string   `mapstructure:"description" json:"description" yaml:"description" toml:"description"`
+	Paths       []string `mapstructure:"paths" json:"paths" yaml:"paths" toml:"paths"`
+	Regexes     []string `mapstructure:"regexes" json:"regexes" yaml:"regexes" toml:"regexes"`
+	StopWords   []string `mapstructure:"stop_words" json:"stop_words" yaml:"stop_words" toml:"stopwords"`
+	Commits     []string `mapstructure:"commits" json:"commits" yaml:"commits" toml:"commits"`
+	TargetRules []string `mapstructure:"target_rules" json:"target_rules" yaml:"target_rules" toml:"targetRules"`
 }
 
 type RuleOptions struct {
 	DisableDefaultRules bool            `mapstructure:"disable-default-rules" json:"disable-default-rules" yaml:"disable-default-rules"`
 	SkipRule            map[string]bool `mapstructure:"skip-rule" json:"skip-rule" yaml:"skip-rule"`
 	OnlyRule            map[string]bool `mapstructure:"only-rule" json:"only-rule" yaml:"only-rule"`
+	RulesBundle         string          `mapstructure:"rules-bundle" json:"rules-bundle" yaml:"rules-bundle"`
+	RulesBundleChecksum string          `mapstructure:"rules-bundle-checksum" json:"rules-bundle-checksum" yaml:"rules-bundle-checksum"`
+	RulePack            []string        `mapstructure:"rule-pack" json:"rule-pack" yaml:"rule-pack"`
+	RulePackMirror      string          `mapstructure:"rule-pack-mirror" json:"rule-pack-mirror" yaml:"rule-pack-mirror"`
 }
 
 type ReportOptions struct {
@@ -95,6 +151,10 @@ type ReportOptions struct {
 	NoExtract          bool            `mapstructure:"no-extract" json:"no-extract" yaml:"no-extract"`
 	NoRuleMeta         bool            `mapstructure:"no-rule-meta" json:"no-rule-meta" yaml:"no-rule-meta"`
 	IncludeStats       bool            `mapstructure:"include-stats" json:"include-stats" yaml:"include-stats"`
+	IncludeIgnored     bool            `mapstructure:"include-ignored" json:"include-ignored" yaml:"include-ignored"`
+	Blame              bool            `mapstructure:"blame" json:"blame" yaml:"blame"`
+	Owner              []string        `mapstructure:"owner" json:"owner" yaml:"owner"`
+	CompareTo          string          `mapstructure:"compare-to" json:"compare-to" yaml:"compare-to"`
 }
 
 type RepositoryOptions struct {
@@ -104,6 +164,7 @@ type RepositoryOptions struct {
 	DefaultBranch     string
 	DiffBaseBranch    string
 	DiffBaseCommit    string
+	DiffHeadCommit    string
 	GithubToken       string
 	GithubRepository  string
 	GithubAPIURL      string
@@ -126,6 +187,7 @@ type GeneralOptions struct {
 type IgnoreAddOptions struct {
 	Author        string `mapstructure:"author" json:"author" yaml:"author"`
 	Comment       string `mapstructure:"comment" json:"comment" yaml:"comment"`
+	Owner         string `mapstructure:"ignore_add_owner" json:"ignore_add_owner" yaml:"ignore_add_owner"`
 	FalsePositive bool   `mapstructure:"false_positive" json:"false_positive" yaml:"false_positive"`
 	Force         bool   `mapstructure:"ignore_add_force" json:"ignore_add_force" yaml:"ignore_add_force"`
 }
@@ -138,6 +200,15 @@ type IgnoreMigrateOptions struct {
 	Force bool `mapstructure:"ignore_migrate_force" json:"ignore_migrate_force" yaml:"ignore_migrate_force"`
 }
 
+type RulesPullOptions struct {
+	Languages []string `mapstructure:"rules_pull_language" json:"rules_pull_language" yaml:"rules_pull_language"`
+}
+
+type CompareOptions struct {
+	OutputFormat string `mapstructure:"compare_format" json:"compare_format" yaml:"compare_format"`
+	OutputPath   string `mapstructure:"compare_output" json:"compare_output" yaml:"compare_output"`
+}
+
 type WorkerOptions struct {
 	ParentProcessID int
 	WorkerID        string `mapstructure:"worker-id" json:"worker-id" yaml:"worker-id"`
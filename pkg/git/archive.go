@@ -0,0 +1,42 @@
+package git
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// ResolveCommit returns the SHA of the commit a ref points to
+func ResolveCommit(rootDir, ref string) (string, error) {
+	output, err := captureCommandBasic(
+		context.TODO(),
+		rootDir,
+		"rev-parse",
+		"--verify",
+		"--end-of-options",
+		ref+"^{commit}",
+	)
+
+	return strings.TrimSpace(output), err
+}
+
+// Archive writes a tar archive of the given path at a commit
+func Archive(rootDir, commitSHA, path string, capture func(io.Reader) error) error {
+	return captureCommand(
+		context.TODO(),
+		rootDir,
+		[]string{"archive", "--format=tar", commitSHA, "--", path},
+		capture,
+	)
+}
+
+// ShowFile writes the content of a file at a commit. The path is relative to
+// the root of the repository
+func ShowFile(rootDir, commitSHA, path string, capture func(io.Reader) error) error {
+	return captureCommand(
+		context.TODO(),
+		rootDir,
+		[]string{"cat-file", "blob", commitSHA + ":" + path},
+		capture,
+	)
+}
@@ -0,0 +1,41 @@
+package git_test
+
+import (
+	"io"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bearer/bearer/pkg/git"
+)
+
+func TestShowFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	runGit(t, tempDir, "init", ".")
+	require.NoError(t, os.Mkdir(path.Join(tempDir, "dir"), 0700))
+	writeFile(t, tempDir, "dir/foo.txt", "original")
+	addAndCommit(t, tempDir)
+
+	commitSHA, err := git.GetCurrentCommit(tempDir)
+	require.NoError(t, err)
+
+	writeFile(t, tempDir, "dir/foo.txt", "changed")
+
+	var content []byte
+	err = git.ShowFile(tempDir, commitSHA, "dir/foo.txt", func(reader io.Reader) error {
+		content, err = io.ReadAll(reader)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(content))
+
+	err = git.ShowFile(tempDir, commitSHA, "missing.txt", func(reader io.Reader) error {
+		_, err := io.Copy(io.Discard, reader)
+		return err
+	})
+	assert.Error(t, err)
+}
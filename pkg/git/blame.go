@@ -0,0 +1,124 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bearer/bearer/pkg/util/linescanner"
+)
+
+const uncommittedSHA = "0000000000000000000000000000000000000000"
+
+// FileBlame holds the commit which last modified each line of a file
+type FileBlame struct {
+	// lines are indexed by line number - 1, and are nil when not committed
+	lines []*Commit
+}
+
+// Blame returns the commits which last modified the lines of the file at path,
+// relative to rootDir
+func Blame(rootDir, path string) (*FileBlame, error) {
+	blame := &FileBlame{}
+
+	err := captureCommand(
+		context.TODO(),
+		rootDir,
+		[]string{"blame", "--porcelain", "--", path},
+		func(stdout io.Reader) error {
+			return parseBlame(linescanner.New(stdout), blame)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return blame, nil
+}
+
+// LastCommit returns the most recent commit which modified the given lines, or
+// nil when none of them are committed
+func (blame *FileBlame) LastCommit(startLineNumber, endLineNumber int) *Commit {
+	var result *Commit
+
+	for lineNumber := max(startLineNumber, 1); lineNumber <= min(endLineNumber, len(blame.lines)); lineNumber++ {
+		commit := blame.lines[lineNumber-1]
+		if commit != nil && (result == nil || commit.Date.After(result.Date)) {
+			result = commit
+		}
+	}
+
+	return result
+}
+
+func parseBlame(scanner *linescanner.Scanner, blame *FileBlame) error {
+	commits := make(map[string]*Commit)
+	var current *Commit
+	var lineNumber int
+	// each entry starts with the commit SHA followed by the original and final
+	// line numbers, and ends with the content of the line
+	expectHeader := true
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// the scanner ends with an empty line
+		if expectHeader && line == "" {
+			continue
+		}
+
+		if expectHeader {
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				return fmt.Errorf("invalid blame header %q", line)
+			}
+
+			var err error
+			lineNumber, err = strconv.Atoi(fields[2])
+			if err != nil {
+				return fmt.Errorf("invalid blame header %q: %w", line, err)
+			}
+
+			sha := fields[0]
+			if commits[sha] == nil {
+				commits[sha] = &Commit{SHA: sha}
+			}
+			current = commits[sha]
+			expectHeader = false
+			continue
+		}
+
+		if strings.HasPrefix(line, "\t") {
+			expectHeader = true
+			if current.SHA == uncommittedSHA {
+				continue
+			}
+
+			for len(blame.lines) < lineNumber {
+				blame.lines = append(blame.lines, nil)
+			}
+			blame.lines[lineNumber-1] = current
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			current.AuthorName = value
+		case "author-mail":
+			current.AuthorEmail = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+		case "author-time":
+			timestamp, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid blame author time %q: %w", value, err)
+			}
+
+			current.Date = time.Unix(timestamp, 0).UTC()
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,49 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bearer/bearer/pkg/git"
+)
+
+func TestBlame(t *testing.T) {
+	tempDir, firstSHA := setupDiffTest(t)
+
+	writeFile(t, tempDir, "foo.txt", "1\nchanged\n3")
+	runGit(t, tempDir, "add", ".")
+	runGit(t, tempDir,
+		"-c", "user.name=Other Dev",
+		"-c", "user.email=other@bearer.com",
+		"-c", "commit.gpgSign=false",
+		"commit",
+		"--date=2030-01-02T03:04:05Z",
+		"--message=change",
+	)
+	secondSHA, err := git.GetCurrentCommit(tempDir)
+	require.NoError(t, err)
+
+	// uncommitted changes
+	writeFile(t, tempDir, "foo.txt", "1\nchanged\n3\n4")
+
+	blame, err := git.Blame(tempDir, "foo.txt")
+	require.NoError(t, err)
+
+	first := blame.LastCommit(1, 1)
+	require.NotNil(t, first)
+	assert.Equal(t, firstSHA, first.SHA)
+	assert.Equal(t, "Bearer CI", first.AuthorName)
+	assert.Equal(t, "ci@bearer.com", first.AuthorEmail)
+
+	last := blame.LastCommit(1, 3)
+	require.NotNil(t, last)
+	assert.Equal(t, secondSHA, last.SHA)
+	assert.Equal(t, "Other Dev", last.AuthorName)
+	assert.Equal(t, "other@bearer.com", last.AuthorEmail)
+	assert.Equal(t, "2030-01-02T03:04:05Z", last.Date.Format("2006-01-02T15:04:05Z"))
+
+	assert.Nil(t, blame.LastCommit(4, 4))
+	assert.Nil(t, blame.LastCommit(10, 12))
+}
@@ -31,24 +31,31 @@ type FilePatch struct {
 const SRC_PREFIX = "caf67b1d-c9b7-44ba-9b85-bd94727547af"
 const DST_PREFIX = "7dcfe536-96a9-49c5-8b86-7caa3d9e5e9c"
 
-func Diff(rootDir, baseRef string) ([]FilePatch, error) {
+// Diff returns the changes between baseRef and headRef. When headRef is empty,
+// the changes are between baseRef and the working tree
+func Diff(rootDir, baseRef, headRef string) ([]FilePatch, error) {
 	var result []FilePatch
 
+	args := []string{
+		"diff",
+		"--unified=0",
+		"--first-parent",
+		"--find-renames",
+		"--break-rewrites",
+		"--src-prefix=" + SRC_PREFIX,
+		"--dst-prefix=" + DST_PREFIX,
+		"--no-color",
+		baseRef,
+	}
+	if headRef != "" {
+		args = append(args, headRef)
+	}
+	args = append(args, "--")
+
 	err := captureCommand(
 		context.TODO(),
 		rootDir,
-		[]string{
-			"diff",
-			"--unified=0",
-			"--first-parent",
-			"--find-renames",
-			"--break-rewrites",
-			"--src-prefix=" + SRC_PREFIX,
-			"--dst-prefix=" + DST_PREFIX,
-			"--no-color",
-			baseRef,
-			"--",
-		},
+		args,
 		func(stdout io.Reader) error {
 			var err error
 			result, err = parseDiff(linescanner.New(stdout))
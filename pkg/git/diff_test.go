@@ -38,7 +38,7 @@ func TestDiff_FileAdded(t *testing.T) {
 	writeFile(t, tempDir, "new.txt", "abc")
 	addAndCommit(t, tempDir)
 
-	result, err := git.Diff(tempDir, baseSHA)
+	result, err := git.Diff(tempDir, baseSHA, "")
 	require.NoError(t, err)
 	assert.Equal(t, []git.FilePatch{{
 		ToPath: "new.txt",
@@ -55,7 +55,7 @@ func TestDiff_FileRemoved(t *testing.T) {
 	require.NoError(t, os.Remove(path.Join(tempDir, "foo.txt")))
 	addAndCommit(t, tempDir)
 
-	result, err := git.Diff(tempDir, baseSHA)
+	result, err := git.Diff(tempDir, baseSHA, "")
 	require.NoError(t, err)
 	assert.Equal(t, []git.FilePatch{{
 		FromPath: "foo.txt",
@@ -72,7 +72,7 @@ func TestDiff_FileRenamed(t *testing.T) {
 	require.NoError(t, os.Rename(path.Join(tempDir, "foo.txt"), path.Join(tempDir, "to.txt")))
 	addAndCommit(t, tempDir)
 
-	result, err := git.Diff(tempDir, baseSHA)
+	result, err := git.Diff(tempDir, baseSHA, "")
 	require.NoError(t, err)
 	assert.Equal(t, []git.FilePatch{
 		{FromPath: "foo.txt", ToPath: "to.txt"},
@@ -98,7 +98,7 @@ func TestDiff_QuotedPathsWithTabs(t *testing.T) {
 	require.NoError(t, os.Rename(path.Join(tempDir, fromPath), path.Join(tempDir, toPath)))
 	addAndCommit(t, tempDir)
 
-	result, err := git.Diff(tempDir, baseSHA)
+	result, err := git.Diff(tempDir, baseSHA, "")
 	require.NoError(t, err)
 	assert.Equal(t, []git.FilePatch{
 		{FromPath: fromPath, ToPath: toPath},
@@ -124,7 +124,7 @@ func TestDiff_QuotedPathsWithSpacesInBoth(t *testing.T) {
 	require.NoError(t, os.Rename(path.Join(tempDir, fromPath), path.Join(tempDir, toPath)))
 	addAndCommit(t, tempDir)
 
-	result, err := git.Diff(tempDir, baseSHA)
+	result, err := git.Diff(tempDir, baseSHA, "")
 	require.NoError(t, err)
 	assert.Equal(t, []git.FilePatch{
 		{FromPath: fromPath, ToPath: toPath},
@@ -150,7 +150,7 @@ func TestDiff_QuotedPathsWithSpaceInFrom(t *testing.T) {
 	require.NoError(t, os.Rename(path.Join(tempDir, fromPath), path.Join(tempDir, toPath)))
 	addAndCommit(t, tempDir)
 
-	result, err := git.Diff(tempDir, baseSHA)
+	result, err := git.Diff(tempDir, baseSHA, "")
 	require.NoError(t, err)
 	assert.Equal(t, []git.FilePatch{
 		{FromPath: fromPath, ToPath: toPath},
@@ -163,7 +163,7 @@ func TestDiff_FileContainsChanges(t *testing.T) {
 	writeFile(t, tempDir, "foo.txt", "x\ny\n2\nd")
 	addAndCommit(t, tempDir)
 
-	result, err := git.Diff(tempDir, baseSHA)
+	result, err := git.Diff(tempDir, baseSHA, "")
 	require.NoError(t, err)
 	assert.Equal(t, []git.FilePatch{{
 		FromPath: "foo.txt",
@@ -188,7 +188,7 @@ func TestDiff_SingleLineChange(t *testing.T) {
 	addAndCommit(t, tempDir)
 	runGit(t, tempDir, "diff", "--unified=0", baseSHA)
 
-	result, err := git.Diff(tempDir, baseSHA)
+	result, err := git.Diff(tempDir, baseSHA, "")
 	require.NoError(t, err)
 	assert.Equal(t, []git.FilePatch{{
 		FromPath: "foo.txt",
@@ -200,6 +200,31 @@ func TestDiff_SingleLineChange(t *testing.T) {
 	}}, result)
 }
 
+func TestDiff_HeadCommit(t *testing.T) {
+	tempDir, baseSHA := setupDiffTest(t)
+
+	writeFile(t, tempDir, "new.txt", "abc")
+	addAndCommit(t, tempDir)
+
+	headSHA, err := git.GetCurrentCommit(tempDir)
+	require.NoError(t, err)
+
+	// changes after the head commit are not included
+	writeFile(t, tempDir, "foo.txt", "changed")
+	writeFile(t, tempDir, "later.txt", "def")
+	addAndCommit(t, tempDir)
+
+	result, err := git.Diff(tempDir, baseSHA, headSHA)
+	require.NoError(t, err)
+	assert.Equal(t, []git.FilePatch{{
+		ToPath: "new.txt",
+		Chunks: []git.Chunk{{
+			From: git.ChunkRange{LineNumber: 0, LineCount: 0},
+			To:   git.ChunkRange{LineNumber: 1, LineCount: 1},
+		}},
+	}}, result)
+}
+
 func TestChunkRange_StartLineNumber(t *testing.T) {
 	t.Run("returns the line number", func(t *testing.T) {
 		assert.Equal(t, 2, git.ChunkRange{LineNumber: 2, LineCount: 1}.StartLineNumber())
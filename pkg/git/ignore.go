@@ -0,0 +1,51 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+
+	"github.com/bearer/bearer/pkg/util/set"
+)
+
+// IgnoredFiles returns the paths, relative to rootDir, which are excluded by
+// the .gitignore files of the repository. As with `git ls-files`, tracked files
+// are never ignored. Outside of a git repository no paths are ignored
+func IgnoredFiles(rootDir string, paths []string) (set.Set[string], error) {
+	result := set.New[string]()
+	if len(paths) == 0 {
+		return result, nil
+	}
+
+	repositoryRoot, err := GetRoot(rootDir)
+	if err != nil || repositoryRoot == "" {
+		return result, err
+	}
+
+	command := logAndBuildCommand(context.TODO(), "check-ignore", "--stdin", "-z")
+	command.Dir = rootDir
+	command.Stdin = strings.NewReader(strings.Join(paths, "\x00") + "\x00")
+
+	logWriter := &debugLogWriter{}
+	command.Stderr = logWriter
+
+	output, err := command.Output()
+	if err != nil {
+		// exit status 1 means that none of the paths are ignored
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return result, nil
+		}
+
+		return nil, newError(err, logWriter.AllOutput())
+	}
+
+	for _, path := range strings.Split(string(output), "\x00") {
+		if path != "" {
+			result.Add(path)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,154 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bearer/bearer/pkg/util/linescanner"
+)
+
+const COMMIT_PREFIX = "0ac5b4ef-9c5e-4b23-9b0d-9a8a4c4e2a41"
+
+type Commit struct {
+	SHA,
+	AuthorName,
+	AuthorEmail string
+	Date time.Time
+}
+
+// AddedLines are the consecutive lines added to a file by a commit
+type AddedLines struct {
+	Path       string
+	LineNumber int
+	Lines      []string
+}
+
+// History calls visit with the lines added by each commit reachable from HEAD,
+// oldest first. When sinceRef is given, only the commits after it are visited.
+// Paths are relative to rootDir, and files outside of it are skipped
+func History(rootDir, sinceRef string, visit func(Commit, AddedLines) error) error {
+	headSHA, err := GetCurrentCommit(rootDir)
+	if err != nil {
+		return err
+	}
+
+	// no commits yet
+	if headSHA == "" {
+		return nil
+	}
+
+	revision := headSHA
+	if sinceRef != "" {
+		revision = sinceRef + ".." + headSHA
+	}
+
+	return captureCommand(
+		context.TODO(),
+		rootDir,
+		[]string{
+			"log",
+			"--patch",
+			"--unified=0",
+			"--reverse",
+			"--no-color",
+			"--no-ext-diff",
+			"--no-textconv",
+			"--relative",
+			"--src-prefix=" + SRC_PREFIX,
+			"--dst-prefix=" + DST_PREFIX,
+			"--format=" + COMMIT_PREFIX + "%H%x00%an%x00%ae%x00%aI",
+			revision,
+			"--",
+		},
+		func(stdout io.Reader) error {
+			return parseLog(linescanner.New(stdout), visit)
+		},
+	)
+}
+
+func parseLog(scanner *linescanner.Scanner, visit func(Commit, AddedLines) error) error {
+	var commit Commit
+	var added *AddedLines
+	var toPath string
+
+	flush := func() error {
+		if added == nil {
+			return nil
+		}
+
+		err := visit(commit, *added)
+		added = nil
+		return err
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		var err error
+		switch {
+		case strings.HasPrefix(line, COMMIT_PREFIX):
+			if err := flush(); err != nil {
+				return err
+			}
+
+			commit, err = parseCommitHeader(strings.TrimPrefix(line, COMMIT_PREFIX))
+			if err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "diff --git"):
+			if err := flush(); err != nil {
+				return err
+			}
+
+			_, toPath, err = parseDiffHeader(line)
+			if err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "deleted file"):
+			toPath = ""
+		case strings.HasPrefix(line, "@@"):
+			if err := flush(); err != nil {
+				return err
+			}
+
+			chunk, err := parseChunkHeader(line)
+			if err != nil {
+				return err
+			}
+
+			if toPath != "" && chunk.To.LineCount != 0 {
+				added = &AddedLines{Path: toPath, LineNumber: chunk.To.LineNumber}
+			}
+		case added != nil && strings.HasPrefix(line, "+"):
+			added.Lines = append(added.Lines, line[1:])
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	return scanner.Err()
+}
+
+func parseCommitHeader(value string) (Commit, error) {
+	parts := strings.Split(value, "\x00")
+	if len(parts) != 4 {
+		return Commit{}, fmt.Errorf("invalid commit header %q", value)
+	}
+
+	date, err := time.Parse(time.RFC3339, parts[3])
+	if err != nil {
+		return Commit{}, fmt.Errorf("error parsing commit date: %w", err)
+	}
+
+	return Commit{
+		SHA:         parts[0],
+		AuthorName:  parts[1],
+		AuthorEmail: parts[2],
+		Date:        date,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package git_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bearer/bearer/pkg/git"
+)
+
+type historyEntry struct {
+	sha   string
+	added git.AddedLines
+}
+
+func collectHistory(t *testing.T, dir, sinceRef string) []historyEntry {
+	t.Helper()
+
+	var result []historyEntry
+	err := git.History(dir, sinceRef, func(commit git.Commit, added git.AddedLines) error {
+		assert.Equal(t, "Bearer CI", commit.AuthorName)
+		assert.Equal(t, "ci@bearer.com", commit.AuthorEmail)
+		assert.False(t, commit.Date.IsZero())
+
+		result = append(result, historyEntry{sha: commit.SHA, added: added})
+		return nil
+	})
+	require.NoError(t, err)
+
+	return result
+}
+
+func TestHistory(t *testing.T) {
+	tempDir, firstSHA := setupDiffTest(t)
+
+	writeFile(t, tempDir, "foo.txt", "1\nchanged\n3\n4")
+	writeFile(t, tempDir, "new.txt", "abc")
+	addAndCommit(t, tempDir)
+	secondSHA, err := git.GetCurrentCommit(tempDir)
+	require.NoError(t, err)
+
+	require.NoError(t, os.Remove(path.Join(tempDir, "new.txt")))
+	addAndCommit(t, tempDir)
+
+	assert.Equal(t, []historyEntry{
+		{sha: firstSHA, added: git.AddedLines{Path: "foo.txt", LineNumber: 1, Lines: []string{"1", "2", "3"}}},
+		// the last line had no newline
+		{sha: secondSHA, added: git.AddedLines{Path: "foo.txt", LineNumber: 2, Lines: []string{"changed", "3", "4"}}},
+		{sha: secondSHA, added: git.AddedLines{Path: "new.txt", LineNumber: 1, Lines: []string{"abc"}}},
+	}, collectHistory(t, tempDir, ""))
+
+	assert.Equal(t, []historyEntry{
+		// the last line had no newline
+		{sha: secondSHA, added: git.AddedLines{Path: "foo.txt", LineNumber: 2, Lines: []string{"changed", "3", "4"}}},
+		{sha: secondSHA, added: git.AddedLines{Path: "new.txt", LineNumber: 1, Lines: []string{"abc"}}},
+	}, collectHistory(t, tempDir, firstSHA))
+}
+
+func TestHistory_NoCommits(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "history-test")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	runGit(t, tempDir, "init", ".")
+
+	assert.Empty(t, collectHistory(t, tempDir, ""))
+}
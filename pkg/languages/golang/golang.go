@@ -18,6 +18,7 @@ import (
 	"github.com/bearer/bearer/pkg/languages/golang/pattern"
 	"github.com/bearer/bearer/pkg/scanner/detectors/datatype"
 	"github.com/bearer/bearer/pkg/scanner/detectors/insecureurl"
+	"github.com/bearer/bearer/pkg/scanner/detectors/sqlquery"
 	"github.com/bearer/bearer/pkg/scanner/detectors/stringliteral"
 	"github.com/bearer/bearer/pkg/scanner/language"
 )
@@ -53,6 +54,14 @@ func (*implementation) NewBuiltInDetectors(schemaClassifier *schema.Classifier,
 		stringdetector.New(querySet),
 		stringliteral.New(querySet),
 		insecureurl.New(querySet),
+		// db.Query(<query>)
+		sqlquery.New(querySet, `(call_expression
+			function: [(identifier) @method (selector_expression field: (field_identifier) @method)]
+			arguments: (argument_list (_) @match)
+		) @root`),
+		sqlquery.NewDynamicWhere(),
+		sqlquery.NewSelectAll(),
+		datatype.NewSQL(detectors.DetectorGo, schemaClassifier),
 	}
 }
 
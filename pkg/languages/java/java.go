@@ -16,6 +16,7 @@ import (
 	"github.com/bearer/bearer/pkg/languages/java/pattern"
 	"github.com/bearer/bearer/pkg/scanner/detectors/datatype"
 	"github.com/bearer/bearer/pkg/scanner/detectors/insecureurl"
+	"github.com/bearer/bearer/pkg/scanner/detectors/sqlquery"
 	"github.com/bearer/bearer/pkg/scanner/detectors/stringliteral"
 	"github.com/bearer/bearer/pkg/scanner/language"
 )
@@ -51,6 +52,11 @@ func (*implementation) NewBuiltInDetectors(schemaClassifier *schema.Classifier,
 		stringdetector.New(querySet),
 		stringliteral.New(querySet),
 		insecureurl.New(querySet),
+		// statement.executeQuery(<query>)
+		sqlquery.New(querySet, `(method_invocation name: (identifier) @method arguments: (argument_list (_) @match)) @root`),
+		sqlquery.NewDynamicWhere(),
+		sqlquery.NewSelectAll(),
+		datatype.NewSQL(detectors.DetectorJava, schemaClassifier),
 	}
 }
 
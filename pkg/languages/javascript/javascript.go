@@ -16,6 +16,7 @@ import (
 	"github.com/bearer/bearer/pkg/languages/javascript/pattern"
 	"github.com/bearer/bearer/pkg/scanner/detectors/datatype"
 	"github.com/bearer/bearer/pkg/scanner/detectors/insecureurl"
+	"github.com/bearer/bearer/pkg/scanner/detectors/sqlquery"
 	"github.com/bearer/bearer/pkg/scanner/detectors/stringliteral"
 	"github.com/bearer/bearer/pkg/scanner/language"
 )
@@ -51,6 +52,14 @@ func (*implementation) NewBuiltInDetectors(schemaClassifier *schema.Classifier,
 		stringdetector.New(querySet),
 		stringliteral.New(querySet),
 		insecureurl.New(querySet),
+		// db.query(<query>)
+		sqlquery.New(querySet, `(call_expression
+			function: [(identifier) @method (member_expression property: (property_identifier) @method)]
+			arguments: (arguments (_) @match)
+		) @root`),
+		sqlquery.NewDynamicWhere(),
+		sqlquery.NewSelectAll(),
+		datatype.NewSQL(detectors.DetectorJavascript, schemaClassifier),
 	}
 }
 
@@ -16,6 +16,7 @@ import (
 	"github.com/bearer/bearer/pkg/languages/php/pattern"
 	"github.com/bearer/bearer/pkg/scanner/detectors/datatype"
 	"github.com/bearer/bearer/pkg/scanner/detectors/insecureurl"
+	"github.com/bearer/bearer/pkg/scanner/detectors/sqlquery"
 	"github.com/bearer/bearer/pkg/scanner/detectors/stringliteral"
 	"github.com/bearer/bearer/pkg/scanner/language"
 )
@@ -51,6 +52,15 @@ func (*implementation) NewBuiltInDetectors(schemaClassifier *schema.Classifier,
 		stringdetector.New(querySet),
 		stringliteral.New(querySet),
 		insecureurl.New(querySet),
+		// $db->query(<query>), mysqli_query($connection, <query>)
+		sqlquery.New(querySet, `[
+			(function_call_expression function: (name) @method arguments: (arguments (argument (_) @match)))
+			(member_call_expression name: (name) @method arguments: (arguments (argument (_) @match)))
+			(scoped_call_expression name: (name) @method arguments: (arguments (argument (_) @match)))
+		] @root`),
+		sqlquery.NewDynamicWhere(),
+		sqlquery.NewSelectAll(),
+		datatype.NewSQL(detectors.DetectorPHP, schemaClassifier),
 	}
 }
 
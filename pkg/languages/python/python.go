@@ -16,6 +16,7 @@ import (
 	"github.com/bearer/bearer/pkg/languages/python/pattern"
 	"github.com/bearer/bearer/pkg/scanner/detectors/datatype"
 	"github.com/bearer/bearer/pkg/scanner/detectors/insecureurl"
+	"github.com/bearer/bearer/pkg/scanner/detectors/sqlquery"
 	"github.com/bearer/bearer/pkg/scanner/detectors/stringliteral"
 	"github.com/bearer/bearer/pkg/scanner/language"
 )
@@ -41,7 +42,7 @@ func (*implementation) EnryLanguages() []string {
 }
 
 func (*implementation) GoclocLanguages() []string {
-	return []string{"Python"}
+	return []string{"Python", "Jupyter Notebook"}
 }
 
 func (*implementation) NewBuiltInDetectors(schemaClassifier *schema.Classifier, querySet *query.Set) []detectortypes.Detector {
@@ -51,6 +52,14 @@ func (*implementation) NewBuiltInDetectors(schemaClassifier *schema.Classifier,
 		stringdetector.New(querySet),
 		stringliteral.New(querySet),
 		insecureurl.New(querySet),
+		// cursor.execute(<query>)
+		sqlquery.New(querySet, `(call
+			function: [(identifier) @method (attribute attribute: (identifier) @method)]
+			arguments: (argument_list (_) @match)
+		) @root`),
+		sqlquery.NewDynamicWhere(),
+		sqlquery.NewSelectAll(),
+		datatype.NewSQL(detectors.DetectorPython, schemaClassifier),
 	}
 }
 
@@ -0,0 +1,125 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"sync"
+)
+
+const (
+	ErrorCodeParseError     = -32700
+	ErrorCodeMethodNotFound = -32601
+	ErrorCodeInvalidParams  = -32602
+	ErrorCodeInternalError  = -32603
+)
+
+// Message is a JSON-RPC 2.0 request, notification or response
+type Message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *ResponseError   `json:"error,omitempty"`
+}
+
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (err *ResponseError) Error() string {
+	return err.Message
+}
+
+type response struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  any              `json:"result"`
+}
+
+type errorResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Error   *ResponseError   `json:"error"`
+}
+
+// Conn reads and writes base protocol messages, which are JSON-RPC payloads
+// framed by a Content-Length header
+type Conn struct {
+	reader      *bufio.Reader
+	writer      io.Writer
+	writerMutex sync.Mutex
+}
+
+func NewConn(reader io.Reader, writer io.Writer) *Conn {
+	return &Conn{reader: bufio.NewReader(reader), writer: writer}
+}
+
+func (conn *Conn) Read() (*Message, error) {
+	header, err := textproto.NewReader(conn.reader).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid content length: %w", err)
+	}
+
+	content := make([]byte, length)
+	if _, err := io.ReadFull(conn.reader, content); err != nil {
+		return nil, err
+	}
+
+	var message Message
+	if err := json.Unmarshal(content, &message); err != nil {
+		return nil, &ResponseError{Code: ErrorCodeParseError, Message: err.Error()}
+	}
+
+	return &message, nil
+}
+
+func (conn *Conn) Reply(id *json.RawMessage, result any, err error) error {
+	if err == nil {
+		return conn.write(response{JSONRPC: "2.0", ID: id, Result: result})
+	}
+
+	var responseErr *ResponseError
+	if !errors.As(err, &responseErr) {
+		responseErr = &ResponseError{Code: ErrorCodeInternalError, Message: err.Error()}
+	}
+
+	return conn.write(errorResponse{JSONRPC: "2.0", ID: id, Error: responseErr})
+}
+
+func (conn *Conn) Notify(method string, params any) error {
+	content, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	return conn.write(Message{JSONRPC: "2.0", Method: method, Params: content})
+}
+
+func (conn *Conn) write(message any) error {
+	content, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	conn.writerMutex.Lock()
+	defer conn.writerMutex.Unlock()
+
+	if _, err := fmt.Fprintf(conn.writer, "Content-Length: %d\r\n\r\n", len(content)); err != nil {
+		return err
+	}
+
+	_, err = conn.writer.Write(content)
+	return err
+}
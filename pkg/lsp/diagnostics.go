@@ -0,0 +1,143 @@
+package lsp
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+
+	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
+	globaltypes "github.com/bearer/bearer/pkg/types"
+)
+
+const diagnosticSource = "bearer"
+
+var diagnosticSeverities = map[string]int{
+	globaltypes.LevelCritical: DiagnosticSeverityError,
+	globaltypes.LevelHigh:     DiagnosticSeverityError,
+	globaltypes.LevelMedium:   DiagnosticSeverityWarning,
+	globaltypes.LevelLow:      DiagnosticSeverityInformation,
+	globaltypes.LevelWarning:  DiagnosticSeverityHint,
+}
+
+func newDiagnostic(finding securitytypes.Finding, lines []string) Diagnostic {
+	severity := finding.SeverityMeta.DisplaySeverity
+
+	message := fmt.Sprintf("[%s] %s", severity, finding.Title)
+	if len(finding.CWEIDs) != 0 {
+		cweIDs := make([]string, len(finding.CWEIDs))
+		for i, cweID := range finding.CWEIDs {
+			cweIDs[i] = "CWE-" + cweID
+		}
+
+		message += fmt.Sprintf(" (%s)", strings.Join(cweIDs, ", "))
+	}
+
+	diagnostic := Diagnostic{
+		Range:    findingRange(finding, lines),
+		Severity: diagnosticSeverities[severity],
+		Code:     finding.Id,
+		Source:   diagnosticSource,
+		Message:  message,
+		Data:     &DiagnosticData{Fingerprint: finding.Fingerprint},
+	}
+
+	if finding.DocumentationUrl != "" {
+		diagnostic.CodeDescription = &CodeDescription{Href: finding.DocumentationUrl}
+	}
+
+	return diagnostic
+}
+
+// findingRange converts the 1-based sink location of a finding into a 0-based
+// range. The columns of a location are byte offsets, whereas the characters of
+// a position are UTF-16 code units
+func findingRange(finding securitytypes.Finding, lines []string) Range {
+	if finding.Sink.Location == nil {
+		return Range{}
+	}
+
+	location := finding.Sink.Location
+	startLine := max(location.Start-1, 0)
+	endLine := max(location.End-1, 0)
+
+	return Range{
+		Start: Position{Line: startLine, Character: utf16Offset(lines, startLine, location.Column.Start-1)},
+		End:   Position{Line: endLine, Character: utf16Offset(lines, endLine, location.Column.End-1)},
+	}
+}
+
+// utf16Offset converts a byte offset in a line into a number of UTF-16 code
+// units
+func utf16Offset(lines []string, line int, byteOffset int) int {
+	byteOffset = max(byteOffset, 0)
+	if line >= len(lines) {
+		return byteOffset
+	}
+
+	content := lines[line]
+	if byteOffset > len(content) {
+		return len(utf16.Encode([]rune(content))) + byteOffset - len(content)
+	}
+
+	return len(utf16.Encode([]rune(content[:byteOffset])))
+}
+
+// codeActions returns the quick fixes for the Bearer diagnostics in a code
+// action request
+func codeActions(uri string, document *document, diagnostics []Diagnostic) []CodeAction {
+	actions := []CodeAction{}
+
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Source != diagnosticSource || diagnostic.Data == nil {
+			continue
+		}
+
+		line := diagnostic.Range.Start.Line
+		disableComment := fmt.Sprintf(
+			"%s%s bearer:disable %s\n",
+			document.indentation(line),
+			commentPrefix(document.languageID, uri),
+			diagnostic.Code,
+		)
+
+		actions = append(actions, CodeAction{
+			Title:       fmt.Sprintf("Disable %s for this line", diagnostic.Code),
+			Kind:        CodeActionKindQuickFix,
+			Diagnostics: []Diagnostic{diagnostic},
+			Edit: &WorkspaceEdit{
+				Changes: map[string][]TextEdit{
+					uri: {{
+						Range:   Range{Start: Position{Line: line}, End: Position{Line: line}},
+						NewText: disableComment,
+					}},
+				},
+			},
+		}, CodeAction{
+			Title:       "Add finding to bearer.ignore",
+			Kind:        CodeActionKindQuickFix,
+			Diagnostics: []Diagnostic{diagnostic},
+			Command: &Command{
+				Title:     "Add finding to bearer.ignore",
+				Command:   CommandIgnoreFingerprint,
+				Arguments: []any{diagnostic.Data.Fingerprint},
+			},
+		})
+	}
+
+	return actions
+}
+
+func commentPrefix(languageID, uri string) string {
+	switch languageID {
+	case "ruby", "python":
+		return "#"
+	case "":
+		switch filepath.Ext(uri) {
+		case ".rb", ".py":
+			return "#"
+		}
+	}
+
+	return "//"
+}
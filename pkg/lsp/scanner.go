@@ -0,0 +1,167 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bearer/bearer/pkg/commands/process/filelist/files"
+	"github.com/bearer/bearer/pkg/commands/process/settings"
+	settingsloader "github.com/bearer/bearer/pkg/commands/process/settings/loader"
+	"github.com/bearer/bearer/pkg/engine"
+	"github.com/bearer/bearer/pkg/flag"
+	flagtypes "github.com/bearer/bearer/pkg/flag/types"
+	reportoutput "github.com/bearer/bearer/pkg/report/output"
+	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
+	globaltypes "github.com/bearer/bearer/pkg/types"
+	"github.com/bearer/bearer/pkg/util/ignore"
+	ignoretypes "github.com/bearer/bearer/pkg/util/ignore/types"
+	"github.com/bearer/bearer/pkg/util/set"
+	"github.com/bearer/bearer/pkg/util/tmpfile"
+	"github.com/bearer/bearer/pkg/version_check"
+)
+
+// Scanner keeps the rules and worker processes loaded between scans of
+// individual documents
+type Scanner struct {
+	engine   engine.Engine
+	config   settings.Config
+	rootPath string
+	// Documents are written to a shadow copy of the workspace so that unsaved
+	// buffers can be scanned
+	shadowPath string
+	mutex      sync.Mutex
+}
+
+func NewScanner(ctx context.Context, opts flagtypes.Options, engine engine.Engine) (*Scanner, error) {
+	var languageIDs []string
+	for _, language := range engine.GetLanguages() {
+		languageIDs = append(languageIDs, language.ID())
+	}
+
+	versionMeta, err := version_check.GetScanVersionMeta(ctx, opts, languageIDs)
+	if err != nil {
+		log.Debug().Msgf("failed: %s", err)
+	}
+
+	if err := engine.Initialize(opts.LogLevel); err != nil {
+		return nil, fmt.Errorf("failed to initialize engine: %w", err)
+	}
+
+	config, err := settingsloader.FromOptions(opts, versionMeta, engine, languageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	shadowPath, err := os.MkdirTemp("", "bearer-lsp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shadow workspace: %w", err)
+	}
+
+	severities := set.New[string]()
+	severities.AddAll(globaltypes.Severities)
+
+	config.Target = shadowPath
+	config.Scan.Quiet = true
+	config.Scan.HideProgressBar = true
+	config.Report.Report = flag.ReportSecurity
+	config.Report.Severity = severities
+	config.Report.NoExtract = true
+
+	return &Scanner{
+		engine:     engine,
+		config:     config,
+		rootPath:   opts.Target,
+		shadowPath: shadowPath,
+	}, nil
+}
+
+// Scan runs the rules against the content of a document, given by its path
+// relative to the workspace root
+func (scanner *Scanner) Scan(relativePath string, content []byte) ([]securitytypes.Finding, error) {
+	scanner.mutex.Lock()
+	defer scanner.mutex.Unlock()
+
+	shadowFilePath := filepath.Join(scanner.shadowPath, relativePath)
+	if err := os.MkdirAll(filepath.Dir(shadowFilePath), 0700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(shadowFilePath, content, 0600); err != nil {
+		return nil, err
+	}
+	defer os.Remove(shadowFilePath)
+
+	reportPath := tmpfile.Create(".jsonl")
+	defer os.Remove(reportPath)
+
+	if err := scanner.engine.Scan(
+		&scanner.config,
+		nil,
+		reportPath,
+		scanner.shadowPath,
+		[]files.File{{FilePath: relativePath, Timeout: scanner.config.Worker.TimeoutFileMaximum}},
+	); err != nil {
+		return nil, err
+	}
+
+	reportData, err := reportoutput.GetData(
+		globaltypes.Report{Path: reportPath, HasFiles: true},
+		scanner.config,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []securitytypes.Finding
+	for _, severity := range globaltypes.Severities {
+		findings = append(findings, reportData.FindingsBySeverity[severity]...)
+	}
+
+	return findings, nil
+}
+
+// IgnoreFingerprint adds a fingerprint to the ignore file and applies it to
+// subsequent scans
+func (scanner *Scanner) IgnoreFingerprint(fingerprint string) error {
+	scanner.mutex.Lock()
+	defer scanner.mutex.Unlock()
+
+	ignoredFingerprints, ignoreFilePath, _, err := ignore.GetIgnoredFingerprints(scanner.config.IgnoreFile, &scanner.rootPath)
+	if err != nil {
+		return fmt.Errorf("error retrieving existing ignores: %w", err)
+	}
+
+	var entry ignoretypes.IgnoredFingerprint
+	if author, err := ignore.GetAuthor(); err == nil {
+		entry.Author = author
+	}
+
+	if err := ignore.MergeIgnoredFingerprints(
+		map[string]ignoretypes.IgnoredFingerprint{fingerprint: entry},
+		ignoredFingerprints,
+		false,
+	); err != nil {
+		return err
+	}
+
+	if err := ignore.WriteIgnoreFile(ignoredFingerprints, ignoreFilePath); err != nil {
+		return err
+	}
+
+	scanner.config.IgnoredFingerprints = ignoredFingerprints
+	return nil
+}
+
+func (scanner *Scanner) Close() {
+	scanner.engine.Close()
+
+	if err := os.RemoveAll(scanner.shadowPath); err != nil {
+		log.Debug().Msgf("failed to remove shadow workspace: %s", err)
+	}
+}
@@ -0,0 +1,382 @@
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bearer/bearer/cmd/bearer/build"
+	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
+)
+
+var ErrExitWithoutShutdown = errors.New("exit notification received before shutdown request")
+
+type DocumentScanner interface {
+	Scan(relativePath string, content []byte) ([]securitytypes.Finding, error)
+	IgnoreFingerprint(fingerprint string) error
+	Close()
+}
+
+// NewScannerFunc creates the scanner once the workspace root is known
+type NewScannerFunc func(rootPath string) (DocumentScanner, error)
+
+type document struct {
+	languageID string
+	text       string
+	// scanVersion is incremented on each scan request, so that the results of
+	// an outdated scan are not published
+	scanVersion int
+}
+
+func (document *document) indentation(line int) string {
+	lines := strings.Split(document.text, "\n")
+	if line >= len(lines) {
+		return ""
+	}
+
+	content := lines[line]
+	return content[:len(content)-len(strings.TrimLeft(content, " \t"))]
+}
+
+type Server struct {
+	conn           *Conn
+	newScanner     NewScannerFunc
+	scanner        DocumentScanner
+	rootPath       string
+	documents      map[string]*document
+	documentsMutex sync.Mutex
+	scans          sync.WaitGroup
+	shutdown       bool
+}
+
+func NewServer(reader io.Reader, writer io.Writer, newScanner NewScannerFunc) *Server {
+	return &Server{
+		conn:       NewConn(reader, writer),
+		newScanner: newScanner,
+		documents:  make(map[string]*document),
+	}
+}
+
+// Run handles messages until the client sends the exit notification
+func (server *Server) Run() error {
+	defer func() {
+		server.scans.Wait()
+
+		if server.scanner != nil {
+			server.scanner.Close()
+		}
+	}()
+
+	for {
+		message, err := server.conn.Read()
+		if err != nil {
+			var responseErr *ResponseError
+			if errors.As(err, &responseErr) {
+				if replyErr := server.conn.Reply(nil, nil, err); replyErr != nil {
+					return replyErr
+				}
+
+				continue
+			}
+
+			return err
+		}
+
+		if message.Method == "exit" {
+			if !server.shutdown {
+				return ErrExitWithoutShutdown
+			}
+
+			return nil
+		}
+
+		result, err := server.handle(message)
+
+		// notifications have no response
+		if message.ID == nil {
+			if err != nil {
+				log.Debug().Msgf("error handling %s: %s", message.Method, err)
+			}
+
+			continue
+		}
+
+		if err := server.conn.Reply(message.ID, result, err); err != nil {
+			return err
+		}
+	}
+}
+
+func (server *Server) handle(message *Message) (any, error) {
+	switch message.Method {
+	case "initialize":
+		var params InitializeParams
+		if err := unmarshalParams(message, &params); err != nil {
+			return nil, err
+		}
+
+		return server.initialize(params)
+	case "shutdown":
+		server.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := unmarshalParams(message, &params); err != nil {
+			return nil, err
+		}
+
+		server.documentsMutex.Lock()
+		server.documents[params.TextDocument.URI] = &document{
+			languageID: params.TextDocument.LanguageID,
+			text:       params.TextDocument.Text,
+		}
+		server.documentsMutex.Unlock()
+
+		server.scanDocument(params.TextDocument.URI)
+		return nil, nil
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := unmarshalParams(message, &params); err != nil {
+			return nil, err
+		}
+
+		if len(params.ContentChanges) != 0 {
+			server.documentsMutex.Lock()
+			if document, exists := server.documents[params.TextDocument.URI]; exists {
+				document.text = params.ContentChanges[len(params.ContentChanges)-1].Text
+			}
+			server.documentsMutex.Unlock()
+		}
+
+		return nil, nil
+	case "textDocument/didSave":
+		var params DidSaveTextDocumentParams
+		if err := unmarshalParams(message, &params); err != nil {
+			return nil, err
+		}
+
+		if params.Text != nil {
+			server.documentsMutex.Lock()
+			if document, exists := server.documents[params.TextDocument.URI]; exists {
+				document.text = *params.Text
+			}
+			server.documentsMutex.Unlock()
+		}
+
+		server.scanDocument(params.TextDocument.URI)
+		return nil, nil
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := unmarshalParams(message, &params); err != nil {
+			return nil, err
+		}
+
+		server.documentsMutex.Lock()
+		delete(server.documents, params.TextDocument.URI)
+		server.documentsMutex.Unlock()
+
+		return nil, server.publishDiagnostics(params.TextDocument.URI, nil)
+	case "textDocument/codeAction":
+		var params CodeActionParams
+		if err := unmarshalParams(message, &params); err != nil {
+			return nil, err
+		}
+
+		server.documentsMutex.Lock()
+		defer server.documentsMutex.Unlock()
+
+		document, exists := server.documents[params.TextDocument.URI]
+		if !exists {
+			return []CodeAction{}, nil
+		}
+
+		return codeActions(params.TextDocument.URI, document, params.Context.Diagnostics), nil
+	case "workspace/executeCommand":
+		var params ExecuteCommandParams
+		if err := unmarshalParams(message, &params); err != nil {
+			return nil, err
+		}
+
+		return nil, server.executeCommand(params)
+	case "initialized", "$/cancelRequest", "$/setTrace":
+		return nil, nil
+	default:
+		return nil, &ResponseError{
+			Code:    ErrorCodeMethodNotFound,
+			Message: fmt.Sprintf("method not supported: %s", message.Method),
+		}
+	}
+}
+
+func (server *Server) initialize(params InitializeParams) (*InitializeResult, error) {
+	rootPath := "."
+	if params.RootURI != "" {
+		var err error
+		if rootPath, err = uriToPath(params.RootURI); err != nil {
+			return nil, err
+		}
+	}
+
+	absoluteRootPath, err := filepath.Abs(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner, err := server.newScanner(absoluteRootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	server.rootPath = absoluteRootPath
+	server.scanner = scanner
+
+	return &InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    TextDocumentSyncKindFull,
+				Save:      SaveOptions{IncludeText: true},
+			},
+			CodeActionProvider: true,
+			ExecuteCommandProvider: ExecuteCommandOptions{
+				Commands: []string{CommandIgnoreFingerprint},
+			},
+		},
+		ServerInfo: ServerInfo{Name: "bearer", Version: build.Version},
+	}, nil
+}
+
+func (server *Server) executeCommand(params ExecuteCommandParams) error {
+	if params.Command != CommandIgnoreFingerprint {
+		return &ResponseError{
+			Code:    ErrorCodeInvalidParams,
+			Message: fmt.Sprintf("command not supported: %s", params.Command),
+		}
+	}
+
+	if len(params.Arguments) != 1 {
+		return &ResponseError{Code: ErrorCodeInvalidParams, Message: "expected a single fingerprint argument"}
+	}
+
+	var fingerprint string
+	if err := json.Unmarshal(params.Arguments[0], &fingerprint); err != nil {
+		return &ResponseError{Code: ErrorCodeInvalidParams, Message: err.Error()}
+	}
+
+	if err := server.scanner.IgnoreFingerprint(fingerprint); err != nil {
+		return err
+	}
+
+	server.documentsMutex.Lock()
+	uris := make([]string, 0, len(server.documents))
+	for uri := range server.documents {
+		uris = append(uris, uri)
+	}
+	server.documentsMutex.Unlock()
+
+	for _, uri := range uris {
+		server.scanDocument(uri)
+	}
+
+	return nil
+}
+
+// scanDocument scans the current text of a document in the background and
+// publishes the findings as diagnostics
+func (server *Server) scanDocument(uri string) {
+	if server.scanner == nil {
+		return
+	}
+
+	path, err := uriToPath(uri)
+	if err != nil {
+		log.Debug().Msgf("skipping document %s: %s", uri, err)
+		return
+	}
+
+	relativePath, err := filepath.Rel(server.rootPath, path)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		log.Debug().Msgf("skipping document outside of workspace: %s", uri)
+		return
+	}
+
+	server.documentsMutex.Lock()
+	document, exists := server.documents[uri]
+	if !exists {
+		server.documentsMutex.Unlock()
+		return
+	}
+	document.scanVersion++
+	scanVersion := document.scanVersion
+	text := document.text
+	server.documentsMutex.Unlock()
+
+	server.scans.Add(1)
+	go func() {
+		defer server.scans.Done()
+
+		findings, err := server.scanner.Scan(relativePath, []byte(text))
+		if err != nil {
+			log.Debug().Msgf("failed to scan %s: %s", uri, err)
+			return
+		}
+
+		server.documentsMutex.Lock()
+		document, exists := server.documents[uri]
+		current := exists && document.scanVersion == scanVersion
+		server.documentsMutex.Unlock()
+
+		if !current {
+			return
+		}
+
+		lines := strings.Split(text, "\n")
+		diagnostics := make([]Diagnostic, len(findings))
+		for i, finding := range findings {
+			diagnostics[i] = newDiagnostic(finding, lines)
+		}
+
+		if err := server.publishDiagnostics(uri, diagnostics); err != nil {
+			log.Debug().Msgf("failed to publish diagnostics for %s: %s", uri, err)
+		}
+	}()
+}
+
+func (server *Server) publishDiagnostics(uri string, diagnostics []Diagnostic) error {
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+
+	return server.conn.Notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: diagnostics,
+	})
+}
+
+func unmarshalParams(message *Message, params any) error {
+	if err := json.Unmarshal(message.Params, params); err != nil {
+		return &ResponseError{Code: ErrorCodeInvalidParams, Message: err.Error()}
+	}
+
+	return nil
+}
+
+func uriToPath(uri string) (string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.Scheme != "file" {
+		return "", fmt.Errorf("unsupported uri scheme: %s", parsed.Scheme)
+	}
+
+	return filepath.FromSlash(parsed.Path), nil
+}
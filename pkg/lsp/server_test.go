@@ -0,0 +1,183 @@
+package lsp_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bearer/bearer/pkg/lsp"
+	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
+)
+
+type fakeScanner struct {
+	scanned []string
+	ignored []string
+}
+
+func (scanner *fakeScanner) Scan(relativePath string, content []byte) ([]securitytypes.Finding, error) {
+	scanner.scanned = append(scanner.scanned, relativePath)
+
+	return []securitytypes.Finding{{
+		Rule: &securitytypes.Rule{
+			Id:               "ruby_lang_logger",
+			Title:            "Leakage of information in logger message",
+			CWEIDs:           []string{"532"},
+			DocumentationUrl: "https://docs.bearer.com/reference/rules/ruby_lang_logger",
+		},
+		Filename: relativePath,
+		Sink: securitytypes.Sink{
+			Location: &securitytypes.Location{Start: 2, End: 2, Column: securitytypes.Column{Start: 3, End: 20}},
+		},
+		Fingerprint:  "abc_0",
+		SeverityMeta: securitytypes.SeverityMeta{DisplaySeverity: "high"},
+	}}, nil
+}
+
+func (scanner *fakeScanner) IgnoreFingerprint(fingerprint string) error {
+	scanner.ignored = append(scanner.ignored, fingerprint)
+	return nil
+}
+
+func (scanner *fakeScanner) Close() {}
+
+type testClient struct {
+	t      *testing.T
+	conn   *lsp.Conn
+	writer io.Writer
+	nextID int
+}
+
+func (client *testClient) send(id *int, method string, params any) {
+	message := map[string]any{"jsonrpc": "2.0", "method": method, "params": params}
+	if id != nil {
+		message["id"] = *id
+	}
+
+	content, err := json.Marshal(message)
+	require.NoError(client.t, err)
+
+	_, err = fmt.Fprintf(client.writer, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	require.NoError(client.t, err)
+}
+
+func (client *testClient) request(method string, params any, result any) {
+	client.nextID++
+	client.send(&client.nextID, method, params)
+
+	message := client.receive()
+	require.Nil(client.t, message.Error)
+	require.Equal(client.t, fmt.Sprint(client.nextID), string(*message.ID))
+
+	if result != nil {
+		require.NoError(client.t, json.Unmarshal(message.Result, result))
+	}
+}
+
+func (client *testClient) receive() *lsp.Message {
+	message, err := client.conn.Read()
+	require.NoError(client.t, err)
+
+	return message
+}
+
+func TestServer(t *testing.T) {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+
+	scanner := &fakeScanner{}
+	var rootPath string
+	server := lsp.NewServer(serverReader, serverWriter, func(path string) (lsp.DocumentScanner, error) {
+		rootPath = path
+		return scanner, nil
+	})
+
+	done := make(chan error)
+	go func() { done <- server.Run() }()
+
+	client := &testClient{t: t, conn: lsp.NewConn(clientReader, nil), writer: clientWriter}
+	uri := "file:///project/app/user.rb"
+
+	var initializeResult lsp.InitializeResult
+	client.request("initialize", lsp.InitializeParams{RootURI: "file:///project"}, &initializeResult)
+	assert.Equal(t, "/project", rootPath)
+	assert.True(t, initializeResult.Capabilities.CodeActionProvider)
+
+	client.send(nil, "textDocument/didOpen", lsp.DidOpenTextDocumentParams{
+		TextDocument: lsp.TextDocumentItem{
+			URI:        uri,
+			LanguageID: "ruby",
+			Text:       "def create\n  logger.info(user.email)\nend\n",
+		},
+	})
+
+	notification := client.receive()
+	assert.Equal(t, "textDocument/publishDiagnostics", notification.Method)
+
+	var published lsp.PublishDiagnosticsParams
+	require.NoError(t, json.Unmarshal(notification.Params, &published))
+	assert.Equal(t, []string{"app/user.rb"}, scanner.scanned)
+	assert.Equal(t, lsp.PublishDiagnosticsParams{
+		URI: uri,
+		Diagnostics: []lsp.Diagnostic{{
+			Range: lsp.Range{
+				Start: lsp.Position{Line: 1, Character: 2},
+				End:   lsp.Position{Line: 1, Character: 19},
+			},
+			Severity:        lsp.DiagnosticSeverityError,
+			Code:            "ruby_lang_logger",
+			CodeDescription: &lsp.CodeDescription{Href: "https://docs.bearer.com/reference/rules/ruby_lang_logger"},
+			Source:          "bearer",
+			Message:         "[high] Leakage of information in logger message (CWE-532)",
+			Data:            &lsp.DiagnosticData{Fingerprint: "abc_0"},
+		}},
+	}, published)
+
+	var actions []lsp.CodeAction
+	client.request("textDocument/codeAction", lsp.CodeActionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: uri},
+		Range:        published.Diagnostics[0].Range,
+		Context:      lsp.CodeActionContext{Diagnostics: published.Diagnostics},
+	}, &actions)
+
+	require.Len(t, actions, 2)
+	assert.Equal(t, []lsp.TextEdit{{
+		Range:   lsp.Range{Start: lsp.Position{Line: 1}, End: lsp.Position{Line: 1}},
+		NewText: "  # bearer:disable ruby_lang_logger\n",
+	}}, actions[0].Edit.Changes[uri])
+	assert.Equal(t, lsp.CommandIgnoreFingerprint, actions[1].Command.Command)
+
+	client.request("workspace/executeCommand", map[string]any{
+		"command":   lsp.CommandIgnoreFingerprint,
+		"arguments": []string{"abc_0"},
+	}, nil)
+	assert.Equal(t, []string{"abc_0"}, scanner.ignored)
+
+	// the open document is scanned again with the new ignore
+	assert.Equal(t, "textDocument/publishDiagnostics", client.receive().Method)
+
+	// positions count UTF-16 code units, whereas the finding columns are bytes
+	client.send(nil, "textDocument/didOpen", lsp.DidOpenTextDocumentParams{
+		TextDocument: lsp.TextDocumentItem{
+			URI:        "file:///project/app/admin.rb",
+			LanguageID: "ruby",
+			Text:       "def create\né 😀 logger.info(user.email)\nend\n",
+		},
+	})
+
+	notification = client.receive()
+	published = lsp.PublishDiagnosticsParams{}
+	require.NoError(t, json.Unmarshal(notification.Params, &published))
+	require.Len(t, published.Diagnostics, 1)
+	assert.Equal(t, lsp.Range{
+		Start: lsp.Position{Line: 1, Character: 1},
+		End:   lsp.Position{Line: 1, Character: 16},
+	}, published.Diagnostics[0].Range)
+
+	client.request("shutdown", nil, nil)
+	client.send(nil, "exit", nil)
+	assert.NoError(t, <-done)
+}
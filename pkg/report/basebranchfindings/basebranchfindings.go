@@ -16,6 +16,9 @@ type Findings struct {
 	fileList *files.List
 	chunks   map[string]git.Chunks
 	items    map[key][]git.ChunkRange
+	// base holds the findings as they are in the base branch, keyed by their
+	// base filename
+	base map[key][]git.ChunkRange
 }
 
 func New(fileList *files.List) *Findings {
@@ -23,10 +26,28 @@ func New(fileList *files.List) *Findings {
 		fileList: fileList,
 		chunks:   make(map[string]git.Chunks),
 		items:    make(map[key][]git.ChunkRange),
+		base:     make(map[key][]git.ChunkRange),
 	}
 }
 
+// Update returns the findings translated using a new list of files, eg. when
+// the changes since the base branch were listed again. Consumed findings are
+// included again
+func (findings Findings) Update(fileList *files.List) *Findings {
+	result := New(fileList)
+	for baseKey, baseRanges := range findings.base {
+		for _, baseRange := range baseRanges {
+			result.Add(baseKey.RuleID, baseKey.Filename, baseRange.LineNumber, baseRange.EndLineNumber())
+		}
+	}
+
+	return result
+}
+
 func (findings Findings) Add(ruleID string, baseFilename string, baseStartLine, baseEndLine int) {
+	baseKey := key{RuleID: ruleID, Filename: baseFilename}
+	findings.base[baseKey] = append(findings.base[baseKey], newRange(baseStartLine, baseEndLine))
+
 	filename := findings.fileList.Renames[baseFilename]
 	if filename == "" {
 		filename = baseFilename
@@ -62,6 +83,36 @@ func (findings Findings) Consume(ruleID string, filename string, startLine, endL
 	return false
 }
 
+// Changed returns whether any line from startLine to endLine was added or
+// changed since the base branch. When includeRemovals is set, lines removed
+// from within the range also count as a change. Every line of a file which is
+// new since the base branch is changed
+func (findings Findings) Changed(filename string, startLine, endLine int, includeRemovals bool) bool {
+	fileChunks, modified := findings.fileList.Chunks[filename]
+	if !modified {
+		return true
+	}
+
+	lineRange := newRange(startLine, endLine)
+
+	for _, chunk := range fileChunks {
+		if chunk.To.LineCount == 0 {
+			// lines were removed after chunk.To.LineNumber
+			if includeRemovals && chunk.To.LineNumber >= startLine && chunk.To.LineNumber < endLine {
+				return true
+			}
+
+			continue
+		}
+
+		if chunk.To.Overlap(lineRange) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func newRange(startLine, endLine int) git.ChunkRange {
 	return git.ChunkRange{LineNumber: startLine, LineCount: endLine - startLine + 1}
 }
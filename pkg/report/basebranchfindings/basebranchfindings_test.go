@@ -0,0 +1,66 @@
+package basebranchfindings_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/pkg/commands/process/filelist/files"
+	"github.com/bearer/bearer/pkg/git"
+	"github.com/bearer/bearer/pkg/report/basebranchfindings"
+)
+
+func TestChanged(t *testing.T) {
+	findings := basebranchfindings.New(&files.List{
+		Chunks: map[string]git.Chunks{
+			"modified.rb": {
+				// line 3 is changed
+				{
+					From: git.ChunkRange{LineNumber: 3, LineCount: 1},
+					To:   git.ChunkRange{LineNumber: 3, LineCount: 1},
+				},
+				// the line after line 6 is removed
+				{
+					From: git.ChunkRange{LineNumber: 7, LineCount: 1},
+					To:   git.ChunkRange{LineNumber: 6, LineCount: 0},
+				},
+			},
+			"renamed.rb": nil,
+		},
+	})
+
+	assert.True(t, findings.Changed("modified.rb", 3, 3, false))
+	assert.True(t, findings.Changed("modified.rb", 1, 4, false))
+	assert.False(t, findings.Changed("modified.rb", 1, 2, false))
+	assert.False(t, findings.Changed("modified.rb", 5, 8, false))
+
+	assert.True(t, findings.Changed("modified.rb", 5, 8, true))
+	assert.False(t, findings.Changed("modified.rb", 6, 6, true))
+	assert.False(t, findings.Changed("modified.rb", 7, 8, true))
+
+	assert.False(t, findings.Changed("renamed.rb", 1, 1, true))
+	assert.True(t, findings.Changed("new.rb", 1, 1, false))
+}
+
+func TestUpdate(t *testing.T) {
+	findings := basebranchfindings.New(&files.List{})
+	findings.Add("ruby_lang_logger", "app.rb", 5, 5)
+
+	assert.True(t, findings.Consume("ruby_lang_logger", "app.rb", 5, 5))
+	assert.False(t, findings.Consume("ruby_lang_logger", "app.rb", 5, 5))
+
+	// line 2 is replaced by 3 lines
+	updated := findings.Update(&files.List{
+		Chunks: map[string]git.Chunks{
+			"app.rb": {
+				{
+					From: git.ChunkRange{LineNumber: 2, LineCount: 1},
+					To:   git.ChunkRange{LineNumber: 2, LineCount: 3},
+				},
+			},
+		},
+	})
+
+	assert.False(t, updated.Consume("ruby_lang_logger", "app.rb", 5, 5))
+	assert.True(t, updated.Consume("ruby_lang_logger", "app.rb", 7, 7))
+}
@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/bearer/bearer/pkg/report/frameworks"
+)
+
+const TypeDeclaration frameworks.Type = "graphql_declaration"
+
+// Kinds of declaration
+const (
+	// KindObjectType is an object type (or type extension) of an SDL schema
+	KindObjectType = "object_type"
+	// KindResolver is a resolver function for a field of an object type
+	KindResolver = "resolver"
+	// KindMiddleware is middleware which authenticates all GraphQL requests
+	KindMiddleware = "middleware"
+)
+
+// QueryTypeName is the name of the root type of queries
+const QueryTypeName = "Query"
+
+// UnauthenticatedSensitiveDataRuleID is the ID of the built-in rule which
+// flags fields exposing sensitive data without authorization
+const UnauthenticatedSensitiveDataRuleID = "graphql_unauthenticated_sensitive_data"
+
+// Declaration is a part of a GraphQL API. Authorized is true when an
+// authorization check was detected for the declaration (eg. an auth directive
+// on an object type, or a resolver checking the current user)
+type Declaration struct {
+	Kind       string  `json:"kind" yaml:"kind"`
+	TypeName   string  `json:"type_name,omitempty" yaml:"type_name,omitempty"`
+	FieldName  string  `json:"field_name,omitempty" yaml:"field_name,omitempty"`
+	Fields     []Field `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Authorized bool    `json:"authorized" yaml:"authorized"`
+}
+
+// Field is a field of an object type. Type is the name of the type it returns,
+// without any list or non-null wrappers
+type Field struct {
+	Name       string `json:"name" yaml:"name"`
+	Type       string `json:"type" yaml:"type"`
+	Authorized bool   `json:"authorized" yaml:"authorized"`
+}
+
+// Declarations aren't components
+func (value Declaration) GetTechnologyKey() string {
+	return ""
+}
+
+// NormalizeTypeName returns the name used to link resolvers to types. Code
+// first libraries usually name the classes of types with a `Type` suffix (eg.
+// `UserType` for `User`), and may namespace them
+func NormalizeTypeName(name string) string {
+	if index := strings.LastIndexAny(name, ":."); index != -1 {
+		name = name[index+1:]
+	}
+
+	if name != "Type" {
+		name = strings.TrimSuffix(name, "Type")
+	}
+
+	return strings.ToLower(name)
+}
+
+// NormalizeFieldName returns the name used to link resolvers to fields.
+// Libraries convert snake case method names into the camel case field names
+// of the schema (eg. `first_name` to `firstName`)
+func NormalizeFieldName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
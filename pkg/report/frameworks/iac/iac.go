@@ -0,0 +1,88 @@
+package iac
+
+import (
+	"strings"
+
+	"github.com/bearer/bearer/pkg/report/frameworks"
+)
+
+const TypeDataStore frameworks.Type = "data_store"
+
+// Kinds of data store
+const (
+	KindDatabase      = "database"
+	KindObjectStorage = "object_storage"
+	KindMessageQueue  = "message_queue"
+	KindCache         = "cache"
+	KindVolume        = "volume"
+)
+
+// DataStore is a data store resource declared in infrastructure-as-code.
+// Encrypted and Public are nil when the setting can't be determined (eg. it is
+// set from a variable)
+type DataStore struct {
+	Name         string `json:"name" yaml:"name"`
+	ResourceType string `json:"resource_type" yaml:"resource_type"`
+	Kind         string `json:"kind" yaml:"kind"`
+	Engine       string `json:"engine,omitempty" yaml:"engine,omitempty"`
+	Encrypted    *bool  `json:"encrypted,omitempty" yaml:"encrypted,omitempty"`
+	Public       *bool  `json:"public,omitempty" yaml:"public,omitempty"`
+}
+
+func (value DataStore) GetTechnologyKey() string {
+	engine := strings.ToLower(value.Engine)
+
+	switch {
+	case strings.Contains(engine, "postgres"):
+		return "428ff7dd-22ea-4e80-8755-84c70cf460db"
+	case strings.Contains(engine, "mariadb"):
+		return "873dde6f-ddd3-4689-b818-7877392c1aea"
+	case strings.Contains(engine, "mysql"):
+		return "ffa70264-2b19-445d-a5c9-be82b64fe750"
+	case strings.Contains(engine, "sqlserver"), strings.Contains(engine, "mssql"):
+		return "e4db4505-b837-4b76-9184-c3cec3b5e522"
+	case strings.Contains(engine, "oracle"):
+		return "80886e2a-ee2c-423d-98bc-0a3d743787b4"
+	case strings.Contains(engine, "mongo"), engine == "docdb":
+		return "be62dea4-2b19-4e33-8092-6751aaa6430b"
+	case strings.Contains(engine, "redis"):
+		return "62c20409-c1bf-4be9-a859-6fe6be7b11e3"
+	case strings.Contains(engine, "memcached"):
+		return "42908ccc-4f0f-419e-9ba0-f25121fd15b7"
+	case strings.Contains(engine, "elasticsearch"):
+		return "911eed55-46f6-4324-ab55-ca11acfe562e"
+	}
+
+	switch value.ResourceType {
+	case "aws_s3_bucket", "AWS::S3::Bucket":
+		return "4e5a3a3a-47cd-4b0e-b0a6-fa30a0a62499"
+	case "aws_dynamodb_table", "AWS::DynamoDB::Table":
+		return "11f8b440-d1ca-40d7-8a2d-2b8caa7c7fad"
+	case "aws_redshift_cluster", "AWS::Redshift::Cluster":
+		return "3a49be11-d8a7-4f63-ba4d-542320f3c580"
+	case "aws_sqs_queue", "AWS::SQS::Queue":
+		return "27c833e8-298b-4c71-a7cf-c1943779f485"
+	case "google_storage_bucket":
+		return "3a154582-174f-4ef7-90a2-f654435c23cb"
+	case "azurerm_storage_account":
+		return "f0f43ee7-7f6b-4572-aaa0-6b207146912b"
+	default:
+		return "unidentified_data_store"
+	}
+}
+
+// IDs of the built-in rules which flag data stores
+const (
+	UnencryptedDataStoreRuleID = "iac_unencrypted_data_store"
+	PublicDataStoreRuleID      = "iac_public_data_store"
+)
+
+// IsUnencrypted is true when the store is known to not be encrypted at rest
+func (value DataStore) IsUnencrypted() bool {
+	return value.Encrypted != nil && !*value.Encrypted
+}
+
+// IsPublic is true when the store is known to be publicly accessible
+func (value DataStore) IsPublic() bool {
+	return value.Public != nil && *value.Public
+}
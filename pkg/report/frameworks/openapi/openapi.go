@@ -0,0 +1,33 @@
+package openapi
+
+import "github.com/bearer/bearer/pkg/report/frameworks"
+
+const TypeEndpoint frameworks.Type = "openapi_endpoint"
+
+// Directions of the data of an endpoint
+const (
+	DirectionRequest  = "request"
+	DirectionResponse = "response"
+)
+
+// Endpoint is an operation of an OpenAPI spec. Path is the path as written in
+// the spec, eg. `/users/{id}`
+type Endpoint struct {
+	HttpMethod string  `json:"http_method" yaml:"http_method"`
+	Path       string  `json:"path" yaml:"path"`
+	Fields     []Field `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// Field is a parameter or a property of a request or response schema of an
+// endpoint. It is identified by the position of its name in the spec, which is
+// the position of the schema detection for the field
+type Field struct {
+	Direction    string `json:"direction" yaml:"direction"`
+	LineNumber   int    `json:"line_number" yaml:"line_number"`
+	ColumnNumber int    `json:"column_number" yaml:"column_number"`
+}
+
+// Endpoints aren't components
+func (value Endpoint) GetTechnologyKey() string {
+	return ""
+}
@@ -0,0 +1,65 @@
+package codeclimate
+
+import (
+	codeclimate "github.com/bearer/bearer/pkg/report/output/codeclimate/types"
+	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
+	globaltypes "github.com/bearer/bearer/pkg/types"
+)
+
+// ReportCodeClimate reports findings as Code Climate issues, as used by the
+// GitLab code quality report. Findings keep their fingerprint, so that issues
+// can be matched between pipelines
+func ReportCodeClimate(outputDetections map[string][]securitytypes.Finding) ([]codeclimate.Issue, error) {
+	issues := []codeclimate.Issue{}
+
+	for _, level := range globaltypes.Severities {
+		for _, finding := range outputDetections[level] {
+			issue := codeclimate.Issue{
+				Type:        "issue",
+				CheckName:   finding.Rule.Id,
+				Description: finding.Rule.Title,
+				Categories:  []string{"Security"},
+				Location: codeclimate.Location{
+					Path: finding.Filename,
+					Lines: codeclimate.Lines{
+						Begin: finding.Sink.Start,
+						End:   finding.Sink.End,
+					},
+				},
+				Severity:    formatSeverity(level),
+				Fingerprint: finding.Fingerprint,
+			}
+
+			if finding.Rule.Description != "" {
+				issue.Content = &codeclimate.Content{Body: finding.Rule.Description}
+			}
+
+			// ignored findings are reported as informational, with the reason
+			// they were ignored
+			if finding.Suppressed {
+				issue.Description += " (ignored)"
+				issue.Severity = formatSeverity(globaltypes.LevelWarning)
+				issue.Content = &codeclimate.Content{Body: finding.IgnoreSummary() + "\n\n" + finding.Rule.Description}
+			}
+
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues, nil
+}
+
+func formatSeverity(level string) string {
+	switch level {
+	case globaltypes.LevelCritical:
+		return "blocker"
+	case globaltypes.LevelHigh:
+		return "critical"
+	case globaltypes.LevelMedium:
+		return "major"
+	case globaltypes.LevelLow:
+		return "minor"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,44 @@
+package codeclimate_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/pkg/report/output/codeclimate"
+	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
+	"github.com/bearer/bearer/pkg/util/output"
+)
+
+func TestRailsGoatCodeClimate(t *testing.T) {
+	securityOutput, err := os.ReadFile("../reviewdog/testdata/rails-goat-security-report.json")
+	if err != nil {
+		t.Fatalf("failed to read file, err: %s", err)
+	}
+
+	var securityFindings map[string][]securitytypes.Finding
+	err = json.Unmarshal(securityOutput, &securityFindings)
+	if err != nil {
+		t.Fatalf("couldn't unmarshal file output: %s", err)
+	}
+
+	res, err := codeclimate.ReportCodeClimate(securityFindings)
+	if err != nil {
+		t.Fatalf("failed to generate security output, err: %s", err)
+	}
+
+	codeClimateOutput, err := output.ReportJSON(res)
+	if err != nil {
+		t.Fatalf("failed to generate JSON output, err: %s", err)
+	}
+
+	var prettyJSON bytes.Buffer
+	err = json.Indent(&prettyJSON, []byte(codeClimateOutput), "", "\t")
+	if err != nil {
+		t.Fatalf("error indenting output, err: %s", err)
+	}
+	cupaloy.SnapshotT(t, prettyJSON.String())
+}
@@ -0,0 +1,26 @@
+package types
+
+type Issue struct {
+	Type        string   `json:"type"`
+	CheckName   string   `json:"check_name"`
+	Description string   `json:"description"`
+	Content     *Content `json:"content,omitempty"`
+	Categories  []string `json:"categories"`
+	Location    Location `json:"location"`
+	Severity    string   `json:"severity"` // info, minor, major, critical or blocker
+	Fingerprint string   `json:"fingerprint"`
+}
+
+type Content struct {
+	Body string `json:"body"` // markdown
+}
+
+type Location struct {
+	Path  string `json:"path"`
+	Lines Lines  `json:"lines"`
+}
+
+type Lines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
@@ -12,9 +12,12 @@ import (
 	"github.com/bearer/bearer/pkg/report/detections"
 	reportdetectors "github.com/bearer/bearer/pkg/report/detectors"
 	"github.com/bearer/bearer/pkg/report/output/dataflow/components"
+	"github.com/bearer/bearer/pkg/report/output/dataflow/datastores"
 	"github.com/bearer/bearer/pkg/report/output/dataflow/datatypes"
 	"github.com/bearer/bearer/pkg/report/output/dataflow/detectiondecoder"
+	"github.com/bearer/bearer/pkg/report/output/dataflow/endpoints"
 	fileerrors "github.com/bearer/bearer/pkg/report/output/dataflow/file_errors"
+	"github.com/bearer/bearer/pkg/report/output/dataflow/graphql"
 	"github.com/bearer/bearer/pkg/report/output/dataflow/paths"
 	"github.com/bearer/bearer/pkg/report/output/dataflow/risks"
 	"github.com/bearer/bearer/pkg/report/output/types"
@@ -59,8 +62,11 @@ func AddReportData(reportData *types.ReportData, config settings.Config, isInter
 	dataTypesHolder := datatypes.New(config, isInternal)
 	risksHolder := risks.New(config, isInternal)
 	componentsHolder := components.New(isInternal)
+	dataStoresHolder := datastores.New()
 	pathsHolder := paths.New(isInternal)
 	errorsHolder := fileerrors.New()
+	graphqlHolder := graphql.New()
+	endpointsHolder := endpoints.New()
 
 	extras, err := datatypes.NewExtras(reportData.Detectors, config)
 	if err != nil {
@@ -152,6 +158,18 @@ func AddReportData(reportData *types.ReportData, config settings.Config, isInter
 				if err = dataTypesHolder.AddSchema(castDetection, detectionExtras); err != nil {
 					return err
 				}
+
+				if castDetection.DetectorType == reportdetectors.DetectorGraphQL {
+					if err = graphqlHolder.AddSchema(castDetection); err != nil {
+						return err
+					}
+				}
+
+				if castDetection.DetectorType == reportdetectors.DetectorOpenAPI {
+					if err = endpointsHolder.AddSchema(castDetection); err != nil {
+						return err
+					}
+				}
 			case detections.TypeOperation:
 				operationDetection, err := detectiondecoder.GetOperation(detection)
 				if err != nil {
@@ -201,7 +219,9 @@ func AddReportData(reportData *types.ReportData, config settings.Config, isInter
 					}
 				}
 			case detections.TypeSecretleak:
-				risksHolder.AddRiskPresence(castDetection)
+				if err := risksHolder.AddSecretLeak(castDetection); err != nil {
+					return err
+				}
 			case detections.TypeDependencyClassified:
 				classifiedDetection, err := detectiondecoder.GetClassifiedDependency(detection)
 				if err != nil {
@@ -232,10 +252,43 @@ func AddReportData(reportData *types.ReportData, config settings.Config, isInter
 				if err = componentsHolder.AddFramework(classifiedDetection); err != nil {
 					return err
 				}
+
+				if classifiedDetection.DetectorType == reportdetectors.DetectorIaC {
+					dataStore, err := detectiondecoder.GetDataStore(classifiedDetection.Value)
+					if err != nil {
+						return err
+					}
+
+					dataStoresHolder.AddDataStore(classifiedDetection, dataStore)
+					risksHolder.AddDataStore(*classifiedDetection.Detection, dataStore)
+				}
+
+				if classifiedDetection.DetectorType == reportdetectors.DetectorGraphQL ||
+					classifiedDetection.DetectorType == reportdetectors.DetectorGraphQLResolvers {
+					declaration, err := detectiondecoder.GetGraphQLDeclaration(classifiedDetection.Value)
+					if err != nil {
+						return err
+					}
+
+					graphqlHolder.AddDeclaration(declaration)
+				}
+
+				if classifiedDetection.DetectorType == reportdetectors.DetectorOpenAPI {
+					endpoint, err := detectiondecoder.GetOpenAPIEndpoint(classifiedDetection.Value)
+					if err != nil {
+						return err
+					}
+
+					endpointsHolder.AddEndpoint(classifiedDetection, endpoint)
+				}
 			}
 		}
 	}
 
+	for _, exposure := range graphqlHolder.Exposures() {
+		risksHolder.AddGraphQLExposure(exposure)
+	}
+
 	if !config.Scan.Quiet {
 		output.StdErrLog("Generating dataflow")
 	}
@@ -249,8 +302,11 @@ func AddReportData(reportData *types.ReportData, config settings.Config, isInter
 		Risks:              risksHolder.ToDataFlow(),
 		Components:         componentsHolder.ToDataFlow(),
 		Dependencies:       componentsHolder.ToDataFlowForDependencies(),
+		DataStores:         dataStoresHolder.ToDataFlow(),
+		Endpoints:          endpointsHolder.ToDataFlow(),
 		Errors:             errorsHolder.ToDataFlow(),
 		Paths:              pathsHolder.ToDataFlow(),
+		GraphQLDetected:    graphqlHolder.Detected(),
 	}
 
 	return nil
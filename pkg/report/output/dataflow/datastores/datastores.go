@@ -0,0 +1,57 @@
+package datastores
+
+import (
+	"sort"
+
+	frameworkclassification "github.com/bearer/bearer/pkg/classification/frameworks"
+	"github.com/bearer/bearer/pkg/report/frameworks/iac"
+	"github.com/bearer/bearer/pkg/report/output/dataflow/types"
+	"github.com/bearer/bearer/pkg/util/classify"
+)
+
+type Holder struct {
+	dataStores []types.DataStore
+}
+
+func New() *Holder {
+	return &Holder{
+		dataStores: []types.DataStore{},
+	}
+}
+
+// AddDataStore adds a data store declared in infrastructure-as-code
+func (holder *Holder) AddDataStore(classifiedDetection frameworkclassification.ClassifiedFramework, dataStore iac.DataStore) {
+	technology := ""
+	if classifiedDetection.Classification != nil &&
+		classifiedDetection.Classification.Decision.State == classify.Valid {
+		technology = classifiedDetection.Classification.RecipeName
+	}
+
+	holder.dataStores = append(holder.dataStores, types.DataStore{
+		Name:         dataStore.Name,
+		ResourceType: dataStore.ResourceType,
+		Kind:         dataStore.Kind,
+		Engine:       dataStore.Engine,
+		Technology:   technology,
+		Encrypted:    dataStore.Encrypted,
+		Public:       dataStore.Public,
+		FullFilename: classifiedDetection.Source.FullFilename,
+		Filename:     classifiedDetection.Source.Filename,
+		LineNumber:   *classifiedDetection.Source.StartLineNumber,
+	})
+}
+
+func (holder *Holder) ToDataFlow() []types.DataStore {
+	sort.Slice(holder.dataStores, func(i, j int) bool {
+		a := holder.dataStores[i]
+		b := holder.dataStores[j]
+
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+
+		return a.LineNumber < b.LineNumber
+	})
+
+	return holder.dataStores
+}
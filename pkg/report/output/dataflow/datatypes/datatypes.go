@@ -168,7 +168,7 @@ func (holder *Holder) addDatatype(
 		lineEntry.verifiedBy = extras.verifiedBy
 	}
 
-	if detectorName == string(detectors.DetectorSchemaRb) {
+	if classify.IsDatabase(detectors.Type(detectorName)) {
 		storedFlag := true
 		lineEntry.stored = &storedFlag
 	} else if customDetector, isCustomDetector := holder.config.Rules[detectorName]; isCustomDetector {
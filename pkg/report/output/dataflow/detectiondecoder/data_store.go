@@ -0,0 +1,24 @@
+package detectiondecoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bearer/bearer/pkg/report/frameworks/iac"
+)
+
+func GetDataStore(value interface{}) (iac.DataStore, error) {
+	var dataStore iac.DataStore
+	buf := bytes.NewBuffer(nil)
+	err := json.NewEncoder(buf).Encode(value)
+	if err != nil {
+		return iac.DataStore{}, fmt.Errorf("expect detection to have value of type data store %#v", value)
+	}
+	err = json.NewDecoder(buf).Decode(&dataStore)
+	if err != nil {
+		return iac.DataStore{}, fmt.Errorf("expect detection to have value of type data store %#v", value)
+	}
+
+	return dataStore, nil
+}
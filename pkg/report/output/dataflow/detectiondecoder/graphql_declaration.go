@@ -0,0 +1,24 @@
+package detectiondecoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bearer/bearer/pkg/report/frameworks/graphql"
+)
+
+func GetGraphQLDeclaration(value interface{}) (graphql.Declaration, error) {
+	var declaration graphql.Declaration
+	buf := bytes.NewBuffer(nil)
+	err := json.NewEncoder(buf).Encode(value)
+	if err != nil {
+		return graphql.Declaration{}, fmt.Errorf("expect detection to have value of type graphql declaration %#v", value)
+	}
+	err = json.NewDecoder(buf).Decode(&declaration)
+	if err != nil {
+		return graphql.Declaration{}, fmt.Errorf("expect detection to have value of type graphql declaration %#v", value)
+	}
+
+	return declaration, nil
+}
@@ -0,0 +1,24 @@
+package detectiondecoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bearer/bearer/pkg/report/frameworks/openapi"
+)
+
+func GetOpenAPIEndpoint(value interface{}) (openapi.Endpoint, error) {
+	var endpoint openapi.Endpoint
+	buf := bytes.NewBuffer(nil)
+	err := json.NewEncoder(buf).Encode(value)
+	if err != nil {
+		return openapi.Endpoint{}, fmt.Errorf("expect detection to have value of type openapi endpoint %#v", value)
+	}
+	err = json.NewDecoder(buf).Decode(&endpoint)
+	if err != nil {
+		return openapi.Endpoint{}, fmt.Errorf("expect detection to have value of type openapi endpoint %#v", value)
+	}
+
+	return endpoint, nil
+}
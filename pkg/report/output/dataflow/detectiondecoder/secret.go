@@ -0,0 +1,24 @@
+package detectiondecoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bearer/bearer/pkg/report/secret"
+)
+
+func GetSecret(value interface{}) (secret.Secret, error) {
+	var result secret.Secret
+	buf := bytes.NewBuffer(nil)
+	err := json.NewEncoder(buf).Encode(value)
+	if err != nil {
+		return secret.Secret{}, fmt.Errorf("expect detection to have value of type secret %#v", value)
+	}
+	err = json.NewDecoder(buf).Decode(&result)
+	if err != nil {
+		return secret.Secret{}, fmt.Errorf("expect detection to have value of type secret %#v", value)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,141 @@
+// Package endpoints links the endpoints of OpenAPI specs to the data types of
+// their request and response fields
+package endpoints
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bearer/bearer/pkg/classification/db"
+	frameworkclassification "github.com/bearer/bearer/pkg/classification/frameworks"
+	"github.com/bearer/bearer/pkg/report/detections"
+	"github.com/bearer/bearer/pkg/report/frameworks/openapi"
+	"github.com/bearer/bearer/pkg/report/output/dataflow/detectiondecoder"
+	"github.com/bearer/bearer/pkg/report/output/dataflow/types"
+	"github.com/bearer/bearer/pkg/util/classify"
+)
+
+type Holder struct {
+	endpoints []endpointHolder
+	// fields are the classified fields of the specs, by position
+	fields map[string]classifiedField
+}
+
+type endpointHolder struct {
+	endpoint     openapi.Endpoint
+	fullFilename string
+	filename     string
+}
+
+type classifiedField struct {
+	fieldName string
+	dataType  *db.DataType
+}
+
+func New() *Holder {
+	return &Holder{
+		fields: make(map[string]classifiedField),
+	}
+}
+
+// AddSchema adds a field of an OpenAPI spec, which is linked to the endpoints
+// using it when it is classified as a data type
+func (holder *Holder) AddSchema(detection detections.Detection) error {
+	value, err := detectiondecoder.GetSchema(detection)
+	if err != nil {
+		return err
+	}
+
+	classification, err := detectiondecoder.GetSchemaClassification(value)
+	if err != nil {
+		return err
+	}
+
+	if classification.Decision.State != classify.Valid || classification.DataType == nil {
+		return nil
+	}
+
+	key := positionKey(detection.Source.Filename, *detection.Source.StartLineNumber, *detection.Source.StartColumnNumber)
+	holder.fields[key] = classifiedField{fieldName: value.FieldName, dataType: classification.DataType}
+
+	return nil
+}
+
+func (holder *Holder) AddEndpoint(classifiedDetection frameworkclassification.ClassifiedFramework, endpoint openapi.Endpoint) {
+	holder.endpoints = append(holder.endpoints, endpointHolder{
+		endpoint:     endpoint,
+		fullFilename: classifiedDetection.Source.FullFilename,
+		filename:     classifiedDetection.Source.Filename,
+	})
+}
+
+// ToDataFlow returns the data types of each endpoint, once per direction
+func (holder *Holder) ToDataFlow() []types.Endpoint {
+	result := []types.Endpoint{}
+	seen := make(map[string]bool)
+
+	for _, endpointHolder := range holder.endpoints {
+		endpoint := endpointHolder.endpoint
+
+		for _, field := range endpoint.Fields {
+			classified, exists := holder.fields[positionKey(endpointHolder.filename, field.LineNumber, field.ColumnNumber)]
+			if !exists {
+				continue
+			}
+
+			key := fmt.Sprintf("%s:%s:%s:%s:%s", endpointHolder.filename, endpoint.HttpMethod, endpoint.Path, field.Direction, classified.dataType.Name)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			var categoryGroups []string
+			for _, group := range classified.dataType.Category.Groups {
+				categoryGroups = append(categoryGroups, group.Name)
+			}
+			sort.Strings(categoryGroups)
+
+			result = append(result, types.Endpoint{
+				HttpMethod:     endpoint.HttpMethod,
+				Path:           endpoint.Path,
+				Direction:      field.Direction,
+				DataType:       classified.dataType.Name,
+				CategoryName:   classified.dataType.Category.Name,
+				CategoryGroups: categoryGroups,
+				FieldName:      classified.fieldName,
+				FullFilename:   endpointHolder.fullFilename,
+				Filename:       endpointHolder.filename,
+				LineNumber:     field.LineNumber,
+			})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a := result[i]
+		b := result[j]
+
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+
+		if a.HttpMethod != b.HttpMethod {
+			return a.HttpMethod < b.HttpMethod
+		}
+
+		if a.Direction != b.Direction {
+			return a.Direction < b.Direction
+		}
+
+		return a.DataType < b.DataType
+	})
+
+	return result
+}
+
+func positionKey(filename string, lineNumber, columnNumber int) string {
+	return fmt.Sprintf("%s:%d:%d", filename, lineNumber, columnNumber)
+}
@@ -0,0 +1,86 @@
+package endpoints_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	frameworkclassification "github.com/bearer/bearer/pkg/classification/frameworks"
+	"github.com/bearer/bearer/pkg/report/detections"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/frameworks/openapi"
+	"github.com/bearer/bearer/pkg/report/output/dataflow/endpoints"
+	"github.com/bearer/bearer/pkg/report/schema"
+	"github.com/bearer/bearer/pkg/report/source"
+)
+
+func specSource(line, column int) source.Source {
+	return source.Source{
+		Filename:          "openapi.yaml",
+		StartLineNumber:   &line,
+		StartColumnNumber: &column,
+		EndLineNumber:     &line,
+		EndColumnNumber:   &column,
+	}
+}
+
+func classifiedField(line int, fieldName, dataType string) detections.Detection {
+	return detections.Detection{
+		DetectorType: detectors.DetectorOpenAPI,
+		Source:       specSource(line, 9),
+		Value: schema.Schema{
+			ObjectName: "User",
+			FieldName:  fieldName,
+			Classification: map[string]interface{}{
+				"name":      fieldName,
+				"data_type": map[string]interface{}{"name": dataType},
+				"decision":  map[string]interface{}{"state": "valid"},
+			},
+		},
+	}
+}
+
+func addEndpoint(holder *endpoints.Holder, method, path string, fields ...openapi.Field) {
+	detection := detections.Detection{Source: specSource(1, 5)}
+
+	holder.AddEndpoint(
+		frameworkclassification.ClassifiedFramework{Detection: &detection},
+		openapi.Endpoint{HttpMethod: method, Path: path, Fields: fields},
+	)
+}
+
+func TestToDataFlow(t *testing.T) {
+	holder := endpoints.New()
+
+	for _, detection := range []detections.Detection{
+		classifiedField(10, "email", "Email Address"),
+		classifiedField(12, "backup_email", "Email Address"),
+		classifiedField(14, "password", "Passwords"),
+	} {
+		assert.NoError(t, holder.AddSchema(detection))
+	}
+
+	addEndpoint(
+		holder,
+		"POST",
+		"/users",
+		openapi.Field{Direction: openapi.DirectionRequest, LineNumber: 10, ColumnNumber: 9},
+		openapi.Field{Direction: openapi.DirectionRequest, LineNumber: 12, ColumnNumber: 9},
+		openapi.Field{Direction: openapi.DirectionRequest, LineNumber: 14, ColumnNumber: 9},
+		openapi.Field{Direction: openapi.DirectionResponse, LineNumber: 10, ColumnNumber: 9},
+		// not classified
+		openapi.Field{Direction: openapi.DirectionResponse, LineNumber: 16, ColumnNumber: 9},
+	)
+	addEndpoint(holder, "GET", "/users")
+
+	var result []string
+	for _, endpoint := range holder.ToDataFlow() {
+		result = append(result, endpoint.HttpMethod+" "+endpoint.Path+" "+endpoint.Direction+" "+endpoint.DataType+" "+endpoint.FieldName)
+	}
+
+	assert.Equal(t, []string{
+		"POST /users request Email Address email",
+		"POST /users request Passwords password",
+		"POST /users response Email Address email",
+	}, result)
+}
@@ -0,0 +1,180 @@
+// Package graphql links the object types of GraphQL schemas to their
+// resolvers, to find the fields which expose sensitive data through queries
+// without any authorization check.
+package graphql
+
+import (
+	"sort"
+
+	"github.com/bearer/bearer/pkg/classification/db"
+	"github.com/bearer/bearer/pkg/report/detections"
+	"github.com/bearer/bearer/pkg/report/frameworks/graphql"
+	"github.com/bearer/bearer/pkg/report/output/dataflow/detectiondecoder"
+	"github.com/bearer/bearer/pkg/report/schema"
+	"github.com/bearer/bearer/pkg/util/classify"
+)
+
+type Holder struct {
+	types map[string]*objectType
+	// resolvers records whether the resolver of each field (by normalized type
+	// and field name) checks authorization
+	resolvers map[string]bool
+	// authorizedTypes are the normalized names of types whose resolvers check
+	// authorization for all fields
+	authorizedTypes map[string]bool
+	// authenticated is true when middleware authenticates all requests
+	authenticated bool
+	// dataTypes are the classified fields, by type and field name
+	dataTypes map[string][]classifiedField
+}
+
+type objectType struct {
+	fields     map[string]graphql.Field
+	authorized bool
+}
+
+type classifiedField struct {
+	detection detections.Detection
+	schema    schema.Schema
+	dataType  *db.DataType
+}
+
+// Exposure is a field exposing sensitive data through queries. Path is the
+// path to the field from the root query type, eg. `Query.user.email`
+type Exposure struct {
+	Path      string
+	Detection detections.Detection
+	Schema    schema.Schema
+	DataType  *db.DataType
+}
+
+type queueItem struct {
+	typeName string
+	path     string
+}
+
+func New() *Holder {
+	return &Holder{
+		types:           make(map[string]*objectType),
+		resolvers:       make(map[string]bool),
+		authorizedTypes: make(map[string]bool),
+		dataTypes:       make(map[string][]classifiedField),
+	}
+}
+
+// AddDeclaration adds an object type, resolver or middleware
+func (holder *Holder) AddDeclaration(declaration graphql.Declaration) {
+	switch declaration.Kind {
+	case graphql.KindObjectType:
+		object, exists := holder.types[declaration.TypeName]
+		if !exists {
+			object = &objectType{fields: make(map[string]graphql.Field)}
+			holder.types[declaration.TypeName] = object
+		}
+
+		object.authorized = object.authorized || declaration.Authorized
+		for _, field := range declaration.Fields {
+			field.Authorized = field.Authorized || object.fields[field.Name].Authorized
+			object.fields[field.Name] = field
+		}
+	case graphql.KindResolver:
+		typeName := graphql.NormalizeTypeName(declaration.TypeName)
+		if declaration.FieldName == "" {
+			holder.authorizedTypes[typeName] = holder.authorizedTypes[typeName] || declaration.Authorized
+			return
+		}
+
+		key := resolverKey(typeName, declaration.FieldName)
+		holder.resolvers[key] = holder.resolvers[key] || declaration.Authorized
+	case graphql.KindMiddleware:
+		holder.authenticated = holder.authenticated || declaration.Authorized
+	}
+}
+
+// Detected returns true when a GraphQL schema type or resolver was found
+func (holder *Holder) Detected() bool {
+	return len(holder.types) != 0 || len(holder.resolvers) != 0 || len(holder.authorizedTypes) != 0
+}
+
+// AddSchema adds a field of a GraphQL schema, which is exposed when it is
+// classified as a data type
+func (holder *Holder) AddSchema(detection detections.Detection) error {
+	value, err := detectiondecoder.GetSchema(detection)
+	if err != nil {
+		return err
+	}
+
+	classification, err := detectiondecoder.GetSchemaClassification(value)
+	if err != nil {
+		return err
+	}
+
+	if classification.Decision.State != classify.Valid || classification.DataType == nil {
+		return nil
+	}
+
+	key := value.ObjectName + "." + value.FieldName
+	holder.dataTypes[key] = append(holder.dataTypes[key], classifiedField{
+		detection: detection,
+		schema:    value,
+		dataType:  classification.DataType,
+	})
+
+	return nil
+}
+
+// Exposures returns the classified fields which can be reached from the root
+// query type through fields without any authorization check
+func (holder *Holder) Exposures() []Exposure {
+	var exposures []Exposure
+	if holder.authenticated {
+		return exposures
+	}
+
+	visited := map[string]bool{graphql.QueryTypeName: true}
+	queue := []queueItem{{typeName: graphql.QueryTypeName, path: graphql.QueryTypeName}}
+
+	for len(queue) != 0 {
+		item := queue[0]
+		queue = queue[1:]
+
+		object, exists := holder.types[item.typeName]
+		if !exists || object.authorized || holder.authorizedTypes[graphql.NormalizeTypeName(item.typeName)] {
+			continue
+		}
+
+		fieldNames := make([]string, 0, len(object.fields))
+		for name := range object.fields {
+			fieldNames = append(fieldNames, name)
+		}
+		sort.Strings(fieldNames)
+
+		for _, fieldName := range fieldNames {
+			field := object.fields[fieldName]
+			if field.Authorized || holder.resolvers[resolverKey(graphql.NormalizeTypeName(item.typeName), fieldName)] {
+				continue
+			}
+
+			path := item.path + "." + fieldName
+			for _, classified := range holder.dataTypes[item.typeName+"."+fieldName] {
+				exposures = append(exposures, Exposure{
+					Path:      path,
+					Detection: classified.detection,
+					Schema:    classified.schema,
+					DataType:  classified.dataType,
+				})
+			}
+
+			if !visited[field.Type] {
+				visited[field.Type] = true
+				queue = append(queue, queueItem{typeName: field.Type, path: path})
+			}
+		}
+	}
+
+	return exposures
+}
+
+func resolverKey(typeName, fieldName string) string {
+	return typeName + "." + graphql.NormalizeFieldName(fieldName)
+}
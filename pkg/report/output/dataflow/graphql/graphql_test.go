@@ -0,0 +1,140 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/pkg/report/detections"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	reportgraphql "github.com/bearer/bearer/pkg/report/frameworks/graphql"
+	"github.com/bearer/bearer/pkg/report/output/dataflow/graphql"
+	"github.com/bearer/bearer/pkg/report/schema"
+	"github.com/bearer/bearer/pkg/report/source"
+)
+
+func classifiedField(objectName, fieldName, dataType string) detections.Detection {
+	line := 1
+
+	return detections.Detection{
+		DetectorType: detectors.DetectorGraphQL,
+		Source: source.Source{
+			Filename:          "schema.graphql",
+			StartLineNumber:   &line,
+			StartColumnNumber: &line,
+			EndLineNumber:     &line,
+			EndColumnNumber:   &line,
+		},
+		Value: schema.Schema{
+			ObjectName: objectName,
+			FieldName:  fieldName,
+			Classification: map[string]interface{}{
+				"name":      fieldName,
+				"data_type": map[string]interface{}{"name": dataType},
+				"decision":  map[string]interface{}{"state": "valid"},
+			},
+		},
+	}
+}
+
+func newHolder(t *testing.T, declarations ...reportgraphql.Declaration) *graphql.Holder {
+	holder := graphql.New()
+
+	holder.AddDeclaration(reportgraphql.Declaration{
+		Kind:     reportgraphql.KindObjectType,
+		TypeName: "Query",
+		Fields: []reportgraphql.Field{
+			{Name: "user", Type: "User"},
+			{Name: "admin", Type: "Admin", Authorized: true},
+		},
+	})
+	holder.AddDeclaration(reportgraphql.Declaration{
+		Kind:     reportgraphql.KindObjectType,
+		TypeName: "User",
+		Fields: []reportgraphql.Field{
+			{Name: "email", Type: "String"},
+			{Name: "account", Type: "Account"},
+		},
+	})
+	holder.AddDeclaration(reportgraphql.Declaration{
+		Kind:     reportgraphql.KindObjectType,
+		TypeName: "Account",
+		Fields:   []reportgraphql.Field{{Name: "iban", Type: "String"}},
+	})
+	holder.AddDeclaration(reportgraphql.Declaration{
+		Kind:     reportgraphql.KindObjectType,
+		TypeName: "Admin",
+		Fields:   []reportgraphql.Field{{Name: "email", Type: "String"}},
+	})
+
+	for _, declaration := range declarations {
+		holder.AddDeclaration(declaration)
+	}
+
+	for _, detection := range []detections.Detection{
+		classifiedField("User", "email", "Email Address"),
+		classifiedField("Account", "iban", "Bank Account"),
+		classifiedField("Admin", "email", "Email Address"),
+	} {
+		assert.NoError(t, holder.AddSchema(detection))
+	}
+
+	return holder
+}
+
+func exposedPaths(holder *graphql.Holder) []string {
+	var paths []string
+	for _, exposure := range holder.Exposures() {
+		paths = append(paths, exposure.Path+" "+exposure.DataType.Name)
+	}
+
+	return paths
+}
+
+func TestExposures(t *testing.T) {
+	assert.Equal(t, []string{
+		"Query.user.email Email Address",
+		"Query.user.account.iban Bank Account",
+	}, exposedPaths(newHolder(t)))
+}
+
+func TestExposuresAuthorizedResolver(t *testing.T) {
+	holder := newHolder(t, reportgraphql.Declaration{
+		Kind:       reportgraphql.KindResolver,
+		TypeName:   "UserType",
+		FieldName:  "account",
+		Authorized: true,
+	})
+
+	assert.Equal(t, []string{"Query.user.email Email Address"}, exposedPaths(holder))
+}
+
+func TestExposuresAuthorizedType(t *testing.T) {
+	holder := newHolder(t, reportgraphql.Declaration{
+		Kind:       reportgraphql.KindResolver,
+		TypeName:   "Types::UserType",
+		Authorized: true,
+	})
+
+	assert.Empty(t, exposedPaths(holder))
+}
+
+func TestExposuresMiddleware(t *testing.T) {
+	holder := newHolder(t, reportgraphql.Declaration{
+		Kind:       reportgraphql.KindMiddleware,
+		Authorized: true,
+	})
+
+	assert.Empty(t, exposedPaths(holder))
+}
+
+func TestDetected(t *testing.T) {
+	holder := graphql.New()
+	holder.AddDeclaration(reportgraphql.Declaration{Kind: reportgraphql.KindMiddleware, Authorized: true})
+	assert.False(t, holder.Detected())
+
+	holder.AddDeclaration(reportgraphql.Declaration{Kind: reportgraphql.KindResolver, TypeName: "UserType", FieldName: "email"})
+	assert.True(t, holder.Detected())
+
+	assert.True(t, newHolder(t).Detected())
+}
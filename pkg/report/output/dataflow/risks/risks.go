@@ -3,13 +3,18 @@ package risks
 import (
 	"bytes"
 	"encoding/json"
+	"slices"
 
 	"github.com/bearer/bearer/pkg/classification/db"
 	"github.com/bearer/bearer/pkg/commands/process/settings"
 	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/report/frameworks/graphql"
+	"github.com/bearer/bearer/pkg/report/frameworks/iac"
 	"github.com/bearer/bearer/pkg/report/output/dataflow/detectiondecoder"
+	graphqldataflow "github.com/bearer/bearer/pkg/report/output/dataflow/graphql"
 	"github.com/bearer/bearer/pkg/report/output/dataflow/types"
 	"github.com/bearer/bearer/pkg/report/schema"
+	"github.com/bearer/bearer/pkg/report/secret"
 
 	"github.com/bearer/bearer/pkg/report/detections"
 	"github.com/bearer/bearer/pkg/util/classify"
@@ -19,6 +24,14 @@ import (
 type Holder struct {
 	detectors map[string]detectorHolder // group detections by detector name
 	config    settings.Config
+	// secretFingerprints are the fingerprints of the secrets in the working tree
+	secretFingerprints map[string]bool
+	historySecrets     []historySecret
+}
+
+type historySecret struct {
+	detection detections.Detection
+	secret    secret.Secret
 }
 
 type detectorHolder struct {
@@ -43,9 +56,18 @@ type lineHolder struct {
 type sourceHolder struct {
 	name    string
 	source  *schema.Source
+	secret  *secretLocation
 	matches map[string]matchCategoryHolder // group detections by datatype category
 }
 
+// secretLocation holds the details of a secret leak
+type secretLocation struct {
+	ruleID   string
+	validity string
+	// commit is set for secrets found in the git history
+	commit *types.RiskCommit
+}
+
 type matchCategoryHolder struct {
 	name         string
 	categoryUUID *string
@@ -65,12 +87,39 @@ var categoryDatatype = "datatype"
 
 func New(config settings.Config, isInternal bool) *Holder {
 	return &Holder{
-		detectors: make(map[string]detectorHolder),
-		config:    config,
+		detectors:          make(map[string]detectorHolder),
+		config:             config,
+		secretFingerprints: make(map[string]bool),
+	}
+}
+
+// AddSecretLeak adds a secret found by the secrets scanner. Secrets found in the
+// git history are only added when they are not also in the working tree
+func (holder *Holder) AddSecretLeak(detection detections.Detection) error {
+	value, err := detectiondecoder.GetSecret(detection.Value)
+	if err != nil {
+		return err
 	}
+
+	if value.Commit != nil {
+		holder.historySecrets = append(holder.historySecrets, historySecret{detection: detection, secret: value})
+		return nil
+	}
+
+	if value.Fingerprint != "" {
+		holder.secretFingerprints[value.Fingerprint] = true
+	}
+
+	holder.addPresence(detection, &secretLocation{ruleID: value.RuleID, validity: value.Validity})
+
+	return nil
 }
 
 func (holder *Holder) AddRiskPresence(detection detections.Detection) {
+	holder.addPresence(detection, nil)
+}
+
+func (holder *Holder) addPresence(detection detections.Detection, secret *secretLocation) {
 	// create entry if it doesn't exist
 	ruleName := string(detection.DetectorType)
 
@@ -108,6 +157,7 @@ func (holder *Holder) AddRiskPresence(detection detections.Detection) {
 			Source: source,
 		},
 		categoryPresence,
+		secret,
 	)
 }
 
@@ -135,12 +185,81 @@ func (holder *Holder) AddSchema(detection detections.Detection) error {
 			*detection.Source.EndColumnNumber,
 			schema,
 			categoryDatatype,
+			nil,
 		)
 	}
 
 	return nil
 }
 
+// AddDataStore adds the risks for a data store declared in
+// infrastructure-as-code, eg. when it isn't encrypted
+func (holder *Holder) AddDataStore(detection detections.Detection, dataStore iac.DataStore) {
+	var ruleNames []string
+	if dataStore.IsUnencrypted() {
+		ruleNames = append(ruleNames, iac.UnencryptedDataStoreRuleID)
+	}
+	if dataStore.IsPublic() {
+		ruleNames = append(ruleNames, iac.PublicDataStoreRuleID)
+	}
+
+	content := dataStore.ResourceType + " " + dataStore.Name
+
+	for _, ruleName := range ruleNames {
+		holder.addDatatype(
+			ruleName,
+			&db.DataType{},
+			nil,
+			detection.Source.Filename,
+			detection.Source.FullFilename,
+			*detection.Source.StartLineNumber,
+			*detection.Source.StartColumnNumber,
+			*detection.Source.EndLineNumber,
+			*detection.Source.EndColumnNumber,
+			schema.Schema{
+				Source: &schema.Source{
+					StartLineNumber:   *detection.Source.StartLineNumber,
+					StartColumnNumber: *detection.Source.StartColumnNumber,
+					EndLineNumber:     *detection.Source.EndLineNumber,
+					EndColumnNumber:   *detection.Source.EndColumnNumber,
+					Content:           content,
+				},
+			},
+			categoryPresence,
+			nil,
+		)
+	}
+}
+
+// AddGraphQLExposure adds the risk for a GraphQL field exposing sensitive
+// data without authorization
+func (holder *Holder) AddGraphQLExposure(exposure graphqldataflow.Exposure) {
+	detection := exposure.Detection
+	fieldSchema := exposure.Schema
+	fieldSchema.Source = &schema.Source{
+		StartLineNumber:   *detection.Source.StartLineNumber,
+		StartColumnNumber: *detection.Source.StartColumnNumber,
+		EndLineNumber:     *detection.Source.EndLineNumber,
+		EndColumnNumber:   *detection.Source.EndColumnNumber,
+		Content:           exposure.Path,
+	}
+
+	holder.addDatatype(
+		graphql.UnauthenticatedSensitiveDataRuleID,
+		exposure.DataType,
+		nil,
+		detection.Source.Filename,
+		detection.Source.FullFilename,
+		*detection.Source.StartLineNumber,
+		*detection.Source.StartColumnNumber,
+		*detection.Source.EndLineNumber,
+		*detection.Source.EndColumnNumber,
+		fieldSchema,
+		categoryDatatype,
+		nil,
+	)
+}
+
 // addDatatype adds detector to hash list and at the same time blocks duplicates
 func (holder *Holder) addDatatype(
 	ruleName string,
@@ -154,6 +273,7 @@ func (holder *Holder) addDatatype(
 	endColumnNumber int,
 	schema schema.Schema,
 	category string,
+	secret *secretLocation,
 ) {
 	if datatype == nil {
 		// FIXME: we end up with empty field Name and no datatype with the new code
@@ -197,13 +317,23 @@ func (holder *Holder) addDatatype(
 	if schema.Source != nil {
 		sourceKey = schema.Source.Content
 	}
+	if secret != nil {
+		sourceKey = secret.ruleID + ":" + sourceKey
+		if secret.commit != nil {
+			sourceKey = secret.commit.SHA + ":" + sourceKey
+		}
+	}
 
 	if _, exists := line.source[sourceKey]; !exists {
 		line.source[sourceKey] = sourceHolder{
 			name:    sourceKey,
 			source:  schema.Source,
+			secret:  secret,
 			matches: make(map[string]matchCategoryHolder),
 		}
+	} else if schema.Source != nil {
+		// the same detection can be reached through several dataflows
+		addDataflow(line.source[sourceKey].source, schema.Source.Dataflow)
 	}
 
 	source := line.source[sourceKey]
@@ -234,7 +364,21 @@ func (holder *Holder) addDatatype(
 	}
 }
 
+func addDataflow(source *schema.Source, dataflow []schema.DataflowLocation) {
+	if source == nil {
+		return
+	}
+
+	for _, location := range dataflow {
+		if !slices.Contains(source.Dataflow, location) {
+			source.Dataflow = append(source.Dataflow, location)
+		}
+	}
+}
+
 func (holder *Holder) ToDataFlow() []types.RiskDetector {
+	holder.flushHistorySecrets()
+
 	data := make([]types.RiskDetector, 0)
 
 	for _, detector := range maputil.ToSortedSlice(holder.detectors) {
@@ -255,6 +399,11 @@ func (holder *Holder) ToDataFlow() []types.RiskDetector {
 						EndLineNumber:     line.endLineNumber,
 						Source:            source.source,
 					}
+					if source.secret != nil {
+						location.SecretRuleID = source.secret.ruleID
+						location.SecretValidity = source.secret.validity
+						location.Commit = source.secret.commit
+					}
 
 					hasDatatype := false
 					matches := maputil.ToSortedSlice(source.matches)
@@ -313,6 +462,28 @@ func (holder *Holder) ToDataFlow() []types.RiskDetector {
 	return data
 }
 
+// flushHistorySecrets adds the secrets of the git history which have since been
+// removed from the working tree
+func (holder *Holder) flushHistorySecrets() {
+	for _, historySecret := range holder.historySecrets {
+		if holder.secretFingerprints[historySecret.secret.Fingerprint] {
+			continue
+		}
+
+		holder.addPresence(historySecret.detection, &secretLocation{
+			ruleID:   historySecret.secret.RuleID,
+			validity: historySecret.secret.Validity,
+			commit: &types.RiskCommit{
+				SHA:    historySecret.secret.Commit.SHA,
+				Author: historySecret.secret.Commit.Author,
+				Date:   historySecret.secret.Commit.Date,
+			},
+		})
+	}
+
+	holder.historySecrets = nil
+}
+
 // removeParentBasedDuplicates checks if there are 2 risk locations one with presence and one with datatype which have same parent line number and parentContent and if it finds such case it discards the presence one
 func removeParentBasedDuplicates(data []types.RiskDetector) []types.RiskDetector {
 	filteredData := []types.RiskDetector{}
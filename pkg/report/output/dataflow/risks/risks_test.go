@@ -12,6 +12,7 @@ import (
 	"github.com/bearer/bearer/pkg/report/output/dataflow/types"
 	"github.com/bearer/bearer/pkg/report/output/detectors"
 	outputtypes "github.com/bearer/bearer/pkg/report/output/types"
+	"github.com/bearer/bearer/pkg/report/schema"
 	globaltypes "github.com/bearer/bearer/pkg/types"
 )
 
@@ -208,6 +209,60 @@ func TestDataflowRisks(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:   "secret leaks - git history",
+			Config: config,
+			FileContent: `{"type": "secret_leak", "detector_type": "gitleaks", "source": {"filename": "./old.rb", "start_line_number": 1, "end_line_number": 1, "start_column_number": 9, "end_column_number": 20}, "value": {"description": "AWS Access Token", "rule_id": "AWS", "fingerprint": "present", "commit": {"sha": "abc123", "author": "Dev <dev@example.com>", "date": "2024-01-01T00:00:00Z"}}}
+		{"type": "secret_leak", "detector_type": "gitleaks", "source": {"filename": "./old.rb", "start_line_number": 2, "end_line_number": 2, "start_column_number": 9, "end_column_number": 20}, "value": {"description": "AWS Access Token", "rule_id": "AWS", "fingerprint": "removed", "commit": {"sha": "abc123", "author": "Dev <dev@example.com>", "date": "2024-01-01T00:00:00Z"}}}
+		{"type": "secret_leak", "detector_type": "gitleaks", "source": {"filename": "./config.rb", "start_line_number": 3, "end_line_number": 3, "start_column_number": 9, "end_column_number": 20}, "value": {"description": "AWS Access Token", "rule_id": "AWS", "fingerprint": "present", "validity": "likely-live"}}`,
+			Want: []types.RiskDetector{
+				{
+					DetectorID: "gitleaks",
+					Locations: []types.RiskLocation{
+						{
+							Filename:          "./config.rb",
+							FullFilename:      "./config.rb",
+							StartLineNumber:   3,
+							EndLineNumber:     3,
+							StartColumnNumber: 9,
+							EndColumnNumber:   20,
+							Source: &schema.Source{
+								StartLineNumber:   3,
+								EndLineNumber:     3,
+								StartColumnNumber: 9,
+								EndColumnNumber:   20,
+								Content:           "AWS Access Token",
+							},
+							PresenceMatches: []types.RiskPresence{{Name: "AWS Access Token"}},
+							SecretRuleID:    "AWS",
+							SecretValidity:  "likely-live",
+						},
+						{
+							Filename:          "./old.rb",
+							FullFilename:      "./old.rb",
+							StartLineNumber:   2,
+							EndLineNumber:     2,
+							StartColumnNumber: 9,
+							EndColumnNumber:   20,
+							Source: &schema.Source{
+								StartLineNumber:   2,
+								EndLineNumber:     2,
+								StartColumnNumber: 9,
+								EndColumnNumber:   20,
+								Content:           "AWS Access Token",
+							},
+							PresenceMatches: []types.RiskPresence{{Name: "AWS Access Token"}},
+							SecretRuleID:    "AWS",
+							Commit: &types.RiskCommit{
+								SHA:    "abc123",
+								Author: "Dev <dev@example.com>",
+								Date:   "2024-01-01T00:00:00Z",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
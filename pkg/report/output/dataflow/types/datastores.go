@@ -0,0 +1,14 @@
+package types
+
+type DataStore struct {
+	Name         string `json:"name" yaml:"name"`
+	ResourceType string `json:"resource_type" yaml:"resource_type"`
+	Kind         string `json:"kind" yaml:"kind"`
+	Engine       string `json:"engine,omitempty" yaml:"engine,omitempty"`
+	Technology   string `json:"technology,omitempty" yaml:"technology,omitempty"`
+	Encrypted    *bool  `json:"encrypted,omitempty" yaml:"encrypted,omitempty"`
+	Public       *bool  `json:"public,omitempty" yaml:"public,omitempty"`
+	FullFilename string `json:"full_filename" yaml:"full_filename"`
+	Filename     string `json:"filename" yaml:"filename"`
+	LineNumber   int    `json:"line_number" yaml:"line_number"`
+}
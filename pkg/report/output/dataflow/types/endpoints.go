@@ -0,0 +1,17 @@
+package types
+
+// Endpoint is a data type accepted (in the request) or returned (in the
+// response) by an API endpoint. LineNumber is the line of the first field of
+// the data type in the spec
+type Endpoint struct {
+	HttpMethod     string   `json:"http_method" yaml:"http_method"`
+	Path           string   `json:"path" yaml:"path"`
+	Direction      string   `json:"direction" yaml:"direction"`
+	DataType       string   `json:"name" yaml:"name"`
+	CategoryName   string   `json:"category_name,omitempty" yaml:"category_name,omitempty"`
+	CategoryGroups []string `json:"category_groups,omitempty" yaml:"category_groups,omitempty"`
+	FieldName      string   `json:"field_name" yaml:"field_name"`
+	FullFilename   string   `json:"full_filename" yaml:"full_filename"`
+	Filename       string   `json:"filename" yaml:"filename"`
+	LineNumber     int      `json:"line_number" yaml:"line_number"`
+}
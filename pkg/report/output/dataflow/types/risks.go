@@ -17,6 +17,16 @@ type RiskLocation struct {
 	Source            *schema.Source `json:"source,omitempty" yaml:"source,omitempty"`
 	DataTypes         []RiskDatatype `json:"data_types,omitempty" yaml:"data_types,omitempty"`
 	PresenceMatches   []RiskPresence `json:"presence_matches,omitempty" yaml:"presence_matches,omitempty"`
+	SecretRuleID      string         `json:"secret_rule_id,omitempty" yaml:"secret_rule_id,omitempty"`
+	SecretValidity    string         `json:"secret_validity,omitempty" yaml:"secret_validity,omitempty"`
+	Commit            *RiskCommit    `json:"commit,omitempty" yaml:"commit,omitempty"`
+}
+
+// RiskCommit is the commit which added a secret found in the git history
+type RiskCommit struct {
+	SHA    string `json:"sha" yaml:"sha"`
+	Author string `json:"author" yaml:"author"`
+	Date   string `json:"date" yaml:"date"`
 }
 
 type RiskDatatype struct {
@@ -16,6 +16,7 @@ func ReportGitLab(
 	endTime time.Time,
 ) (gitlab.GitLabOutput, error) {
 	var vulnerabilities []gitlab.Vulnerability
+	failed := false
 	for _, level := range []string{"critical", "high", "medium", "low", "warning"} {
 		if findings, ok := outputDetections[level]; ok {
 			for _, finding := range findings {
@@ -36,7 +37,7 @@ func ReportGitLab(
 					})
 				}
 
-				vulnerabilities = append(vulnerabilities, gitlab.Vulnerability{
+				vulnerability := gitlab.Vulnerability{
 					Id:                   finding.Fingerprint,
 					Category:             "sast",
 					Name:                 finding.Rule.Title,
@@ -55,7 +56,15 @@ func ReportGitLab(
 						Endline:   finding.Sink.End,
 					},
 					Identifiers: identifiers,
-				})
+				}
+
+				if finding.Suppressed {
+					addIgnoreMeta(&vulnerability, finding)
+				} else {
+					failed = true
+				}
+
+				vulnerabilities = append(vulnerabilities, vulnerability)
 			}
 		}
 	}
@@ -86,15 +95,37 @@ func ReportGitLab(
 			Type:      "sast",
 			StartTime: startTime.Format("2006-01-02T15:04:05"),
 			EndTime:   endTime.Format("2006-01-02T15:04:05"),
-			Status:    calculateStatus(vulnerabilities),
+			Status:    calculateStatus(failed),
 		},
 	}
 
 	return output, nil
 }
 
-func calculateStatus(vulnerabilities []gitlab.Vulnerability) string {
-	if len(vulnerabilities) > 0 {
+// addIgnoreMeta records why an ignored finding was ignored, flagging it when
+// it was ignored as a false positive
+func addIgnoreMeta(vulnerability *gitlab.Vulnerability, finding securitytypes.Finding) {
+	vulnerability.Details = map[string]gitlab.Detail{
+		"ignored": {
+			Type:  "text",
+			Name:  "Ignored",
+			Value: finding.IgnoreSummary(),
+		},
+	}
+
+	if finding.Ignore != nil && finding.Ignore.FalsePositive {
+		vulnerability.Flags = []gitlab.Flag{{
+			Type:        "flagged-as-likely-false-positive",
+			Origin:      "bearer",
+			Description: finding.IgnoreSummary(),
+		}}
+	}
+}
+
+// calculateStatus reports a failure when there are findings which were not
+// ignored
+func calculateStatus(failed bool) string {
+	if failed {
 		return "failure"
 	} else {
 		return "success"
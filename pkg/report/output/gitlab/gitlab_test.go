@@ -10,6 +10,7 @@ import (
 	"github.com/bradleyjkemp/cupaloy"
 
 	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
+	ignoretypes "github.com/bearer/bearer/pkg/util/ignore/types"
 	util "github.com/bearer/bearer/pkg/util/output"
 )
 
@@ -45,3 +46,42 @@ func TestJuiceShopSarif(t *testing.T) {
 	}
 	cupaloy.SnapshotT(t, prettyJSON.String())
 }
+
+func TestIgnoredFindingsGitLab(t *testing.T) {
+	comment := "test fixture"
+	ignoredFinding := securitytypes.IgnoredFinding{
+		Finding: securitytypes.Finding{
+			Rule:        &securitytypes.Rule{Id: "ruby_lang_logger", Title: "Leakage of information in logger message"},
+			Filename:    "spec/fixtures/user.rb",
+			Sink:        securitytypes.Sink{Location: &securitytypes.Location{Start: 3, End: 3}},
+			Fingerprint: "ignored_0",
+		},
+		IgnoreMeta: ignoretypes.IgnoredFingerprint{
+			Comment:       &comment,
+			FalsePositive: true,
+			IgnoredAt:     "2024-03-18T09:12:44Z",
+		},
+	}
+
+	res, err := ReportGitLab(
+		map[string][]securitytypes.Finding{"high": {ignoredFinding.Suppressed()}},
+		time.Time{},
+		time.Time{},
+	)
+	if err != nil {
+		t.Fatalf("failed to generate security output, err: %s", err)
+	}
+
+	if res.Scan.Status != "success" {
+		t.Errorf("expected ignored findings not to fail the scan, got status %s", res.Scan.Status)
+	}
+
+	vulnerability := res.Vulnerabilities[0]
+	expectedSummary := "Ignored on 2024-03-18T09:12:44Z as a false positive: test fixture"
+	if vulnerability.Details["ignored"].Value != expectedSummary {
+		t.Errorf("expected ignored detail %q, got %q", expectedSummary, vulnerability.Details["ignored"].Value)
+	}
+	if len(vulnerability.Flags) != 1 || vulnerability.Flags[0].Type != "flagged-as-likely-false-positive" {
+		t.Errorf("expected a false positive flag, got %+v", vulnerability.Flags)
+	}
+}
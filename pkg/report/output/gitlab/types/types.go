@@ -13,6 +13,20 @@ type Vulnerability struct {
 	Scanner              VulnerabilityScanner `json:"scanner"`
 	Location             Location             `json:"location"`
 	Identifiers          []Identifier         `json:"identifiers"`
+	Flags                []Flag               `json:"flags,omitempty"`
+	Details              map[string]Detail    `json:"details,omitempty"`
+}
+
+type Flag struct {
+	Type        string `json:"type"`   // flagged-as-likely-false-positive
+	Origin      string `json:"origin"` // bearer
+	Description string `json:"description"`
+}
+
+type Detail struct {
+	Type  string `json:"type"` // text
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 type Identifier struct {
@@ -22,6 +22,9 @@ var securityTemplate string
 //go:embed privacy.tmpl
 var privacyTemplate string
 
+//go:embed compare.tmpl
+var compareTemplate string
+
 //go:embed wrapper.tmpl
 var wrapperTemplate string
 
@@ -54,9 +57,20 @@ func ReportHTMLWrapper(title string, body *string) (string, error) {
 	return htmlContent.String(), nil
 }
 
-func ReportSecurityHTML(detections map[string][]securitytypes.Finding) (*string, error) {
+func ReportSecurityHTML(
+	detections map[string][]securitytypes.Finding,
+	ignoredDetections map[string][]securitytypes.IgnoredFinding,
+) (*string, error) {
 	htmlContent := &strings.Builder{}
 
+	securityPage := html.SecurityHTMLBody{
+		Findings:        detections,
+		IgnoredFindings: ignoredDetections,
+	}
+	for _, ignoredFindings := range ignoredDetections {
+		securityPage.IgnoredCount += len(ignoredFindings)
+	}
+
 	findingsTemplate, err := template.New("findingsTemplate").Funcs(template.FuncMap{
 		"kebabCase":      kebabCase,
 		"markdownToHtml": markdownToHtml,
@@ -67,7 +81,7 @@ func ReportSecurityHTML(detections map[string][]securitytypes.Finding) (*string,
 	if err != nil {
 		return nil, err
 	}
-	err = findingsTemplate.Execute(htmlContent, detections)
+	err = findingsTemplate.Execute(htmlContent, securityPage)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +96,9 @@ func ReportPrivacyHTML(privacyReport *privacytypes.Report) (*string, error) {
 	privacyPage := html.PrivacyHTMLBody{
 		GroupedDataSubject: make([]html.GroupedDataSubject, 0),
 		GroupedThirdParty:  make([]html.GroupedThirdParty, 0),
+		DataStores:         privacyReport.DataStores,
+		APISurface:         privacyReport.APISurface,
+		Owners:             privacyReport.Owners,
 	}
 
 	subjectGroups := make(map[string][]privacytypes.Subject)
@@ -111,7 +128,8 @@ func ReportPrivacyHTML(privacyReport *privacytypes.Report) (*string, error) {
 	}
 
 	subjectTemplate, err := template.New("subjectTemplate").Funcs(template.FuncMap{
-		"kebabCase": kebabCase,
+		"kebabCase":     kebabCase,
+		"formatSetting": privacytypes.FormatSetting,
 	}).Parse(privacyTemplate)
 	if err != nil {
 		return nil, err
@@ -126,6 +144,34 @@ func ReportPrivacyHTML(privacyReport *privacytypes.Report) (*string, error) {
 	return &content, nil
 }
 
+func ReportComparisonHTML(comparison securitytypes.Comparison) (*string, error) {
+	htmlContent := &strings.Builder{}
+
+	comparisonPage := html.ComparisonHTMLBody{
+		Summary: comparison.Summary,
+		Sections: []html.ComparisonSection{
+			{Title: "New findings", Findings: comparison.New},
+			{Title: "Fixed findings", Findings: comparison.Fixed},
+			{Title: "Unchanged findings", Findings: comparison.Unchanged},
+		},
+	}
+
+	comparisonTemplate, err := template.New("comparisonTemplate").Funcs(template.FuncMap{
+		"markdownToHtml": markdownToHtml,
+		"joinCwe":        joinCwe,
+	}).Parse(compareTemplate)
+	if err != nil {
+		return nil, err
+	}
+	err = comparisonTemplate.Execute(htmlContent, comparisonPage)
+	if err != nil {
+		return nil, err
+	}
+
+	content := htmlContent.String()
+	return &content, nil
+}
+
 func kebabCase(s string) string {
 	return strings.ReplaceAll(strings.ToLower(s), " ", "-")
 }
@@ -9,6 +9,7 @@ import (
 
 	privacytypes "github.com/bearer/bearer/pkg/report/output/privacy/types"
 	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
+	ignoretypes "github.com/bearer/bearer/pkg/util/ignore/types"
 )
 
 func TestJuiceShopSecurityHtml(t *testing.T) {
@@ -23,7 +24,7 @@ func TestJuiceShopSecurityHtml(t *testing.T) {
 		t.Fatalf("couldn't unmarshal file output: %s", err)
 	}
 
-	output, err := ReportSecurityHTML(securityResults)
+	output, err := ReportSecurityHTML(securityResults, nil)
 	if err != nil {
 		t.Fatalf("failed to generate security output, err: %s", err)
 	}
@@ -31,6 +32,42 @@ func TestJuiceShopSecurityHtml(t *testing.T) {
 	snapshotter := cupaloy.New(cupaloy.SnapshotFileExtension(".html"))
 	snapshotter.SnapshotT(t, []byte(*output))
 }
+func TestIgnoredFindingsSecurityHtml(t *testing.T) {
+	author := "Mish Bear"
+	comment := "Only logs <redacted> values"
+	ignoredFindings := map[string][]securitytypes.IgnoredFinding{
+		"high": {
+			{
+				Finding: securitytypes.Finding{
+					Rule: &securitytypes.Rule{
+						Id:          "ruby_lang_logger",
+						Title:       "Leakage of information in logger message",
+						Description: "## Description\n\nLeaking information to loggers is a common cause of data leaks.",
+						CWEIDs:      []string{"532"},
+					},
+					Filename:    "app/models/user.rb",
+					LineNumber:  12,
+					Fingerprint: "ignored_0",
+				},
+				IgnoreMeta: ignoretypes.IgnoredFingerprint{
+					Author:        &author,
+					Comment:       &comment,
+					FalsePositive: true,
+					IgnoredAt:     "2024-03-18T09:12:44Z",
+				},
+			},
+		},
+	}
+
+	output, err := ReportSecurityHTML(map[string][]securitytypes.Finding{}, ignoredFindings)
+	if err != nil {
+		t.Fatalf("failed to generate security output, err: %s", err)
+	}
+
+	snapshotter := cupaloy.New(cupaloy.SnapshotFileExtension(".html"))
+	snapshotter.SnapshotT(t, []byte(*output))
+}
+
 func TestBearPublishingPrivacyHtml(t *testing.T) {
 	privacyOutput, err := os.ReadFile("testdata/bear-publishing-privacy-report.json")
 	if err != nil {
@@ -50,3 +87,40 @@ func TestBearPublishingPrivacyHtml(t *testing.T) {
 	snapshotter := cupaloy.New(cupaloy.SnapshotFileExtension(".html"))
 	snapshotter.SnapshotT(t, []byte(*output))
 }
+
+func TestComparisonHtml(t *testing.T) {
+	finding := func(severity, fingerprint string) securitytypes.RawFinding {
+		return securitytypes.RawFinding{
+			Finding: &securitytypes.Finding{
+				Rule: &securitytypes.Rule{
+					Id:          "ruby_lang_logger",
+					Title:       "Leakage of information in logger message",
+					Description: "## Description\n\nLeaking information to loggers is a common cause of data leaks.",
+					CWEIDs:      []string{"532"},
+				},
+				Filename:    "app/models/user.rb",
+				LineNumber:  12,
+				Fingerprint: fingerprint,
+			},
+			Severity: severity,
+		}
+	}
+
+	comparison := securitytypes.Comparison{
+		Summary: securitytypes.ComparisonSummary{
+			New:   securitytypes.FindingCount{Total: 1, BySeverity: map[string]int{"high": 1}},
+			Fixed: securitytypes.FindingCount{Total: 1, BySeverity: map[string]int{"medium": 1}},
+		},
+		New:       []securitytypes.RawFinding{finding("high", "new_0")},
+		Fixed:     []securitytypes.RawFinding{finding("medium", "fixed_0")},
+		Unchanged: []securitytypes.RawFinding{},
+	}
+
+	output, err := ReportComparisonHTML(comparison)
+	if err != nil {
+		t.Fatalf("failed to generate comparison output, err: %s", err)
+	}
+
+	snapshotter := cupaloy.New(cupaloy.SnapshotFileExtension(".html"))
+	snapshotter.SnapshotT(t, []byte(*output))
+}
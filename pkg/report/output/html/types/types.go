@@ -2,6 +2,7 @@ package types
 
 import (
 	privacytypes "github.com/bearer/bearer/pkg/report/output/privacy/types"
+	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
 )
 
 type GroupedThirdParty struct {
@@ -16,6 +17,25 @@ type GroupedDataSubject struct {
 type PrivacyHTMLBody = struct {
 	GroupedDataSubject []GroupedDataSubject
 	GroupedThirdParty  []GroupedThirdParty
+	DataStores         []privacytypes.DataStore
+	APISurface         []privacytypes.Endpoint
+	Owners             []privacytypes.Owner
+}
+
+type SecurityHTMLBody = struct {
+	Findings        map[string][]securitytypes.Finding
+	IgnoredFindings map[string][]securitytypes.IgnoredFinding
+	IgnoredCount    int
+}
+
+type ComparisonSection struct {
+	Title    string
+	Findings []securitytypes.RawFinding
+}
+
+type ComparisonHTMLBody = struct {
+	Summary  securitytypes.ComparisonSummary
+	Sections []ComparisonSection
 }
 
 type WrapperHTMLPage = struct {
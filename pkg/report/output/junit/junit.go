@@ -0,0 +1,129 @@
+package junit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bearer/bearer/pkg/commands/process/settings"
+	junit "github.com/bearer/bearer/pkg/report/output/junit/types"
+	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
+	globaltypes "github.com/bearer/bearer/pkg/types"
+)
+
+// ReportJUnit reports each rule as a test case. Findings are failures of the
+// rule's test case, and a rule with only ignored findings is skipped
+func ReportJUnit(
+	rules []*settings.Rule,
+	findingsBySeverity map[string][]securitytypes.Finding,
+	ignoredFindingsBySeverity map[string][]securitytypes.IgnoredFinding,
+	startTime time.Time,
+	endTime time.Time,
+) (junit.TestSuites, error) {
+	var ruleIDs []string
+	seenRuleIDs := make(map[string]bool)
+	addRule := func(ruleID string) {
+		if !seenRuleIDs[ruleID] {
+			seenRuleIDs[ruleID] = true
+			ruleIDs = append(ruleIDs, ruleID)
+		}
+	}
+
+	for _, rule := range rules {
+		addRule(rule.Id)
+	}
+
+	failures := make(map[string][]junit.Failure)
+	ignored := make(map[string][]string)
+	for _, severity := range globaltypes.Severities {
+		for _, finding := range findingsBySeverity[severity] {
+			addRule(finding.Rule.Id)
+			failures[finding.Rule.Id] = append(failures[finding.Rule.Id], junit.Failure{
+				Message: fmt.Sprintf("%s %s", location(finding), finding.Rule.Title),
+				Type:    severity,
+				Text:    failureText(finding, severity),
+			})
+		}
+
+		for _, finding := range ignoredFindingsBySeverity[severity] {
+			addRule(finding.Rule.Id)
+			ignored[finding.Rule.Id] = append(
+				ignored[finding.Rule.Id],
+				fmt.Sprintf("%s (%s)", location(finding.Finding), finding.Fingerprint),
+			)
+		}
+	}
+
+	sort.Strings(ruleIDs)
+
+	suite := junit.TestSuite{
+		Name:      "bearer",
+		Time:      formatDuration(endTime.Sub(startTime)),
+		Timestamp: startTime.Format("2006-01-02T15:04:05"),
+	}
+
+	for _, ruleID := range ruleIDs {
+		testCase := junit.TestCase{
+			Name:      ruleID,
+			ClassName: "bearer",
+			Failures:  failures[ruleID],
+		}
+
+		if ignoredLocations := ignored[ruleID]; len(ignoredLocations) != 0 {
+			ignoredText := "Ignored findings:\n" + strings.Join(ignoredLocations, "\n")
+
+			if len(testCase.Failures) == 0 {
+				testCase.Skipped = &junit.Skipped{
+					Message: fmt.Sprintf("%d ignored finding(s)", len(ignoredLocations)),
+					Text:    ignoredText,
+				}
+			} else {
+				testCase.SystemOut = ignoredText
+			}
+		}
+
+		suite.Tests++
+		if len(testCase.Failures) != 0 {
+			suite.Failures++
+		} else if testCase.Skipped != nil {
+			suite.Skipped++
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	return junit.TestSuites{
+		Name:     "Bearer",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junit.TestSuite{suite},
+	}, nil
+}
+
+func location(finding securitytypes.Finding) string {
+	return fmt.Sprintf("%s:%d", finding.Filename, finding.Sink.Start)
+}
+
+func failureText(finding securitytypes.Finding, severity string) string {
+	rule := finding.Rule.Id
+	if len(finding.CWEIDs) != 0 {
+		rule += " (CWE-" + strings.Join(finding.CWEIDs, ", CWE-") + ")"
+	}
+
+	text := fmt.Sprintf("%s\nSeverity: %s\nRule: %s\n", location(finding), severity, rule)
+	if finding.Rule.Description != "" {
+		text += "\n" + finding.Rule.Description + "\n"
+	}
+	if finding.Rule.DocumentationUrl != "" {
+		text += "\nDocumentation: " + finding.Rule.DocumentationUrl + "\n"
+	}
+
+	return text
+}
+
+func formatDuration(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
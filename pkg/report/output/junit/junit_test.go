@@ -0,0 +1,55 @@
+package junit_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/pkg/commands/process/settings"
+	"github.com/bearer/bearer/pkg/report/output/junit"
+	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
+	"github.com/bearer/bearer/pkg/util/output"
+)
+
+func TestRailsGoatJUnit(t *testing.T) {
+	securityOutput, err := os.ReadFile("../reviewdog/testdata/rails-goat-security-report.json")
+	if err != nil {
+		t.Fatalf("failed to read file, err: %s", err)
+	}
+
+	var securityFindings map[string][]securitytypes.Finding
+	err = json.Unmarshal(securityOutput, &securityFindings)
+	if err != nil {
+		t.Fatalf("couldn't unmarshal file output: %s", err)
+	}
+
+	// ignore the only finding for one rule, and one of several for another
+	warnings := securityFindings["warning"]
+	ignoredFindings := map[string][]securitytypes.IgnoredFinding{
+		"warning": {{Finding: warnings[0]}, {Finding: warnings[7]}},
+	}
+	securityFindings["warning"] = warnings[1:7]
+
+	rules := []*settings.Rule{
+		{Id: "ruby_lang_cookies"},
+		{Id: "ruby_lang_logger"},
+	}
+
+	startTime, _ := time.Parse("2006-01-02T15:04:05", "2006-01-02T15:04:05")
+	endTime, _ := time.Parse("2006-01-02T15:04:05", "2006-01-02T15:05:05")
+
+	res, err := junit.ReportJUnit(rules, securityFindings, ignoredFindings, startTime, endTime)
+	if err != nil {
+		t.Fatalf("failed to generate security output, err: %s", err)
+	}
+
+	junitOutput, err := output.ReportXML(res)
+	if err != nil {
+		t.Fatalf("failed to generate XML output, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, junitOutput)
+}
@@ -38,7 +38,7 @@ func GetData(
 	gitContext *gitrepository.Context,
 	baseBranchFindings *basebranchfindings.Findings,
 ) (*types.ReportData, error) {
-	data := &types.ReportData{}
+	data := &types.ReportData{GitContext: gitContext}
 
 	// add languages
 	languages := make(map[string]int32)
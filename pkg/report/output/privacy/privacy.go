@@ -10,7 +10,9 @@ import (
 
 	"github.com/bearer/bearer/pkg/classification/db"
 	"github.com/bearer/bearer/pkg/commands/process/settings"
+	"github.com/bearer/bearer/pkg/report/frameworks/iac"
 	globaltypes "github.com/bearer/bearer/pkg/types"
+	"github.com/bearer/bearer/pkg/util/codeowners"
 	"github.com/bearer/bearer/pkg/util/output"
 	"github.com/bearer/bearer/pkg/util/progressbar"
 	"github.com/bearer/bearer/pkg/util/rego"
@@ -100,6 +102,55 @@ func BuildCsvString(reportData *outputtypes.ReportData, config settings.Config)
 		csvStr.WriteString(strings.Join(thirdPartyArr, ",") + "\n")
 	}
 
+	if len(reportData.PrivacyReport.DataStores) != 0 {
+		csvStr.WriteString("\n")
+		csvStr.WriteString("Data Store,Resource Type,Technology,Encrypted,Public,At Risk,Location\n")
+
+		for _, dataStore := range reportData.PrivacyReport.DataStores {
+			dataStoreArr := []string{
+				dataStore.Name,
+				dataStore.ResourceType,
+				dataStore.Technology,
+				types.FormatSetting(dataStore.Encrypted),
+				types.FormatSetting(dataStore.Public),
+				fmt.Sprint(dataStore.AtRisk),
+				fmt.Sprintf("%s:%d", dataStore.Filename, dataStore.LineNumber),
+			}
+			csvStr.WriteString(strings.Join(dataStoreArr, ",") + "\n")
+		}
+	}
+
+	if len(reportData.PrivacyReport.APISurface) != 0 {
+		csvStr.WriteString("\n")
+		csvStr.WriteString("HTTP Method,Path,Direction,Data Type,Category,Location\n")
+
+		for _, endpoint := range reportData.PrivacyReport.APISurface {
+			endpointArr := []string{
+				endpoint.HttpMethod,
+				endpoint.Path,
+				endpoint.Direction,
+				endpoint.DataType,
+				endpoint.CategoryName,
+				fmt.Sprintf("%s:%d", endpoint.Filename, endpoint.LineNumber),
+			}
+			csvStr.WriteString(strings.Join(endpointArr, ",") + "\n")
+		}
+	}
+
+	if len(reportData.PrivacyReport.Owners) != 0 {
+		csvStr.WriteString("\n")
+		csvStr.WriteString("Owner,Data Types,Detection Count\n")
+
+		for _, owner := range reportData.PrivacyReport.Owners {
+			ownerArr := []string{
+				owner.Owner,
+				"\"" + strings.Join(owner.DataTypes, ",") + "\"",
+				fmt.Sprint(owner.DetectionCount),
+			}
+			csvStr.WriteString(strings.Join(ownerArr, ",") + "\n")
+		}
+	}
+
 	return csvStr, nil
 }
 
@@ -349,13 +400,103 @@ func AddReportData(reportData *outputtypes.ReportData, config settings.Config) e
 	subjects := slices.Collect(maps.Values(subjectInventory))
 	sortInventory(subjects, thirdPartyInventory)
 
+	owners, err := buildOwners(reportData, config)
+	if err != nil {
+		return err
+	}
+
 	reportData.PrivacyReport = &types.Report{
 		Subjects:   subjects,
 		ThirdParty: thirdPartyInventory,
+		DataStores: buildDataStores(reportData, config),
+		APISurface: buildAPISurface(reportData),
+		Owners:     owners,
 	}
 	return nil
 }
 
+// buildOwners summarizes the data types detected in the files of each owner
+// from the CODEOWNERS file
+func buildOwners(reportData *outputtypes.ReportData, config settings.Config) ([]types.Owner, error) {
+	codeOwners, err := codeowners.LoadOptional(config.Scan.Target, len(config.Report.Owner) != 0)
+	if err != nil || codeOwners == nil {
+		return nil, err
+	}
+
+	ownerDataTypes := make(map[string]map[string]bool)
+	ownerDetectionCounts := make(map[string]int)
+	for _, dataType := range reportData.Dataflow.Datatypes {
+		for _, detector := range dataType.Detectors {
+			for _, location := range detector.Locations {
+				for _, owner := range codeOwners.Owners(location.FullFilename) {
+					if len(config.Report.Owner) != 0 && !codeowners.HasOwner([]string{owner}, config.Report.Owner) {
+						continue
+					}
+
+					if ownerDataTypes[owner] == nil {
+						ownerDataTypes[owner] = make(map[string]bool)
+					}
+					ownerDataTypes[owner][dataType.Name] = true
+					ownerDetectionCounts[owner]++
+				}
+			}
+		}
+	}
+
+	var owners []types.Owner
+	for _, owner := range slices.Sorted(maps.Keys(ownerDataTypes)) {
+		owners = append(owners, types.Owner{
+			Owner:          owner,
+			DataTypes:      slices.Sorted(maps.Keys(ownerDataTypes[owner])),
+			DetectionCount: ownerDetectionCounts[owner],
+		})
+	}
+
+	return owners, nil
+}
+
+func buildDataStores(reportData *outputtypes.ReportData, config settings.Config) []types.DataStore {
+	_, unencryptedRuleEnabled := config.BuiltInRules[iac.UnencryptedDataStoreRuleID]
+	_, publicRuleEnabled := config.BuiltInRules[iac.PublicDataStoreRuleID]
+
+	var dataStores []types.DataStore
+	for _, dataStore := range reportData.Dataflow.DataStores {
+		settings := iac.DataStore{Encrypted: dataStore.Encrypted, Public: dataStore.Public}
+
+		dataStores = append(dataStores, types.DataStore{
+			Name:         dataStore.Name,
+			ResourceType: dataStore.ResourceType,
+			Technology:   dataStore.Technology,
+			Encrypted:    dataStore.Encrypted,
+			Public:       dataStore.Public,
+			AtRisk: (unencryptedRuleEnabled && settings.IsUnencrypted()) ||
+				(publicRuleEnabled && settings.IsPublic()),
+			Filename:   dataStore.Filename,
+			LineNumber: dataStore.LineNumber,
+		})
+	}
+
+	return dataStores
+}
+
+func buildAPISurface(reportData *outputtypes.ReportData) []types.Endpoint {
+	var apiSurface []types.Endpoint
+	for _, endpoint := range reportData.Dataflow.Endpoints {
+		apiSurface = append(apiSurface, types.Endpoint{
+			HttpMethod:     endpoint.HttpMethod,
+			Path:           endpoint.Path,
+			Direction:      endpoint.Direction,
+			DataType:       endpoint.DataType,
+			CategoryName:   endpoint.CategoryName,
+			CategoryGroups: endpoint.CategoryGroups,
+			Filename:       endpoint.Filename,
+			LineNumber:     endpoint.LineNumber,
+		})
+	}
+
+	return apiSurface
+}
+
 func sortInventory(subjectInventory []types.Subject, thirdPartyInventory []types.ThirdParty) {
 	// sort subject
 	sort.Slice(subjectInventory, func(i, j int) bool {
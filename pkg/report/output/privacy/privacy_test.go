@@ -63,6 +63,92 @@ func TestAddReportData(t *testing.T) {
 	cupaloy.SnapshotT(t, output.PrivacyReport)
 }
 
+func TestAddReportDataWithDataStores(t *testing.T) {
+	engine := engineimpl.New(languages.Default())
+	config, err := generateConfig(engine, flagtypes.ReportOptions{Report: "privacy"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	encrypted := true
+	unencrypted := false
+	dataflow := dummyDataflow()
+	dataflow.DataStores = []types.DataStore{
+		{
+			Name:         "users",
+			ResourceType: "aws_db_instance",
+			Kind:         "database",
+			Engine:       "postgres",
+			Technology:   "PostgreSQL",
+			Encrypted:    &unencrypted,
+			Filename:     "infra/main.tf",
+			LineNumber:   5,
+		},
+		{
+			Name:         "uploads",
+			ResourceType: "aws_s3_bucket",
+			Kind:         "object_storage",
+			Technology:   "AWS S3",
+			Encrypted:    &encrypted,
+			Filename:     "infra/main.tf",
+			LineNumber:   18,
+		},
+	}
+
+	output := &outputtypes.ReportData{
+		Dataflow: dataflow,
+	}
+	if err = privacy.AddReportData(output, config); err != nil {
+		t.Fatalf("failed to generate privacy output err:%s", err)
+	}
+
+	cupaloy.SnapshotT(t, output.PrivacyReport.DataStores)
+}
+
+func TestBuildCsvStringWithAPISurface(t *testing.T) {
+	engine := engineimpl.New(languages.Default())
+	config, err := generateConfig(engine, flagtypes.ReportOptions{Report: "privacy"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	dataflow := dummyDataflow()
+	dataflow.Endpoints = []types.Endpoint{
+		{
+			HttpMethod:     "GET",
+			Path:           "/users/{id}",
+			Direction:      "response",
+			DataType:       "Email Address",
+			CategoryName:   "Contact",
+			CategoryGroups: []string{"PII", "Personal Data"},
+			FieldName:      "email",
+			Filename:       "openapi.yaml",
+			LineNumber:     42,
+		},
+		{
+			HttpMethod:     "POST",
+			Path:           "/users",
+			Direction:      "request",
+			DataType:       "Fullname",
+			CategoryName:   "Identification",
+			CategoryGroups: []string{"PII", "Personal Data"},
+			FieldName:      "name",
+			Filename:       "openapi.yaml",
+			LineNumber:     37,
+		},
+	}
+
+	output := &outputtypes.ReportData{
+		Dataflow: dataflow,
+	}
+	if err = privacy.AddReportData(output, config); err != nil {
+		t.Fatalf("failed to generate privacy output err:%s", err)
+	}
+
+	stringBuilder, _ := privacy.BuildCsvString(output, config)
+	cupaloy.SnapshotT(t, stringBuilder.String())
+}
+
 func generateConfig(engine engine.Engine, reportOptions flagtypes.ReportOptions) (settings.Config, error) {
 	opts := flagtypes.Options{
 		ScanOptions: flagtypes.ScanOptions{
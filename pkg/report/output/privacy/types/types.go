@@ -3,6 +3,43 @@ package types
 type Report struct {
 	Subjects   []Subject    `json:"subjects,omitempty" yaml:"subjects"`
 	ThirdParty []ThirdParty `json:"third_party,omitempty" yaml:"third_party"`
+	DataStores []DataStore  `json:"data_stores,omitempty" yaml:"data_stores,omitempty"`
+	APISurface []Endpoint   `json:"api_surface,omitempty" yaml:"api_surface,omitempty"`
+	Owners     []Owner      `json:"owners,omitempty" yaml:"owners,omitempty"`
+}
+
+// Owner is the data processed in the files of an owner from the CODEOWNERS file
+type Owner struct {
+	Owner          string   `json:"owner" yaml:"owner"`
+	DataTypes      []string `json:"data_types" yaml:"data_types"`
+	DetectionCount int      `json:"detection_count" yaml:"detection_count"`
+}
+
+// DataStore is a data store declared in infrastructure-as-code. It is at risk
+// when the application processes sensitive data and the store isn't encrypted
+// or is publicly accessible
+type DataStore struct {
+	Name         string `json:"name" yaml:"name"`
+	ResourceType string `json:"resource_type" yaml:"resource_type"`
+	Technology   string `json:"technology,omitempty" yaml:"technology,omitempty"`
+	Encrypted    *bool  `json:"encrypted,omitempty" yaml:"encrypted,omitempty"`
+	Public       *bool  `json:"public,omitempty" yaml:"public,omitempty"`
+	AtRisk       bool   `json:"at_risk" yaml:"at_risk"`
+	Filename     string `json:"filename" yaml:"filename"`
+	LineNumber   int    `json:"line_number" yaml:"line_number"`
+}
+
+// Endpoint is a data type accepted (in the request) or returned (in the
+// response) by an endpoint of an API spec
+type Endpoint struct {
+	HttpMethod     string   `json:"http_method" yaml:"http_method"`
+	Path           string   `json:"path" yaml:"path"`
+	Direction      string   `json:"direction" yaml:"direction"`
+	DataType       string   `json:"name" yaml:"name"`
+	CategoryName   string   `json:"category_name,omitempty" yaml:"category_name,omitempty"`
+	CategoryGroups []string `json:"category_groups,omitempty" yaml:"category_groups,omitempty"`
+	Filename       string   `json:"filename" yaml:"filename"`
+	LineNumber     int      `json:"line_number" yaml:"line_number"`
 }
 
 type ThirdParty struct {
@@ -26,3 +63,16 @@ type Subject struct {
 	LowRiskFindingCount      int    `json:"low_risk_failure_count" yaml:"low_risk_failure_count"`
 	RulesPassedCount         int    `json:"rules_passed_count" yaml:"rules_passed_count"`
 }
+
+// FormatSetting formats the encryption or public access of a data store
+func FormatSetting(value *bool) string {
+	if value == nil {
+		return "Unknown"
+	}
+
+	if *value {
+		return "Yes"
+	}
+
+	return "No"
+}
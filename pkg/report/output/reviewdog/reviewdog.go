@@ -12,13 +12,18 @@ func ReportReviewdog(outputDetections map[string][]securitytypes.Finding) (revie
 		if findings, ok := outputDetections[level]; ok {
 			for _, finding := range findings {
 				var severity string
-				if level == "warning" {
+				if finding.Suppressed {
+					severity = "INFO"
+				} else if level == "warning" {
 					severity = "WARNING"
 				} else {
 					severity = "ERROR"
 				}
 
 				message := "\n# " + finding.Rule.Title + "\n" + finding.Rule.Description
+				if finding.Suppressed {
+					message += "\n\n" + finding.IgnoreSummary()
+				}
 
 				reviewdogDiagnostics = append(reviewdogDiagnostics, reviewdog.Diagnostic{
 					Message:  message,
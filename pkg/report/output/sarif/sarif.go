@@ -1,13 +1,38 @@
 package sarif
 
 import (
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+
+	"github.com/gitsight/go-vcsurl"
+
+	"github.com/bearer/bearer/pkg/commands/process/gitrepository"
 	"github.com/bearer/bearer/pkg/commands/process/settings"
 	sarif "github.com/bearer/bearer/pkg/report/output/sarif/types"
 	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
+	globaltypes "github.com/bearer/bearer/pkg/types"
+	ignoretypes "github.com/bearer/bearer/pkg/util/ignore/types"
 )
 
-func ReportSarif(outputDetections map[string][]securitytypes.Finding, rules map[string]*settings.Rule) (sarif.SarifOutput, error) {
-	var sarifRules []sarif.Rule
+// securitySeverities are the scores used by code scanning dashboards to rank
+// results, chosen in the middle of the range GitHub maps to each severity
+var securitySeverities = map[string]string{
+	globaltypes.LevelCritical: "9.5",
+	globaltypes.LevelHigh:     "8.0",
+	globaltypes.LevelMedium:   "5.5",
+	globaltypes.LevelLow:      "2.0",
+	globaltypes.LevelWarning:  "0.0",
+}
+
+func ReportSarif(
+	outputDetections map[string][]securitytypes.Finding,
+	ignoredDetections map[string][]securitytypes.IgnoredFinding,
+	rules map[string]*settings.Rule,
+	gitContext *gitrepository.Context,
+) (sarif.SarifOutput, error) {
+	sarifRules := make([]sarif.Rule, 0, len(rules))
 
 	for _, rule := range rules {
 		if !rule.PolicyType() {
@@ -23,6 +48,7 @@ func ReportSarif(outputDetections map[string][]securitytypes.Finding, rules map[
 			FullDescription: sarif.Description{
 				Text: rule.Description,
 			},
+			HelpUri: rule.DocumentationUrl,
 			Help: sarif.Help{
 				Text:     rule.RemediationMessage,
 				Markdown: rule.RemediationMessage,
@@ -30,43 +56,32 @@ func ReportSarif(outputDetections map[string][]securitytypes.Finding, rules map[
 			DefaultConfiguration: sarif.Configuration{
 				Level: "error", // rule.Severity, accepted values are ("none", "note", "warning", "error")
 			},
-			// Properties: sarif.Properties{
-			// 		Tags:      []string{"maintainability"},
-			// 		Precision: "very-high",
-			// },
+			Properties: &sarif.Properties{
+				Tags:             append([]string{"security"}, cweTags(rule.CWEIDs)...),
+				SecuritySeverity: securitySeverities[rule.Severity],
+			},
 		})
 	}
 
+	sort.Slice(sarifRules, func(i, j int) bool {
+		return sarifRules[i].Id < sarifRules[j].Id
+	})
+
 	var results []sarif.Result
 
-	for _, level := range []string{"critical", "high", "medium", "low", "warning"} {
-		if findings, ok := outputDetections[level]; ok {
-			for _, finding := range findings {
-				results = append(results, sarif.Result{
-					RuleId: finding.Rule.Id,
-					Message: sarif.Message{
-						Text: finding.Title,
-					},
-					Locations: []sarif.Location{
-						{
-							PhysicalLocation: sarif.PhysicalLocation{
-								ArtifactLocation: sarif.ArtifactLocation{
-									URI: finding.Filename,
-								},
-								Region: sarif.Region{
-									StartLine:   finding.Sink.Start,
-									EndLine:     finding.Sink.End,
-									StartColumn: finding.Sink.Column.Start,
-									EndColumn:   finding.Sink.Column.End,
-								},
-							},
-						},
-					},
-					PartialFingerprints: &sarif.PartialFingerprints{
-						PrimaryLocationLineHash: finding.Fingerprint,
-					},
-				})
-			}
+	for _, level := range globaltypes.Severities {
+		for _, finding := range outputDetections[level] {
+			results = append(results, result(finding, level))
+		}
+	}
+
+	// ignored findings are reported as suppressed, so that code scanning
+	// dashboards close their alerts with the justification from the ignore file
+	for _, level := range globaltypes.Severities {
+		for _, ignoredFinding := range ignoredDetections[level] {
+			ignoredResult := result(ignoredFinding.Finding, level)
+			ignoredResult.Suppressions = []sarif.Suppression{suppression(ignoredFinding.IgnoreMeta)}
+			results = append(results, ignoredResult)
 		}
 	}
 
@@ -81,10 +96,156 @@ func ReportSarif(outputDetections map[string][]securitytypes.Finding, rules map[
 						Rules: sarifRules,
 					},
 				},
-				Results: results,
+				Results:                  results,
+				VersionControlProvenance: versionControlProvenance(gitContext),
 			},
 		},
 	}
 
 	return output, nil
 }
+
+func result(finding securitytypes.Finding, level string) sarif.Result {
+	return sarif.Result{
+		RuleId: finding.Rule.Id,
+		Message: sarif.Message{
+			Text: finding.Title,
+		},
+		Locations: []sarif.Location{location(finding)},
+		PartialFingerprints: &sarif.PartialFingerprints{
+			BearerFingerprint: finding.Fingerprint,
+		},
+		Properties: resultProperties(finding, level),
+	}
+}
+
+// location returns the SARIF location of a finding. Findings in Jupyter
+// notebooks also carry the notebook cell, both as a logical location and in
+// the location's property bag.
+func location(finding securitytypes.Finding) sarif.Location {
+	result := sarif.Location{
+		PhysicalLocation: sarif.PhysicalLocation{
+			ArtifactLocation: sarif.ArtifactLocation{
+				URI: finding.Filename,
+			},
+			Region: sarif.Region{
+				StartLine:   finding.Sink.Start,
+				EndLine:     finding.Sink.End,
+				StartColumn: finding.Sink.Column.Start,
+				EndColumn:   finding.Sink.Column.End,
+			},
+		},
+	}
+
+	if cell := finding.NotebookCell; cell != nil {
+		result.LogicalLocations = []sarif.LogicalLocation{{
+			Name: fmt.Sprintf("cell %d", cell.Cell),
+			Kind: "notebookCell",
+		}}
+		result.Properties = &sarif.LocationProperties{
+			NotebookCell:           cell.Cell,
+			NotebookCellLineNumber: cell.LineNumber,
+		}
+	}
+
+	return result
+}
+
+// resultProperties returns the property bag of a result, holding its tags and
+// severity score, the owners of the finding and the commit which last modified
+// it when using blame
+func resultProperties(finding securitytypes.Finding, level string) *sarif.ResultProperties {
+	tags := cweTags(finding.CWEIDs)
+	for _, categoryGroup := range finding.CategoryGroups {
+		if !slices.Contains(tags, categoryGroup) {
+			tags = append(tags, categoryGroup)
+		}
+	}
+
+	properties := &sarif.ResultProperties{
+		Tags:             tags,
+		SecuritySeverity: securitySeverities[level],
+		Owners:           finding.Owners,
+	}
+	if finding.Blame != nil {
+		properties.Blame = &sarif.Blame{
+			CommitSHA: finding.Blame.SHA,
+			Author:    finding.Blame.Author,
+			Date:      finding.Blame.Date,
+		}
+	}
+
+	return properties
+}
+
+// suppression returns the SARIF suppression of an ignored finding, using the
+// comment from the ignore file as the justification
+func suppression(ignoreMeta ignoretypes.IgnoredFingerprint) sarif.Suppression {
+	result := sarif.Suppression{
+		Kind:   "external",
+		Status: "accepted",
+		Properties: &sarif.SuppressionProperties{
+			FalsePositive: ignoreMeta.FalsePositive,
+			IgnoredAt:     ignoreMeta.IgnoredAt,
+		},
+	}
+
+	if ignoreMeta.Comment != nil {
+		result.Justification = *ignoreMeta.Comment
+	}
+	if ignoreMeta.Author != nil {
+		result.Properties.Author = *ignoreMeta.Author
+	}
+	if ignoreMeta.Owner != nil {
+		result.Properties.Owner = *ignoreMeta.Owner
+	}
+
+	return result
+}
+
+// versionControlProvenance returns the repository and commit which were
+// scanned. The repository is only included when its origin can be expressed
+// as a URI, and never includes credentials
+func versionControlProvenance(gitContext *gitrepository.Context) []sarif.VersionControlDetails {
+	if gitContext == nil || gitContext.OriginURL == "" {
+		return nil
+	}
+
+	uri := repositoryUri(gitContext.OriginURL)
+	if uri == "" {
+		return nil
+	}
+
+	return []sarif.VersionControlDetails{{
+		RepositoryUri: uri,
+		RevisionId:    gitContext.CommitHash,
+		Branch:        gitContext.Branch,
+	}}
+}
+
+func repositoryUri(originURL string) string {
+	uri := originURL
+	if vcs, err := vcsurl.Parse(originURL); err == nil {
+		if remote, err := vcs.Remote(vcsurl.HTTPS); err == nil {
+			uri = remote
+		}
+	}
+
+	parsedURL, err := url.Parse(uri)
+	if err != nil || parsedURL.Scheme == "" {
+		return ""
+	}
+
+	parsedURL.User = nil
+	return parsedURL.String()
+}
+
+// cweTags returns the tags used by code scanning dashboards for CWEs
+func cweTags(cweIDs []string) []string {
+	tags := make([]string, 0, len(cweIDs))
+	for _, cweID := range cweIDs {
+		tags = append(tags, "external/cwe/cwe-"+cweID)
+	}
+
+	return tags
+}
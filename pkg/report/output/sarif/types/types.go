@@ -9,8 +9,9 @@ type Configuration struct {
 }
 
 type Properties struct {
-	Tags      []string `json:"tags"` // Could add Data Types as Tags!
-	Precision string   `json:"precision"`
+	Tags             []string `json:"tags,omitempty"`
+	Precision        string   `json:"precision,omitempty"`
+	SecuritySeverity string   `json:"security-severity,omitempty"`
 }
 
 type Help struct {
@@ -26,6 +27,7 @@ type Rule struct {
 	FullDescription      Description   `json:"fullDescription"`
 	DefaultConfiguration Configuration `json:"defaultConfiguration"`
 	Properties           *Properties   `json:"properties,omitempty"`
+	HelpUri              string        `json:"helpUri,omitempty"`
 	Help                 Help          `json:"help"`
 }
 
@@ -58,26 +60,75 @@ type Region struct {
 	EndLine     int `json:"endLine"`
 }
 
+type LogicalLocation struct {
+	Name string `json:"name"`
+	Kind string `json:"kind,omitempty"`
+}
+
+type LocationProperties struct {
+	NotebookCell           int `json:"notebookCell,omitempty"`
+	NotebookCellLineNumber int `json:"notebookCellLineNumber,omitempty"`
+}
+
 type Location struct {
-	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+	PhysicalLocation PhysicalLocation    `json:"physicalLocation"`
+	LogicalLocations []LogicalLocation   `json:"logicalLocations,omitempty"`
+	Properties       *LocationProperties `json:"properties,omitempty"`
 }
 
 type PartialFingerprints struct {
+	BearerFingerprint                     string `json:"bearerFingerprint/v1,omitempty"`
 	PrimaryLocationLineHash               string `json:"primaryLocationLineHash,omitempty"`
 	PrimaryLocationStartColumnFingerprint string `json:"primaryLocationStartColumnFingerprint,omitempty"`
 }
 
+type Blame struct {
+	CommitSHA string `json:"commitSha"`
+	Author    string `json:"author"`
+	Date      string `json:"date"`
+}
+
+type ResultProperties struct {
+	Tags             []string `json:"tags,omitempty"`
+	SecuritySeverity string   `json:"security-severity,omitempty"`
+	Blame            *Blame   `json:"blame,omitempty"`
+	Owners           []string `json:"owners,omitempty"`
+}
+
+type SuppressionProperties struct {
+	Author        string `json:"author,omitempty"`
+	Owner         string `json:"owner,omitempty"`
+	FalsePositive bool   `json:"falsePositive"`
+	IgnoredAt     string `json:"ignoredAt,omitempty"`
+}
+
+type Suppression struct {
+	Kind          string                 `json:"kind"`
+	Status        string                 `json:"status,omitempty"`
+	Justification string                 `json:"justification,omitempty"`
+	Properties    *SuppressionProperties `json:"properties,omitempty"`
+}
+
 type Result struct {
 	RuleId              string               `json:"ruleId"`
 	RuleIndex           int                  `json:"ruleIndex,omitempty"`
 	Message             Message              `json:"message"`
 	Locations           []Location           `json:"locations"`
 	PartialFingerprints *PartialFingerprints `json:"partialFingerprints,omitempty"`
+	Suppressions        []Suppression        `json:"suppressions,omitempty"`
+	Properties          *ResultProperties    `json:"properties,omitempty"`
+}
+
+type VersionControlDetails struct {
+	RepositoryUri string `json:"repositoryUri"`
+	RevisionId    string `json:"revisionId,omitempty"`
+	Branch        string `json:"branch,omitempty"`
 }
 
 type Run struct {
-	Tool    Tool     `json:"tool"`
-	Results []Result `json:"results"`
+	Tool                     Tool                    `json:"tool"`
+	Results                  []Result                `json:"results"`
+	VersionControlProvenance []VersionControlDetails `json:"versionControlProvenance,omitempty"`
 }
 
 type SarifOutput struct {
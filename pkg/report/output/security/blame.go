@@ -0,0 +1,99 @@
+package security
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bearer/bearer/pkg/commands/process/settings"
+	"github.com/bearer/bearer/pkg/git"
+	"github.com/bearer/bearer/pkg/util/output"
+
+	types "github.com/bearer/bearer/pkg/report/output/security/types"
+)
+
+// blamer finds the commit which last modified a finding, blaming each file
+// once
+type blamer struct {
+	rootDir string
+	files   map[string]*git.FileBlame
+}
+
+// addBlame sets the commit which last modified each finding
+func addBlame(findings Findings, ignoredFindings IgnoredFindings, config settings.Config) error {
+	rootDir, err := git.GetRoot(config.Scan.Target)
+	if err != nil {
+		return err
+	}
+
+	if rootDir == "" {
+		output.StdErrLog("Warning: blame is not available as the target is not in a git repository")
+		return nil
+	}
+
+	blamer := &blamer{rootDir: rootDir, files: make(map[string]*git.FileBlame)}
+
+	for _, severityFindings := range findings {
+		for i := range severityFindings {
+			severityFindings[i].Blame = blamer.commit(severityFindings[i])
+		}
+	}
+
+	for _, severityFindings := range ignoredFindings {
+		for i := range severityFindings {
+			severityFindings[i].Blame = blamer.commit(severityFindings[i].Finding)
+		}
+	}
+
+	return nil
+}
+
+func (blamer *blamer) commit(finding types.Finding) *types.Commit {
+	// findings from the git history already have the commit which added them
+	if finding.Commit != nil {
+		return nil
+	}
+
+	fileBlame, cached := blamer.files[finding.FullFilename]
+	if !cached {
+		fileBlame = blamer.blameFile(finding.FullFilename)
+		blamer.files[finding.FullFilename] = fileBlame
+	}
+
+	if fileBlame == nil {
+		return nil
+	}
+
+	startLineNumber, endLineNumber := finding.LineNumber, finding.LineNumber
+	if finding.Sink.Location != nil && finding.Sink.Start != 0 {
+		startLineNumber, endLineNumber = finding.Sink.Start, finding.Sink.End
+	}
+
+	commit := fileBlame.LastCommit(startLineNumber, endLineNumber)
+	if commit == nil {
+		return nil
+	}
+
+	return &types.Commit{
+		SHA:    commit.SHA,
+		Author: commit.AuthorName + " <" + commit.AuthorEmail + ">",
+		Date:   commit.Date.UTC().Format(time.RFC3339),
+	}
+}
+
+func (blamer *blamer) blameFile(filename string) *git.FileBlame {
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		log.Debug().Msgf("failed to resolve path of %s for blame: %s", filename, err)
+		return nil
+	}
+
+	fileBlame, err := git.Blame(blamer.rootDir, path)
+	if err != nil {
+		log.Debug().Msgf("failed to blame %s: %s", filename, err)
+		return nil
+	}
+
+	return fileBlame
+}
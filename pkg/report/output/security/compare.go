@@ -0,0 +1,282 @@
+package security
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/bearer/bearer/pkg/flag"
+	"github.com/bearer/bearer/pkg/report/output/html"
+	types "github.com/bearer/bearer/pkg/report/output/security/types"
+	globaltypes "github.com/bearer/bearer/pkg/types"
+	outputhandler "github.com/bearer/bearer/pkg/util/output"
+	"github.com/bearer/bearer/pkg/util/set"
+)
+
+// LoadFindings reads the findings of a security report in the json or jsonv2
+// format. Ignored findings, included in reports using --include-ignored, are
+// skipped
+func LoadFindings(path string) ([]types.RawFinding, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	invalidErr := fmt.Errorf("%s is not a security report in json or jsonv2 format", path)
+
+	var report map[string]json.RawMessage
+	if err := json.Unmarshal(content, &report); err != nil {
+		return nil, invalidErr
+	}
+
+	var result []types.RawFinding
+	if rawFindings, isJSONV2 := report["findings"]; isJSONV2 {
+		if err := json.Unmarshal(rawFindings, &result); err != nil {
+			return nil, invalidErr
+		}
+
+		result = slices.DeleteFunc(result, func(finding types.RawFinding) bool {
+			return finding.Finding == nil || finding.Suppressed
+		})
+	} else {
+		for severity, rawFindings := range report {
+			if !slices.Contains(globaltypes.Severities, severity) {
+				return nil, invalidErr
+			}
+
+			var findings []types.Finding
+			if err := json.Unmarshal(rawFindings, &findings); err != nil {
+				return nil, invalidErr
+			}
+
+			for i := range findings {
+				if findings[i].Suppressed {
+					continue
+				}
+
+				result = append(result, types.RawFinding{Finding: &findings[i], Severity: severity})
+			}
+		}
+	}
+
+	for _, finding := range result {
+		if finding.Rule == nil {
+			finding.Rule = &types.Rule{}
+		}
+	}
+
+	return result, nil
+}
+
+// FilterFindingsBySeverity keeps the findings with one of the given severities
+func FilterFindingsBySeverity(findings []types.RawFinding, severity set.Set[string]) []types.RawFinding {
+	var result []types.RawFinding
+	for _, finding := range findings {
+		if severity.Has(finding.Severity) {
+			result = append(result, finding)
+		}
+	}
+
+	return result
+}
+
+// CompareFindings classifies the current findings as new or unchanged, and the
+// previous findings which are no longer reported as fixed. Findings are matched
+// by fingerprint, falling back to the old fingerprint of the current findings
+// so that findings from before a fingerprint change are still matched
+func CompareFindings(previous, current []types.RawFinding) types.Comparison {
+	previousMatched := make([]bool, len(previous))
+	currentMatched := make([]bool, len(current))
+
+	match := func(fingerprintOf func(types.RawFinding) string, previousFingerprintOf func(types.RawFinding) string) {
+		previousIndexes := make(map[string][]int)
+		for i, finding := range previous {
+			if previousMatched[i] {
+				continue
+			}
+
+			if fingerprint := previousFingerprintOf(finding); fingerprint != "" {
+				previousIndexes[fingerprint] = append(previousIndexes[fingerprint], i)
+			}
+		}
+
+		for i, finding := range current {
+			if currentMatched[i] {
+				continue
+			}
+
+			fingerprint := fingerprintOf(finding)
+			if fingerprint == "" {
+				continue
+			}
+
+			indexes := previousIndexes[fingerprint]
+			if len(indexes) == 0 {
+				continue
+			}
+
+			previousMatched[indexes[0]] = true
+			currentMatched[i] = true
+			previousIndexes[fingerprint] = indexes[1:]
+		}
+	}
+
+	fingerprint := func(finding types.RawFinding) string { return finding.Fingerprint }
+	oldFingerprint := func(finding types.RawFinding) string { return finding.OldFingerprint }
+
+	match(fingerprint, fingerprint)
+	match(oldFingerprint, fingerprint)
+	match(oldFingerprint, oldFingerprint)
+
+	comparison := types.Comparison{
+		New:       []types.RawFinding{},
+		Fixed:     []types.RawFinding{},
+		Unchanged: []types.RawFinding{},
+	}
+
+	for i, finding := range current {
+		if currentMatched[i] {
+			comparison.Unchanged = append(comparison.Unchanged, finding)
+		} else {
+			comparison.New = append(comparison.New, finding)
+		}
+	}
+
+	for i, finding := range previous {
+		if !previousMatched[i] {
+			comparison.Fixed = append(comparison.Fixed, finding)
+		}
+	}
+
+	sortRawFindings(comparison.New)
+	sortRawFindings(comparison.Fixed)
+	sortRawFindings(comparison.Unchanged)
+
+	comparison.Summary = types.ComparisonSummary{
+		New:       countRawFindings(comparison.New),
+		Fixed:     countRawFindings(comparison.Fixed),
+		Unchanged: countRawFindings(comparison.Unchanged),
+	}
+
+	return comparison
+}
+
+// FormatComparison outputs a comparison in the terminal, json or html format
+func FormatComparison(comparison types.Comparison, format string, noColor bool) (string, error) {
+	switch format {
+	case flag.FormatEmpty:
+		return buildComparisonString(comparison, noColor), nil
+	case flag.FormatJSON:
+		return outputhandler.ReportJSON(comparison)
+	case flag.FormatHTML:
+		body, err := html.ReportComparisonHTML(comparison)
+		if err != nil {
+			return "", err
+		}
+
+		output, err := html.ReportHTMLWrapper("Comparison Report", body)
+		if err != nil {
+			return "", fmt.Errorf("could not generate html page %s", err)
+		}
+
+		return output, nil
+	}
+
+	return "", fmt.Errorf("format %s is not supported for comparisons", format)
+}
+
+func buildComparisonString(comparison types.Comparison, noColor bool) string {
+	initialColorSetting := color.NoColor
+	if noColor && !initialColorSetting {
+		color.NoColor = true
+	}
+	defer func() { color.NoColor = initialColorSetting }()
+
+	reportStr := &strings.Builder{}
+	reportStr.WriteString("\n\nComparison Report\n")
+	reportStr.WriteString("\n=====================================\n\n")
+
+	writeSummaryCountsToString(reportStr, "New", comparison.Summary.New.Total, comparison.Summary.New.BySeverity)
+	writeSummaryCountsToString(reportStr, "Fixed", comparison.Summary.Fixed.Total, comparison.Summary.Fixed.BySeverity)
+	writeSummaryCountsToString(
+		reportStr,
+		"Unchanged",
+		comparison.Summary.Unchanged.Total,
+		comparison.Summary.Unchanged.BySeverity,
+	)
+
+	writeComparedFindingsToString(reportStr, "New findings", comparison.New)
+	writeComparedFindingsToString(reportStr, "Fixed findings", comparison.Fixed)
+
+	return reportStr.String()
+}
+
+func writeComparedFindingsToString(reportStr *strings.Builder, title string, findings []types.RawFinding) {
+	if len(findings) == 0 {
+		return
+	}
+
+	reportStr.WriteString("\n=====================================\n\n")
+	reportStr.WriteString(title + ":\n")
+
+	for _, finding := range findings {
+		reportStr.WriteString("\n")
+		reportStr.WriteString(formatSeverity(finding.Severity))
+		reportStr.WriteString(finding.Title)
+		if len(finding.CWEIDs) != 0 {
+			var displayCWEList []string
+			for _, cweID := range finding.CWEIDs {
+				displayCWEList = append(displayCWEList, "CWE-"+cweID)
+			}
+			reportStr.WriteString(" [" + strings.Join(displayCWEList, ", ") + "]")
+		}
+		reportStr.WriteString("\n")
+		reportStr.WriteString(color.HiBlueString("File: " + underline(finding.Location(finding.Filename)) + "\n"))
+		if finding.Fingerprint != "" {
+			reportStr.WriteString(color.HiBlackString("Fingerprint: " + finding.Fingerprint + "\n"))
+		}
+	}
+}
+
+func sortRawFindings(findings []types.RawFinding) {
+	severityIndex := func(severity string) int {
+		if index := slices.Index(globaltypes.Severities, severity); index != -1 {
+			return index
+		}
+
+		return len(globaltypes.Severities)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		findingA := findings[i]
+		findingB := findings[j]
+
+		if severityA, severityB := severityIndex(findingA.Severity), severityIndex(findingB.Severity); severityA != severityB {
+			return severityA < severityB
+		}
+
+		if findingA.Filename != findingB.Filename {
+			return findingA.Filename < findingB.Filename
+		}
+
+		if findingA.LineNumber != findingB.LineNumber {
+			return findingA.LineNumber < findingB.LineNumber
+		}
+
+		return findingA.Fingerprint < findingB.Fingerprint
+	})
+}
+
+func countRawFindings(findings []types.RawFinding) types.FindingCount {
+	count := types.FindingCount{Total: len(findings), BySeverity: make(map[string]int)}
+	for _, finding := range findings {
+		count.BySeverity[finding.Severity]++
+	}
+
+	return count
+}
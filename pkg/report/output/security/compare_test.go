@@ -0,0 +1,136 @@
+package security_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bearer/bearer/pkg/flag"
+	"github.com/bearer/bearer/pkg/report/output/security"
+	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
+	globaltypes "github.com/bearer/bearer/pkg/types"
+)
+
+func comparedFinding(severity, filename string, lineNumber int, fingerprint, oldFingerprint string) securitytypes.RawFinding {
+	return securitytypes.RawFinding{
+		Finding: &securitytypes.Finding{
+			Rule:           &securitytypes.Rule{Id: "ruby_lang_logger", Title: "Leakage of information in logger message"},
+			Filename:       filename,
+			LineNumber:     lineNumber,
+			Fingerprint:    fingerprint,
+			OldFingerprint: oldFingerprint,
+		},
+		Severity: severity,
+	}
+}
+
+func TestCompareFindings(t *testing.T) {
+	previous := []securitytypes.RawFinding{
+		comparedFinding(globaltypes.LevelHigh, "app.rb", 1, "unchanged_0", "unchanged_0"),
+		comparedFinding(globaltypes.LevelMedium, "app.rb", 5, "fixed_0", "fixed_0"),
+		comparedFinding(globaltypes.LevelLow, "user.rb", 2, "before_rename_0", "before_rename_0"),
+	}
+
+	current := []securitytypes.RawFinding{
+		comparedFinding(globaltypes.LevelHigh, "app.rb", 1, "unchanged_0", "unchanged_0"),
+		comparedFinding(globaltypes.LevelLow, "user.rb", 2, "after_rename_0", "before_rename_0"),
+		comparedFinding(globaltypes.LevelCritical, "new.rb", 3, "new_0", "new_0"),
+	}
+
+	comparison := security.CompareFindings(previous, current)
+
+	fingerprints := func(findings []securitytypes.RawFinding) []string {
+		result := []string{}
+		for _, finding := range findings {
+			result = append(result, finding.Fingerprint)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"new_0"}, fingerprints(comparison.New))
+	assert.Equal(t, []string{"fixed_0"}, fingerprints(comparison.Fixed))
+	assert.Equal(t, []string{"unchanged_0", "after_rename_0"}, fingerprints(comparison.Unchanged))
+
+	assert.Equal(t, securitytypes.ComparisonSummary{
+		New:       securitytypes.FindingCount{Total: 1, BySeverity: map[string]int{globaltypes.LevelCritical: 1}},
+		Fixed:     securitytypes.FindingCount{Total: 1, BySeverity: map[string]int{globaltypes.LevelMedium: 1}},
+		Unchanged: securitytypes.FindingCount{Total: 2, BySeverity: map[string]int{globaltypes.LevelHigh: 1, globaltypes.LevelLow: 1}},
+	}, comparison.Summary)
+}
+
+func TestCompareFindings_DuplicateFingerprints(t *testing.T) {
+	previous := []securitytypes.RawFinding{
+		comparedFinding(globaltypes.LevelHigh, "app.rb", 1, "same_0", "same_0"),
+	}
+
+	current := []securitytypes.RawFinding{
+		comparedFinding(globaltypes.LevelHigh, "app.rb", 1, "same_0", "same_0"),
+		comparedFinding(globaltypes.LevelHigh, "app.rb", 2, "same_0", "same_0"),
+	}
+
+	comparison := security.CompareFindings(previous, current)
+
+	assert.Len(t, comparison.New, 1)
+	assert.Len(t, comparison.Unchanged, 1)
+	assert.Empty(t, comparison.Fixed)
+}
+
+func TestLoadFindings(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "report.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{
+		"high": [{"id": "ruby_lang_logger", "title": "Logger", "filename": "app.rb", "line_number": 1, "fingerprint": "a_0"}],
+		"low": [{"filename": "user.rb", "line_number": 2, "fingerprint": "b_0"}]
+	}`), 0600))
+
+	findings, err := security.LoadFindings(jsonPath)
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+
+	bySeverity := map[string]string{}
+	for _, finding := range findings {
+		bySeverity[finding.Severity] = finding.Fingerprint
+		assert.NotNil(t, finding.Rule)
+	}
+	assert.Equal(t, map[string]string{globaltypes.LevelHigh: "a_0", globaltypes.LevelLow: "b_0"}, bySeverity)
+
+	jsonV2Path := filepath.Join(dir, "report-v2.json")
+	require.NoError(t, os.WriteFile(jsonV2Path, []byte(`{
+		"source": "Bearer",
+		"findings": [{"id": "ruby_lang_logger", "filename": "app.rb", "fingerprint": "a_0", "severity": "medium"}]
+	}`), 0600))
+
+	findings, err = security.LoadFindings(jsonV2Path)
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "a_0", findings[0].Fingerprint)
+	assert.Equal(t, globaltypes.LevelMedium, findings[0].Severity)
+
+	invalidPath := filepath.Join(dir, "privacy.json")
+	require.NoError(t, os.WriteFile(invalidPath, []byte(`{"subjects": []}`), 0600))
+
+	_, err = security.LoadFindings(invalidPath)
+	assert.EqualError(t, err, invalidPath+" is not a security report in json or jsonv2 format")
+}
+
+func TestFormatComparison(t *testing.T) {
+	comparison := security.CompareFindings(
+		[]securitytypes.RawFinding{comparedFinding(globaltypes.LevelMedium, "app.rb", 5, "fixed_0", "fixed_0")},
+		[]securitytypes.RawFinding{comparedFinding(globaltypes.LevelHigh, "new.rb", 3, "new_0", "new_0")},
+	)
+
+	output, err := security.FormatComparison(comparison, flag.FormatEmpty, true)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "  New: 1 (1 high)\n")
+	assert.Contains(t, output, "  Fixed: 1 (1 medium)\n")
+	assert.Contains(t, output, "  Unchanged: 0\n")
+	assert.Contains(t, output, "HIGH: Leakage of information in logger message\nFile: new.rb:3\nFingerprint: new_0\n")
+
+	_, err = security.FormatComparison(comparison, flag.FormatSarif, true)
+	assert.Error(t, err)
+}
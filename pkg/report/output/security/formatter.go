@@ -2,6 +2,7 @@ package security
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/hhatto/gocloc"
@@ -10,12 +11,16 @@ import (
 	"github.com/bearer/bearer/pkg/commands/process/settings"
 	"github.com/bearer/bearer/pkg/engine"
 	"github.com/bearer/bearer/pkg/flag"
+	"github.com/bearer/bearer/pkg/report/output/codeclimate"
 	dataflowtypes "github.com/bearer/bearer/pkg/report/output/dataflow/types"
 	"github.com/bearer/bearer/pkg/report/output/gitlab"
 	"github.com/bearer/bearer/pkg/report/output/html"
+	"github.com/bearer/bearer/pkg/report/output/junit"
 	"github.com/bearer/bearer/pkg/report/output/reviewdog"
 	"github.com/bearer/bearer/pkg/report/output/sarif"
+	types "github.com/bearer/bearer/pkg/report/output/security/types"
 	outputtypes "github.com/bearer/bearer/pkg/report/output/types"
+	globaltypes "github.com/bearer/bearer/pkg/types"
 	outputhandler "github.com/bearer/bearer/pkg/util/output"
 )
 
@@ -34,6 +39,8 @@ type JsonV2Output struct {
 	Findings RawFindings           `json:"findings" yaml:"findings"`
 	Expected ExpectedDetections    `json:"expected_findings,omitempty" yaml:"expected_findings,omitempty"`
 	Errors   []dataflowtypes.Error `json:"errors" yaml:"errors"`
+	Authors  []types.AuthorSummary `json:"authors,omitempty" yaml:"authors,omitempty"`
+	Owners   []types.OwnerSummary  `json:"owners,omitempty" yaml:"owners,omitempty"`
 }
 
 func NewFormatter(
@@ -55,42 +62,83 @@ func NewFormatter(
 }
 
 func (f Formatter) Format(format string) (output string, err error) {
+	if f.Config.Report.CompareTo != "" {
+		return f.formatComparison(format)
+	}
+
+	findings := f.ReportData.FindingsBySeverity
+	var ignoredFindings IgnoredFindings
+	if f.Config.Report.IncludeIgnored {
+		findings = withIgnoredFindings(findings, f.ReportData.IgnoredFindingsBySeverity)
+		ignoredFindings = f.ReportData.IgnoredFindingsBySeverity
+	}
+
 	switch format {
 	case flag.FormatEmpty:
 		output = BuildReportString(f.ReportData, f.Config, f.engine, f.GoclocResult).String()
 	case flag.FormatSarif:
-		sarifContent, sarifErr := sarif.ReportSarif(f.ReportData.FindingsBySeverity, f.Config.Rules)
+		sarifContent, sarifErr := sarif.ReportSarif(
+			f.ReportData.FindingsBySeverity,
+			ignoredFindings,
+			f.Config.Rules,
+			f.ReportData.GitContext,
+		)
 		if sarifErr != nil {
 			return output, fmt.Errorf("error generating sarif report %s", sarifErr)
 		}
 		return outputhandler.ReportJSON(sarifContent)
 	case flag.FormatReviewDog:
-		sastContent, reviewdogErr := reviewdog.ReportReviewdog(f.ReportData.FindingsBySeverity)
+		sastContent, reviewdogErr := reviewdog.ReportReviewdog(findings)
 		if reviewdogErr != nil {
 			return output, fmt.Errorf("error generating reviewdog report %s", reviewdogErr)
 		}
 		return outputhandler.ReportJSON(sastContent)
 	case flag.FormatGitLabSast:
-		sastContent, sastErr := gitlab.ReportGitLab(f.ReportData.FindingsBySeverity, f.StartTime, f.EndTime)
+		sastContent, sastErr := gitlab.ReportGitLab(findings, f.StartTime, f.EndTime)
 		if sastErr != nil {
 			return output, fmt.Errorf("error generating gitlab-sast report %s", sastErr)
 		}
 		return outputhandler.ReportJSON(sastContent)
+	case flag.FormatCodeClimate:
+		codeClimateContent, codeClimateErr := codeclimate.ReportCodeClimate(findings)
+		if codeClimateErr != nil {
+			return output, fmt.Errorf("error generating codeclimate report %s", codeClimateErr)
+		}
+		return outputhandler.ReportJSON(codeClimateContent)
+	case flag.FormatJUnit:
+		var languages map[string]*gocloc.Language
+		if f.GoclocResult != nil {
+			languages = f.GoclocResult.Languages
+		}
+
+		junitContent, junitErr := junit.ReportJUnit(
+			enabledRules(f.engine, f.Config, f.ReportData.Dataflow, languages),
+			f.ReportData.FindingsBySeverity,
+			f.ReportData.IgnoredFindingsBySeverity,
+			f.StartTime,
+			f.EndTime,
+		)
+		if junitErr != nil {
+			return output, fmt.Errorf("error generating junit report %s", junitErr)
+		}
+		return outputhandler.ReportXML(junitContent)
 	case flag.FormatJSON:
-		return outputhandler.ReportJSON(f.ReportData.FindingsBySeverity)
+		return outputhandler.ReportJSON(findings)
 	case flag.FormatJSONV2:
 		return outputhandler.ReportJSON(JsonV2Output{
 			Source:   "Bearer",
 			Version:  build.Version,
-			Findings: f.ReportData.RawFindings,
+			Findings: f.rawFindings(),
 			Expected: f.ReportData.ExpectedDetections,
 			Errors:   f.ReportData.Dataflow.Errors,
+			Authors:  f.ReportData.AuthorSummaries,
+			Owners:   f.ReportData.OwnerSummaries,
 		})
 	case flag.FormatYAML:
-		return outputhandler.ReportYAML(f.ReportData.FindingsBySeverity)
+		return outputhandler.ReportYAML(findings)
 	case flag.FormatHTML:
 		title := "Security Report"
-		body, securityErr := html.ReportSecurityHTML(f.ReportData.FindingsBySeverity)
+		body, securityErr := html.ReportSecurityHTML(f.ReportData.FindingsBySeverity, ignoredFindings)
 		if securityErr != nil {
 			return output, securityErr
 		}
@@ -103,3 +151,34 @@ func (f Formatter) Format(format string) (output string, err error) {
 
 	return output, err
 }
+
+// rawFindings returns the findings of the jsonv2 format, followed by the
+// ignored findings when they are included in the report
+func (f Formatter) rawFindings() RawFindings {
+	if !f.Config.Report.IncludeIgnored {
+		return f.ReportData.RawFindings
+	}
+
+	result := slices.Clone(f.ReportData.RawFindings)
+	for _, severity := range globaltypes.Severities {
+		for _, ignoredFinding := range f.ReportData.IgnoredFindingsBySeverity[severity] {
+			result = append(result, ignoredFinding.Suppressed().ToRawFinding(severity))
+		}
+	}
+
+	return result
+}
+
+func (f Formatter) formatComparison(format string) (string, error) {
+	previousFindings, err := LoadFindings(f.Config.Report.CompareTo)
+	if err != nil {
+		return "", fmt.Errorf("error reading report to compare to: %w", err)
+	}
+
+	comparison := CompareFindings(
+		FilterFindingsBySeverity(previousFindings, f.Config.Report.Severity),
+		f.ReportData.RawFindings,
+	)
+
+	return FormatComparison(comparison, format, f.Config.NoColor)
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"os"
+	"path/filepath"
 	"slices"
 	"sort"
 	"strings"
@@ -17,18 +19,22 @@ import (
 	"github.com/bearer/bearer/pkg/classification/db"
 	"github.com/bearer/bearer/pkg/commands/process/settings"
 	"github.com/bearer/bearer/pkg/engine"
+	"github.com/bearer/bearer/pkg/flag"
 	"github.com/bearer/bearer/pkg/report/basebranchfindings"
+	"github.com/bearer/bearer/pkg/report/schema"
+	"github.com/bearer/bearer/pkg/report/secret"
 	"github.com/bearer/bearer/pkg/scanner/language"
 	globaltypes "github.com/bearer/bearer/pkg/types"
+	"github.com/bearer/bearer/pkg/util/codeowners"
 	"github.com/bearer/bearer/pkg/util/file"
 	ignoretypes "github.com/bearer/bearer/pkg/util/ignore/types"
 	"github.com/bearer/bearer/pkg/util/maputil"
+	"github.com/bearer/bearer/pkg/util/notebook"
 	"github.com/bearer/bearer/pkg/util/output"
 	bearerprogressbar "github.com/bearer/bearer/pkg/util/progressbar"
 	"github.com/bearer/bearer/pkg/util/rego"
 	"github.com/bearer/bearer/pkg/util/set"
 
-	dataflowtypes "github.com/bearer/bearer/pkg/report/output/dataflow/types"
 	types "github.com/bearer/bearer/pkg/report/output/security/types"
 	stats "github.com/bearer/bearer/pkg/report/output/stats"
 	outputtypes "github.com/bearer/bearer/pkg/report/output/types"
@@ -71,6 +77,12 @@ type Output struct {
 	DataType        *types.DataType `json:"data_type,omitempty" yaml:"data_type,omitempty"`
 	Severity        string          `json:"severity,omitempty" yaml:"severity,omitempty"`
 	DetailedContext string          `json:"detailed_context,omitempty" yaml:"detailed_context,omitempty"`
+	SecretRuleID    string          `json:"secret_rule_id,omitempty" yaml:"secret_rule_id,omitempty"`
+	SecretValidity  string          `json:"secret_validity,omitempty" yaml:"secret_validity,omitempty"`
+	Commit          *types.Commit   `json:"commit,omitempty" yaml:"commit,omitempty"`
+	// Dataflow holds the locations the data flows through from the source to
+	// the sink
+	Dataflow []schema.DataflowLocation `json:"dataflow,omitempty" yaml:"dataflow,omitempty"`
 }
 
 func AddReportData(
@@ -88,20 +100,37 @@ func AddReportData(
 		return nil
 	}
 
+	codeOwners, err := codeowners.LoadOptional(config.Scan.Target, len(config.Report.Owner) != 0)
+	if err != nil {
+		return err
+	}
+
 	dataflow := reportData.Dataflow
 	if !config.Scan.Quiet {
 		output.StdErrLog("Evaluating rules")
 	}
 
-	builtInFingerprints, builtInFailed, err := evaluateRules(summaryFindings, ignoredSummaryFindings, config.BuiltInRules, config, dataflow, baseBranchFindings, true)
+	builtInFingerprints, builtInFailed, err := evaluateRules(summaryFindings, ignoredSummaryFindings, config.BuiltInRules, config, dataflow, baseBranchFindings, codeOwners, true)
 	if err != nil {
 		return err
 	}
-	fingerprints, failed, err := evaluateRules(summaryFindings, ignoredSummaryFindings, config.Rules, config, dataflow, baseBranchFindings, false)
+	fingerprints, failed, err := evaluateRules(summaryFindings, ignoredSummaryFindings, config.Rules, config, dataflow, baseBranchFindings, codeOwners, false)
 	if err != nil {
 		return err
 	}
 
+	if codeOwners != nil {
+		reportData.OwnerSummaries = ownerSummaries(summaryFindings)
+	}
+
+	if config.Report.Blame {
+		if err := addBlame(summaryFindings, ignoredSummaryFindings, config); err != nil {
+			return fmt.Errorf("failed to blame findings: %w", err)
+		}
+
+		reportData.AuthorSummaries = authorSummaries(summaryFindings)
+	}
+
 	for severity, findingsSlice := range summaryFindings {
 		for _, finding := range findingsSlice {
 			reportData.RawFindings = append(reportData.RawFindings, finding.ToRawFinding(severity))
@@ -146,6 +175,7 @@ func evaluateRules(
 	config settings.Config,
 	dataflow *outputtypes.DataFlow,
 	baseBranchFindings *basebranchfindings.Findings,
+	codeOwners *codeowners.CodeOwners,
 	builtIn bool,
 ) ([]string, bool, error) {
 	outputFindings := map[string][]types.Finding{}
@@ -158,6 +188,7 @@ func evaluateRules(
 
 	var fingerprints []string
 	failed := false
+	notebooks := make(map[string]*notebook.Notebook)
 
 	for _, rule := range maputil.ToSortedSlice(rules) {
 		if !builtIn {
@@ -219,23 +250,46 @@ func evaluateRules(
 			sortByLineNumber(policyFailures)
 
 			for i, output := range policyFailures {
-				instanceID := instanceCount[output.Filename]
-				instanceCount[output.Filename]++
+				// findings from the git history are counted per commit, to keep
+				// the fingerprints of the other findings of the file stable
+				instanceKey := output.Filename
+				if output.Commit != nil {
+					instanceKey = output.Filename + "_" + output.Commit.SHA
+				}
+				instanceID := instanceCount[instanceKey]
+				instanceCount[instanceKey]++
 
 				if baseBranchFindings != nil &&
 					baseBranchFindings.Consume(rule.Id, output.Filename, output.Sink.Start, output.Sink.End) {
 					continue
 				}
 
-				fingerprintId := fmt.Sprintf("%s_%s", rule.Id, output.Filename)
+				if baseBranchFindings != nil && !inDiffScope(baseBranchFindings, config.Scan.DiffScope, output) {
+					continue
+				}
+
+				fingerprintId := fmt.Sprintf("%s_%s", rule.Id, instanceKey)
 				oldFingerprintId := fmt.Sprintf("%s_%s", rule.Id, output.FullFilename)
 				fingerprint := fmt.Sprintf("%x_%d", md5.Sum([]byte(fingerprintId)), instanceID)
 				oldFingerprint := fmt.Sprintf("%x_%d", md5.Sum([]byte(oldFingerprintId)), i)
 				fingerprints = append(fingerprints, fingerprint)
 
+				owners := codeOwners.Owners(output.FullFilename)
+				if len(config.Report.Owner) != 0 && !codeowners.HasOwner(owners, config.Report.Owner) {
+					continue
+				}
+
+				// the scanned files may be a snapshot of a commit instead of the
+				// working tree
+				sourceFilename := output.FullFilename
+				if config.SourceDir != "" {
+					sourceFilename = file.GetFullFilename(config.SourceDir, output.Filename)
+				}
+
 				rawCodeExtract := []file.Line{}
-				if !config.Report.NoExtract {
-					rawCodeExtract = codeExtract(output.FullFilename, output.Source, output.Sink)
+				// the working tree doesn't have the code of findings from the git history
+				if !config.Report.NoExtract && output.Commit == nil {
+					rawCodeExtract = codeExtract(sourceFilename, output.Source, output.Sink)
 				}
 				codeExtract := getExtract(rawCodeExtract)
 
@@ -243,6 +297,7 @@ func evaluateRules(
 					Rule:             ruleSummary,
 					FullFilename:     output.FullFilename,
 					Filename:         output.Filename,
+					NotebookCell:     notebookCell(notebooks, sourceFilename, output.LineNumber),
 					LineNumber:       output.LineNumber,
 					CategoryGroups:   output.CategoryGroups,
 					DataType:         output.DataType,
@@ -251,6 +306,10 @@ func evaluateRules(
 					ParentLineNumber: output.Sink.Start,
 					ParentContent:    output.Sink.Content,
 					DetailedContext:  output.DetailedContext,
+					SecretRuleID:     output.SecretRuleID,
+					SecretValidity:   output.SecretValidity,
+					Commit:           output.Commit,
+					Owners:           owners,
 					CodeExtract:      codeExtract,
 					RawCodeExtract:   rawCodeExtract,
 					Fingerprint:      fingerprint,
@@ -264,6 +323,7 @@ func evaluateRules(
 				}
 
 				severityMeta := CalculateSeverity(finding.CategoryGroups, rule.GetSeverity(), output.IsLocal != nil && *output.IsLocal)
+				severityMeta.DisplaySeverity = secretSeverity(severityMeta.DisplaySeverity, output.SecretValidity)
 				severity := severityMeta.DisplaySeverity
 
 				if config.Report.Severity.Has(severity) {
@@ -432,7 +492,7 @@ func BuildReportString(
 		engine,
 		config.Rules,
 		config.BuiltInRules,
-		reportData.Dataflow.Dependencies,
+		reportData.Dataflow,
 		lineOfCodeOutput.Languages,
 		config,
 	)
@@ -458,6 +518,10 @@ func BuildReportString(
 		}
 	}
 
+	if config.Report.IncludeIgnored {
+		writeIgnoredFindingsToString(reportStr, reportData.IgnoredFindingsBySeverity)
+	}
+
 	if !reportData.ReportFailed {
 		reportStr.WriteString("\nNeed to add your own custom rule? Check out the guide: https://docs.bearer.com/guides/custom-rule\n")
 	}
@@ -469,6 +533,14 @@ func BuildReportString(
 		writeStatsToString(reportData, reportStr, config, lineOfCodeOutput)
 	}
 
+	if !noFailureSummary && len(reportData.AuthorSummaries) != 0 {
+		writeAuthorSummaryToString(reportStr, reportData.AuthorSummaries)
+	}
+
+	if !noFailureSummary && len(reportData.OwnerSummaries) != 0 {
+		writeOwnerSummaryToString(reportStr, reportData.OwnerSummaries)
+	}
+
 	color.NoColor = initialColorSetting
 
 	return reportStr
@@ -488,6 +560,23 @@ func CalculateSeverity(groups []string, severity string, hasLocalDataTypes bool)
 	}
 }
 
+// secretSeverity raises the severity of the secrets which are likely to be live
+// credentials, and lowers it for the fake ones
+func secretSeverity(severity string, validity string) string {
+	if severity == globaltypes.LevelWarning {
+		return severity
+	}
+
+	switch validity {
+	case secret.ValidityLikelyLive:
+		return globaltypes.LevelCritical
+	case secret.ValidityLikelyFake:
+		return globaltypes.LevelLow
+	default:
+		return severity
+	}
+}
+
 func writeStatsToString(
 	reportData *outputtypes.ReportData,
 	reportStr *strings.Builder,
@@ -509,12 +598,12 @@ func writeRuleListToString(
 	engine engine.Engine,
 	rules map[string]*settings.Rule,
 	builtInRules map[string]*settings.Rule,
-	reportedDependencies []dataflowtypes.Dependency,
+	dataflow *outputtypes.DataFlow,
 	languages map[string]*gocloc.Language,
 	config settings.Config,
 ) int {
-	ruleCountPerLang, totalRuleCount, defaultRulesUsed := countRules(engine, rules, languages, config, false)
-	builtInRuleCountPerLang, totalBuiltInRuleCount, builtInRulesUsed := countRules(engine, builtInRules, languages, config, true)
+	ruleCountPerLang, totalRuleCount, defaultRulesUsed := countRules(engine, rules, dataflow, languages, config, false)
+	builtInRuleCountPerLang, totalBuiltInRuleCount, builtInRulesUsed := countRules(engine, builtInRules, dataflow, languages, config, true)
 
 	// combine default and built-in rules per lang
 	for lang := range builtInRuleCountPerLang {
@@ -552,7 +641,7 @@ func writeRuleListToString(
 				languageFiles.fileCount,
 			)
 		} else {
-			for _, reportedDependency := range reportedDependencies {
+			for _, reportedDependency := range dataflow.Dependencies {
 				if reportedDependency.DetectorLanguage == languageID {
 					unsupportedLanguages[languageID] = true
 					tbl.AddRow(displayName, 0, 0, languageFiles.fileCount)
@@ -611,6 +700,7 @@ func getFileCountByLanguage(engine engine.Engine, languages map[string]*gocloc.L
 func countRules(
 	engine engine.Engine,
 	rules map[string]*settings.Rule,
+	dataflow *outputtypes.DataFlow,
 	languages map[string]*gocloc.Language,
 	config settings.Config,
 	builtIn bool,
@@ -624,27 +714,13 @@ func countRules(
 
 	for key := range rules {
 		rule := rules[key]
-		if !rule.PolicyType() {
+		if !ruleEnabled(engine, rule, dataflow, languages, config) {
 			continue
 		}
 
-		var shouldCount bool
 		var languageID string
-
-		if rule.IsSecrets() {
-			shouldCount = slices.Contains(config.Scan.Scanner, "secrets")
-		} else if slices.Contains(config.Scan.Scanner, "sast") {
+		if !rule.IsSecrets() {
 			languageID = rule.Languages[0]
-			language := engine.GetLanguageById(languageID)
-			for _, name := range language.GoclocLanguages() {
-				if languages[name] != nil {
-					shouldCount = true
-				}
-			}
-		}
-
-		if !shouldCount {
-			continue
 		}
 
 		// increase total count by 1
@@ -683,6 +759,70 @@ func countRules(
 	return ruleCountPerLang, totalRuleCount, defaultRulesUsed
 }
 
+// ruleEnabled returns true when a rule applies to the scan, given the
+// scanners used and the languages found. Infrastructure-as-code and GraphQL
+// rules only apply when a data store or GraphQL schema was found
+func ruleEnabled(
+	engine engine.Engine,
+	rule *settings.Rule,
+	dataflow *outputtypes.DataFlow,
+	languages map[string]*gocloc.Language,
+	config settings.Config,
+) bool {
+	if !rule.PolicyType() {
+		return false
+	}
+
+	if rule.IsSecrets() {
+		return slices.Contains(config.Scan.Scanner, "secrets")
+	}
+
+	if !slices.Contains(config.Scan.Scanner, "sast") {
+		return false
+	}
+
+	if rule.IsIaC() {
+		return len(dataflow.DataStores) != 0
+	}
+
+	if rule.IsGraphQL() {
+		return dataflow.GraphQLDetected
+	}
+
+	language := engine.GetLanguageById(rule.Languages[0])
+	for _, name := range language.GoclocLanguages() {
+		if languages[name] != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enabledRules returns the rules (including built-in rules) counted in the
+// report, sorted by id
+func enabledRules(
+	engine engine.Engine,
+	config settings.Config,
+	dataflow *outputtypes.DataFlow,
+	languages map[string]*gocloc.Language,
+) []*settings.Rule {
+	var result []*settings.Rule
+	for _, rules := range []map[string]*settings.Rule{config.Rules, config.BuiltInRules} {
+		for _, rule := range rules {
+			if ruleEnabled(engine, rule, dataflow, languages, config) {
+				result = append(result, rule)
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+
+	return result
+}
+
 func writeSuccessToString(ruleCount int, reportStr *strings.Builder) {
 	reportStr.WriteString("\n\n")
 	reportStr.WriteString(color.HiGreenString("SUCCESS\n\n"))
@@ -739,6 +879,56 @@ func checkAndWriteFailureSummaryToString(
 	return false
 }
 
+func writeAuthorSummaryToString(reportStr *strings.Builder, summaries []types.AuthorSummary) {
+	reportStr.WriteString("\nFindings by author:\n")
+
+	for _, summary := range summaries {
+		writeSummaryCountsToString(reportStr, summary.Author, summary.Total, summary.BySeverity)
+	}
+}
+
+func writeOwnerSummaryToString(reportStr *strings.Builder, summaries []types.OwnerSummary) {
+	reportStr.WriteString("\nFindings by owner:\n")
+
+	for _, summary := range summaries {
+		writeSummaryCountsToString(reportStr, summary.Owner, summary.Total, summary.BySeverity)
+	}
+}
+
+func writeSummaryCountsToString(reportStr *strings.Builder, name string, total int, bySeverity map[string]int) {
+	var counts []string
+	for _, severityLevel := range globaltypes.Severities {
+		if count := bySeverity[severityLevel]; count != 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", count, severityLevel))
+		}
+	}
+
+	if len(counts) == 0 {
+		reportStr.WriteString(fmt.Sprintf("  %s: %d\n", name, total))
+		return
+	}
+
+	reportStr.WriteString(fmt.Sprintf("  %s: %d (%s)\n", name, total, strings.Join(counts, ", ")))
+}
+
+func writeIgnoredFindingsToString(reportStr *strings.Builder, ignoredFindings IgnoredFindings) {
+	findings := withIgnoredFindings(make(Findings), ignoredFindings)
+	if len(findings) == 0 {
+		return
+	}
+
+	reportStr.WriteString("\n\n=====================================\n\n")
+	reportStr.WriteString("Ignored findings:")
+
+	for _, severityLevel := range globaltypes.Severities {
+		for _, finding := range findings[severityLevel] {
+			writeFailureToString(reportStr, finding, severityLevel)
+		}
+	}
+
+	reportStr.WriteString("\n")
+}
+
 func writeFailureToString(reportStr *strings.Builder, finding types.Finding, severity string) {
 	reportStr.WriteString("\n\n")
 	reportStr.WriteString(formatSeverity(severity))
@@ -757,17 +947,54 @@ func writeFailureToString(reportStr *strings.Builder, finding types.Finding, sev
 		reportStr.WriteString(color.HiBlackString(finding.DocumentationUrl + "\n"))
 	}
 
-	reportStr.WriteString(color.HiBlackString("To ignore this finding, run: bearer ignore add " + finding.Fingerprint + "\n"))
+	if finding.Suppressed {
+		reportStr.WriteString(color.HiBlackString(finding.IgnoreSummary() + "\n"))
+	} else {
+		reportStr.WriteString(color.HiBlackString("To ignore this finding, run: bearer ignore add " + finding.Fingerprint + "\n"))
+	}
 	reportStr.WriteString("\n")
 	if finding.DetailedContext != "" {
-		reportStr.WriteString("Detected: " + finding.DetailedContext + "\n\n")
+		reportStr.WriteString("Detected: " + finding.DetailedContext)
+		if finding.SecretRuleID != "" {
+			reportStr.WriteString(" [" + finding.SecretRuleID + "]")
+		}
+		if finding.SecretValidity != "" {
+			reportStr.WriteString(" (" + finding.SecretValidity + ")")
+		}
+		reportStr.WriteString("\n\n")
+	}
+	reportStr.WriteString(color.HiBlueString("File: " + underline(finding.Location(finding.FullFilename)) + "\n"))
+	if finding.Commit != nil {
+		reportStr.WriteString(color.HiBlueString("Commit: " + finding.Commit.SHA + " (" + finding.Commit.Author + ", " + finding.Commit.Date + ")\n"))
+	}
+	if finding.Blame != nil {
+		reportStr.WriteString(color.HiBlueString("Last modified: " + finding.Blame.SHA + " (" + finding.Blame.Author + ", " + finding.Blame.Date + ")\n"))
+	}
+	if len(finding.Owners) != 0 {
+		reportStr.WriteString(color.HiBlueString("Owners: " + strings.Join(finding.Owners, ", ") + "\n"))
 	}
-	reportStr.WriteString(color.HiBlueString("File: " + underline(finding.FullFilename+":"+fmt.Sprint(finding.LineNumber)) + "\n"))
 
 	reportStr.WriteString("\n")
 	reportStr.WriteString(finding.HighlightCodeExtract())
 }
 
+// withIgnoredFindings returns the findings followed by the ignored findings,
+// which are marked as suppressed and carry their ignore metadata
+func withIgnoredFindings(findings Findings, ignoredFindings IgnoredFindings) Findings {
+	result := make(Findings)
+	for severity, severityFindings := range findings {
+		result[severity] = slices.Clone(severityFindings)
+	}
+
+	for severity, severityIgnoredFindings := range ignoredFindings {
+		for _, ignoredFinding := range severityIgnoredFindings {
+			result[severity] = append(result[severity], ignoredFinding.Suppressed())
+		}
+	}
+
+	return result
+}
+
 func formatSeverity(severity string) string {
 	severityColorFn, ok := severityColorFns[severity]
 	if !ok {
@@ -780,6 +1007,7 @@ type key struct {
 	LineNumber int
 	FileName   string
 	Detector   string
+	CommitSHA  string
 }
 
 // removeDuplicates removes detections for same detector with same line number by keeping only a single highest severity detection
@@ -802,6 +1030,9 @@ func removeDuplicates[F types.GenericFinding](data map[string][]F) map[string][]
 				FileName:   finding.Filename,
 				Detector:   finding.Rule.Id,
 			}
+			if finding.Commit != nil {
+				key.CommitSHA = finding.Commit.SHA
+			}
 			if reportedDetections.Add(key) {
 				filteredData[severity] = append(filteredData[severity], genericFinding)
 			}
@@ -852,6 +1083,45 @@ func sortFindings[F types.GenericFinding](data []F) {
 	})
 }
 
+// inDiffScope returns whether a new finding of a diff scan is reported for the
+// given scope
+func inDiffScope(baseBranchFindings *basebranchfindings.Findings, scope string, output Output) bool {
+	switch scope {
+	case flag.DiffScopeHunk:
+		return changedLines(baseBranchFindings, output.Filename, output.Source.Start, output.Source.End, false) ||
+			changedLines(baseBranchFindings, output.Filename, output.Sink.Start, output.Sink.End, false)
+	case flag.DiffScopeContext:
+		if changedLines(baseBranchFindings, output.Filename, output.Source.Start, output.Source.End, true) ||
+			changedLines(baseBranchFindings, output.Filename, output.Sink.Start, output.Sink.End, true) {
+			return true
+		}
+
+		for _, location := range output.Dataflow {
+			if changedLines(baseBranchFindings, output.Filename, location.StartLineNumber, location.EndLineNumber, true) {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return true
+	}
+}
+
+func changedLines(
+	baseBranchFindings *basebranchfindings.Findings,
+	filename string,
+	startLine,
+	endLine int,
+	includeRemovals bool,
+) bool {
+	if startLine == 0 {
+		return false
+	}
+
+	return baseBranchFindings.Changed(filename, startLine, endLine, includeRemovals)
+}
+
 func sortByLineNumber(outputs []Output) {
 	sort.Slice(outputs, func(i, j int) bool {
 		return outputs[i].LineNumber < outputs[j].LineNumber
@@ -875,6 +1145,29 @@ func codeExtract(filename string, Source types.Source, Sink types.Sink) []file.L
 	return code
 }
 
+// notebookCell returns the cell location of a line in a Jupyter notebook, or
+// nil for other files. Parsed notebooks are cached across findings.
+func notebookCell(notebooks map[string]*notebook.Notebook, filename string, lineNumber int) *notebook.CellLocation {
+	if filepath.Ext(filename) != ".ipynb" {
+		return nil
+	}
+
+	parsedNotebook, cached := notebooks[filename]
+	if !cached {
+		if content, err := os.ReadFile(filename); err == nil {
+			parsedNotebook, _ = notebook.Parse(content)
+		}
+
+		notebooks[filename] = parsedNotebook
+	}
+
+	if parsedNotebook == nil {
+		return nil
+	}
+
+	return parsedNotebook.CellLocation(lineNumber)
+}
+
 func getLanguageByGocloc(engine engine.Engine, goclocLanguage *gocloc.Language) language.Language {
 	for _, language := range engine.GetLanguages() {
 		if slices.Contains(language.GoclocLanguages(), goclocLanguage.Name) {
@@ -1,17 +1,25 @@
 package security_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/bradleyjkemp/cupaloy"
 	"github.com/hhatto/gocloc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/bearer/bearer/pkg/commands/process/filelist/files"
 	"github.com/bearer/bearer/pkg/commands/process/settings"
 	settingsloader "github.com/bearer/bearer/pkg/commands/process/settings/loader"
 	"github.com/bearer/bearer/pkg/engine"
 	engineimpl "github.com/bearer/bearer/pkg/engine/implementation"
+	"github.com/bearer/bearer/pkg/flag"
 	flagtypes "github.com/bearer/bearer/pkg/flag/types"
 	"github.com/bearer/bearer/pkg/git"
 	"github.com/bearer/bearer/pkg/languages"
@@ -19,11 +27,13 @@ import (
 	"github.com/bearer/bearer/pkg/report/basebranchfindings"
 	"github.com/bearer/bearer/pkg/report/schema"
 	globaltypes "github.com/bearer/bearer/pkg/types"
+	ignoretypes "github.com/bearer/bearer/pkg/util/ignore/types"
 	"github.com/bearer/bearer/pkg/util/set"
 	"github.com/bearer/bearer/pkg/version_check"
 
 	dataflowtypes "github.com/bearer/bearer/pkg/report/output/dataflow/types"
 	"github.com/bearer/bearer/pkg/report/output/security"
+	securitytypes "github.com/bearer/bearer/pkg/report/output/security/types"
 	"github.com/bearer/bearer/pkg/report/output/testhelper"
 	outputtypes "github.com/bearer/bearer/pkg/report/output/types"
 )
@@ -93,6 +103,45 @@ func TestNoRulesBuildReportString(t *testing.T) {
 	cupaloy.SnapshotT(t, stringBuilder.String())
 }
 
+func TestDataStoreRulesBuildReportString(t *testing.T) {
+	engine := engineimpl.New(languages.Default())
+	config, err := generateConfig(engine, flagtypes.ReportOptions{Report: "security"})
+	// set rule version
+	config.BearerRulesVersion = "TEST"
+	config.Rules = map[string]*settings.Rule{}
+
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	output := dummyDataflowData()
+	output.Dataflow.DataStores = []dataflowtypes.DataStore{
+		{
+			Name:         "users",
+			ResourceType: "aws_db_instance",
+			Kind:         "database",
+			Filename:     "infra/main.tf",
+			LineNumber:   1,
+		},
+	}
+	if err := security.AddReportData(output, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	dummyGoclocLanguage := gocloc.Language{}
+	dummyGoclocResult := gocloc.Result{
+		Total: &dummyGoclocLanguage,
+		Files: map[string]*gocloc.ClocFile{},
+		Languages: map[string]*gocloc.Language{
+			"HCL": {},
+		},
+		MaxPathLength: 0,
+	}
+
+	stringBuilder := security.BuildReportString(output, config, engine, &dummyGoclocResult)
+	cupaloy.SnapshotT(t, stringBuilder.String())
+}
+
 func TestAddReportData(t *testing.T) {
 	engine := engineimpl.New(languages.Default())
 	config, err := generateConfig(engine, flagtypes.ReportOptions{Report: "security"})
@@ -302,6 +351,457 @@ func TestFingerprintIsStableWithBaseBranchFindings(t *testing.T) {
 	assert.Equal(t, fullScanFinding.Fingerprint, diffFinding.Fingerprint)
 }
 
+func TestAddReportDataWithBlame(t *testing.T) {
+	engine := engineimpl.New(languages.Default())
+	config, err := generateConfig(engine, flagtypes.ReportOptions{Report: "security", Blame: true})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+	}
+
+	tempDir := t.TempDir()
+	config.Scan.Target = tempDir
+
+	filename := "config/application.rb"
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "config"), 0700))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(tempDir, filename),
+		[]byte("require \"net/http\"\nhttp.verify_mode = OpenSSL::SSL::VERIFY_NONE\n"),
+		0600,
+	))
+	runGit(t, tempDir, "init", ".")
+	runGit(t, tempDir, "add", ".")
+	runGit(t, tempDir, "commit", "--date=2030-01-02T03:04:05Z", "--message=initial")
+
+	data := &outputtypes.ReportData{
+		Dataflow: &outputtypes.DataFlow{
+			Risks: []dataflowtypes.RiskDetector{
+				{
+					DetectorID: "ruby_lang_ssl_verification",
+					Locations: []dataflowtypes.RiskLocation{
+						{
+							Filename:        filename,
+							FullFilename:    filepath.Join(tempDir, filename),
+							StartLineNumber: 2,
+							Source: &schema.Source{
+								StartLineNumber:   2,
+								StartColumnNumber: 1,
+								EndLineNumber:     2,
+								EndColumnNumber:   44,
+							},
+							PresenceMatches: []dataflowtypes.RiskPresence{
+								{
+									Name: "http.verify_mode = OpenSSL::SSL::VERIFY_NONE",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Files: []string{filename},
+	}
+
+	if err = security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	finding := data.FindingsBySeverity[globaltypes.LevelMedium][0]
+	require.NotNil(t, finding.Blame)
+	assert.Equal(t, "Bearer CI <ci@bearer.com>", finding.Blame.Author)
+	assert.Equal(t, "2030-01-02T03:04:05Z", finding.Blame.Date)
+
+	assert.Equal(t, []securitytypes.AuthorSummary{{
+		Author:     "Bearer CI <ci@bearer.com>",
+		Total:      1,
+		BySeverity: map[string]int{globaltypes.LevelMedium: 1},
+	}}, data.AuthorSummaries)
+}
+
+func TestAddReportDataWithOwner(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, ".github"), 0700))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(tempDir, ".github", "CODEOWNERS"),
+		[]byte("* @acme/backend\n/config/ @acme/security\n"),
+		0600,
+	))
+
+	risk := func(filename string) dataflowtypes.RiskDetector {
+		return dataflowtypes.RiskDetector{
+			DetectorID: "ruby_lang_ssl_verification",
+			Locations: []dataflowtypes.RiskLocation{
+				{
+					Filename:        filename,
+					FullFilename:    filepath.Join(tempDir, filename),
+					StartLineNumber: 1,
+					Source: &schema.Source{
+						StartLineNumber:   1,
+						StartColumnNumber: 1,
+						EndLineNumber:     1,
+						EndColumnNumber:   44,
+					},
+					PresenceMatches: []dataflowtypes.RiskPresence{
+						{
+							Name: "http.verify_mode = OpenSSL::SSL::VERIFY_NONE",
+						},
+					},
+				},
+			},
+		}
+	}
+
+	for _, testCase := range []struct {
+		name      string
+		owner     []string
+		filenames []string
+		summaries []securitytypes.OwnerSummary
+	}{
+		{
+			name:      "all owners",
+			filenames: []string{"app/client.rb", "config/application.rb"},
+			summaries: []securitytypes.OwnerSummary{
+				{Owner: "@acme/backend", Total: 1, BySeverity: map[string]int{globaltypes.LevelMedium: 1}},
+				{Owner: "@acme/security", Total: 1, BySeverity: map[string]int{globaltypes.LevelMedium: 1}},
+			},
+		},
+		{
+			name:      "filtered by owner",
+			owner:     []string{"@ACME/security"},
+			filenames: []string{"config/application.rb"},
+			summaries: []securitytypes.OwnerSummary{
+				{Owner: "@acme/security", Total: 1, BySeverity: map[string]int{globaltypes.LevelMedium: 1}},
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			engine := engineimpl.New(languages.Default())
+			config, err := generateConfig(engine, flagtypes.ReportOptions{Report: "security", Owner: testCase.owner})
+			if err != nil {
+				t.Fatalf("failed to generate config:%s", err)
+			}
+
+			config.Rules = map[string]*settings.Rule{
+				"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+			}
+			config.Scan.Target = tempDir
+
+			data := &outputtypes.ReportData{
+				Dataflow: &outputtypes.DataFlow{
+					Risks: []dataflowtypes.RiskDetector{risk("app/client.rb"), risk("config/application.rb")},
+				},
+				Files: []string{"app/client.rb", "config/application.rb"},
+			}
+
+			if err = security.AddReportData(data, config, nil, true); err != nil {
+				t.Fatalf("failed to generate security output err:%s", err)
+			}
+
+			var filenames []string
+			for _, finding := range data.FindingsBySeverity[globaltypes.LevelMedium] {
+				filenames = append(filenames, finding.Filename)
+			}
+
+			assert.Equal(t, testCase.filenames, filenames)
+			assert.Equal(t, testCase.summaries, data.OwnerSummaries)
+		})
+	}
+}
+
+func TestAddReportDataWithDiffScope(t *testing.T) {
+	risk := func(filename string, lineNumber int) dataflowtypes.RiskDetector {
+		return dataflowtypes.RiskDetector{
+			DetectorID: "ruby_lang_ssl_verification",
+			Locations: []dataflowtypes.RiskLocation{
+				{
+					Filename:        filename,
+					StartLineNumber: lineNumber,
+					Source: &schema.Source{
+						StartLineNumber:   lineNumber,
+						StartColumnNumber: 1,
+						EndLineNumber:     lineNumber,
+						EndColumnNumber:   44,
+					},
+					PresenceMatches: []dataflowtypes.RiskPresence{
+						{
+							Name: "http.verify_mode = OpenSSL::SSL::VERIFY_NONE",
+						},
+					},
+				},
+			},
+		}
+	}
+
+	modifiedFilename := "config/application.rb"
+	newFilename := "config/new.rb"
+
+	// line 3 is changed and the line after line 4 is removed
+	fileList := &files.List{
+		Files: []files.File{{FilePath: modifiedFilename}, {FilePath: newFilename}},
+		Chunks: map[string]git.Chunks{
+			modifiedFilename: {
+				{
+					From: git.ChunkRange{LineNumber: 3, LineCount: 1},
+					To:   git.ChunkRange{LineNumber: 3, LineCount: 1},
+				},
+				{
+					From: git.ChunkRange{LineNumber: 5, LineCount: 1},
+					To:   git.ChunkRange{LineNumber: 4, LineCount: 0},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range []struct {
+		scope     string
+		locations []string
+	}{
+		{
+			scope:     flag.DiffScopeFile,
+			locations: []string{"config/application.rb:1", "config/new.rb:1", "config/application.rb:3", "config/application.rb:5"},
+		},
+		{
+			scope:     flag.DiffScopeHunk,
+			locations: []string{"config/new.rb:1", "config/application.rb:3"},
+		},
+		{
+			scope:     flag.DiffScopeContext,
+			locations: []string{"config/new.rb:1", "config/application.rb:3"},
+		},
+	} {
+		t.Run(testCase.scope, func(t *testing.T) {
+			engine := engineimpl.New(languages.Default())
+			config, err := generateConfig(engine, flagtypes.ReportOptions{Report: "security"})
+			if err != nil {
+				t.Fatalf("failed to generate config:%s", err)
+			}
+
+			config.Rules = map[string]*settings.Rule{
+				"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+			}
+			config.Scan.DiffScope = testCase.scope
+
+			data := &outputtypes.ReportData{
+				Dataflow: &outputtypes.DataFlow{
+					Risks: []dataflowtypes.RiskDetector{
+						risk(modifiedFilename, 1),
+						risk(modifiedFilename, 3),
+						risk(modifiedFilename, 5),
+						risk(newFilename, 1),
+					},
+				},
+				Files: []string{modifiedFilename, newFilename},
+			}
+
+			if err = security.AddReportData(data, config, basebranchfindings.New(fileList), true); err != nil {
+				t.Fatalf("failed to generate security output err:%s", err)
+			}
+
+			var locations []string
+			for _, finding := range data.FindingsBySeverity[globaltypes.LevelMedium] {
+				locations = append(locations, fmt.Sprintf("%s:%d", finding.Filename, finding.LineNumber))
+			}
+
+			assert.ElementsMatch(t, testCase.locations, locations)
+		})
+	}
+}
+
+func TestAddReportDataWithContextDiffScope(t *testing.T) {
+	subject := "User"
+	risk := func(sourceLine, sinkLine int, dataflow ...schema.DataflowLocation) dataflowtypes.RiskLocation {
+		return dataflowtypes.RiskLocation{
+			Filename:        "app/logger.rb",
+			StartLineNumber: sourceLine,
+			EndLineNumber:   sourceLine,
+			Source: &schema.Source{
+				StartLineNumber:   sinkLine,
+				StartColumnNumber: 1,
+				EndLineNumber:     sinkLine,
+				EndColumnNumber:   30,
+				Content:           fmt.Sprintf("Rails.logger.info(email_%d)", sinkLine),
+				Dataflow:          dataflow,
+			},
+			DataTypes: []dataflowtypes.RiskDatatype{
+				{
+					Name:         "Email Address",
+					CategoryUUID: "cef587dd-76db-430b-9e18-7b031e1a193b",
+					Schemas:      []dataflowtypes.RiskSchema{{SubjectName: &subject}},
+				},
+			},
+		}
+	}
+
+	// only lines 2 and 6 are changed
+	fileList := &files.List{
+		Files: []files.File{{FilePath: "app/logger.rb"}},
+		Chunks: map[string]git.Chunks{
+			"app/logger.rb": {
+				{
+					From: git.ChunkRange{LineNumber: 2, LineCount: 1},
+					To:   git.ChunkRange{LineNumber: 2, LineCount: 1},
+				},
+				{
+					From: git.ChunkRange{LineNumber: 6, LineCount: 1},
+					To:   git.ChunkRange{LineNumber: 6, LineCount: 1},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range []struct {
+		scope     string
+		sinkLines []int
+	}{
+		{
+			scope:     flag.DiffScopeHunk,
+			sinkLines: []int{10},
+		},
+		{
+			scope:     flag.DiffScopeContext,
+			sinkLines: []int{10, 12},
+		},
+	} {
+		t.Run(testCase.scope, func(t *testing.T) {
+			engine := engineimpl.New(languages.Default())
+			config, err := generateConfig(engine, flagtypes.ReportOptions{Report: "security"})
+			require.NoError(t, err)
+
+			config.Rules = map[string]*settings.Rule{
+				"ruby_rails_logger": testhelper.RubyRailsLoggerRule(),
+			}
+			config.Scan.DiffScope = testCase.scope
+
+			data := &outputtypes.ReportData{
+				Dataflow: &outputtypes.DataFlow{
+					Risks: []dataflowtypes.RiskDetector{
+						{
+							DetectorID: "ruby_rails_logger",
+							Locations: []dataflowtypes.RiskLocation{
+								// only the source line is changed
+								risk(2, 10),
+								// only an intermediate line of the dataflow is changed
+								risk(4, 12, schema.DataflowLocation{StartLineNumber: 6, EndLineNumber: 6}),
+								// nothing in the dataflow is changed
+								risk(20, 22, schema.DataflowLocation{StartLineNumber: 21, EndLineNumber: 21}),
+							},
+						},
+					},
+				},
+				Files: []string{"app/logger.rb"},
+			}
+
+			require.NoError(t, security.AddReportData(data, config, basebranchfindings.New(fileList), true))
+
+			var sinkLines []int
+			for _, findings := range data.FindingsBySeverity {
+				for _, finding := range findings {
+					sinkLines = append(sinkLines, finding.Sink.Start)
+				}
+			}
+
+			assert.ElementsMatch(t, testCase.sinkLines, sinkLines)
+		})
+	}
+}
+
+func TestFormatWithIncludeIgnored(t *testing.T) {
+	engine := engineimpl.New(languages.Default())
+	config, err := generateConfig(engine, flagtypes.ReportOptions{Report: "security", IncludeIgnored: true})
+	require.NoError(t, err)
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+		"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
+	}
+
+	data := dummyDataflowData()
+	require.NoError(t, security.AddReportData(data, config, nil, true))
+	require.NotEmpty(t, data.FindingsBySeverity[globaltypes.LevelMedium])
+	ignoredFingerprint := data.FindingsBySeverity[globaltypes.LevelMedium][0].Fingerprint
+
+	author := "Mish Bear"
+	comment := "verified in staging only"
+	config.IgnoredFingerprints = map[string]ignoretypes.IgnoredFingerprint{
+		ignoredFingerprint: {
+			Author:        &author,
+			Comment:       &comment,
+			FalsePositive: true,
+			IgnoredAt:     "2024-03-18T09:12:44Z",
+		},
+	}
+
+	data = dummyDataflowData()
+	require.NoError(t, security.AddReportData(data, config, nil, true))
+	require.Empty(t, data.FindingsBySeverity[globaltypes.LevelMedium])
+	require.Len(t, data.IgnoredFindingsBySeverity[globaltypes.LevelMedium], 1)
+
+	formatter := security.NewFormatter(data, config, engine, nil, time.Time{}, time.Time{})
+
+	output, err := formatter.Format(flag.FormatJSON)
+	require.NoError(t, err)
+
+	var findings map[string][]securitytypes.Finding
+	require.NoError(t, json.Unmarshal([]byte(output), &findings))
+	require.Len(t, findings[globaltypes.LevelMedium], 1)
+
+	ignoredFinding := findings[globaltypes.LevelMedium][0]
+	assert.True(t, ignoredFinding.Suppressed)
+	assert.Equal(t, ignoredFingerprint, ignoredFinding.Fingerprint)
+	require.NotNil(t, ignoredFinding.Ignore)
+	assert.Equal(t, config.IgnoredFingerprints[ignoredFingerprint], *ignoredFinding.Ignore)
+	assert.Equal(
+		t,
+		"Ignored by Mish Bear on 2024-03-18T09:12:44Z as a false positive: verified in staging only",
+		ignoredFinding.IgnoreSummary(),
+	)
+
+	for _, finding := range findings[globaltypes.LevelCritical] {
+		assert.False(t, finding.Suppressed)
+	}
+
+	output, err = formatter.Format(flag.FormatJSONV2)
+	require.NoError(t, err)
+
+	var jsonV2 security.JsonV2Output
+	require.NoError(t, json.Unmarshal([]byte(output), &jsonV2))
+	require.Len(t, jsonV2.Findings, len(data.RawFindings)+1)
+	assert.True(t, jsonV2.Findings[len(jsonV2.Findings)-1].Suppressed)
+
+	output, err = formatter.Format(flag.FormatSarif)
+	require.NoError(t, err)
+	assert.Contains(t, output, ignoredFingerprint)
+	assert.Contains(t, output, "suppressions")
+
+	config.Report.IncludeIgnored = false
+	formatter = security.NewFormatter(data, config, engine, nil, time.Time{}, time.Time{})
+
+	output, err = formatter.Format(flag.FormatJSON)
+	require.NoError(t, err)
+	assert.NotContains(t, output, ignoredFingerprint)
+
+	output, err = formatter.Format(flag.FormatSarif)
+	require.NoError(t, err)
+	assert.NotContains(t, output, ignoredFingerprint)
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	command := exec.Command("git", append([]string{
+		"-c", "user.name=Bearer CI",
+		"-c", "user.email=ci@bearer.com",
+		"-c", "commit.gpgSign=false",
+	}, args...)...)
+	command.Dir = dir
+
+	output, err := command.CombinedOutput()
+	require.NoError(t, err, string(output))
+}
+
 func generateConfig(engine engine.Engine, reportOptions flagtypes.ReportOptions) (settings.Config, error) {
 	if reportOptions.Severity == nil {
 		reportOptions.Severity = set.New[string]()
@@ -0,0 +1,86 @@
+package security
+
+import (
+	"sort"
+
+	types "github.com/bearer/bearer/pkg/report/output/security/types"
+)
+
+// authorSummaries counts the findings by the author who last modified them
+func authorSummaries(findings Findings) []types.AuthorSummary {
+	counts := countFindings(findings, func(finding types.Finding) []string {
+		commit := finding.Blame
+		if commit == nil {
+			commit = finding.Commit
+		}
+		if commit == nil {
+			return nil
+		}
+
+		return []string{commit.Author}
+	})
+
+	var result []types.AuthorSummary
+	for _, count := range counts {
+		result = append(result, types.AuthorSummary{Author: count.key, Total: count.total, BySeverity: count.bySeverity})
+	}
+
+	return result
+}
+
+// ownerSummaries counts the findings by owner. A finding with several owners is
+// counted for each of them
+func ownerSummaries(findings Findings) []types.OwnerSummary {
+	counts := countFindings(findings, func(finding types.Finding) []string {
+		return finding.Owners
+	})
+
+	var result []types.OwnerSummary
+	for _, count := range counts {
+		result = append(result, types.OwnerSummary{Owner: count.key, Total: count.total, BySeverity: count.bySeverity})
+	}
+
+	return result
+}
+
+type findingCount struct {
+	key        string
+	total      int
+	bySeverity map[string]int
+}
+
+// countFindings counts the findings by severity for each key, ordered by
+// decreasing number of findings
+func countFindings(findings Findings, keys func(types.Finding) []string) []findingCount {
+	countsByKey := make(map[string]*findingCount)
+
+	for severity, severityFindings := range findings {
+		for _, finding := range severityFindings {
+			for _, key := range keys(finding) {
+				count := countsByKey[key]
+				if count == nil {
+					count = &findingCount{key: key, bySeverity: make(map[string]int)}
+					countsByKey[key] = count
+				}
+
+				count.total++
+				count.bySeverity[severity]++
+			}
+		}
+	}
+
+	result := make([]findingCount, 0, len(countsByKey))
+	for _, count := range countsByKey {
+		result = append(result, *count)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].total != result[j].total {
+			return result[i].total > result[j].total
+		}
+
+		return result[i].key < result[j].key
+	})
+
+	return result
+}
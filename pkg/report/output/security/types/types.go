@@ -9,6 +9,7 @@ import (
 
 	"github.com/bearer/bearer/pkg/util/file"
 	ignoretypes "github.com/bearer/bearer/pkg/util/ignore/types"
+	"github.com/bearer/bearer/pkg/util/notebook"
 )
 
 type ExpectedDetection struct {
@@ -23,21 +24,78 @@ type RawFinding struct {
 
 type Finding struct {
 	*Rule
-	LineNumber       int          `json:"line_number,omitempty" yaml:"line_number,omitempty"`
-	FullFilename     string       `json:"full_filename,omitempty" yaml:"full_filename,omitempty"`
-	Filename         string       `json:"filename,omitempty" yaml:"filename,omitempty"`
-	DataType         *DataType    `json:"data_type,omitempty" yaml:"data_type,omitempty"`
-	CategoryGroups   []string     `json:"category_groups,omitempty" yaml:"category_groups,omitempty"`
-	Source           Source       `json:"source,omitempty" yaml:"source,omitempty"`
-	Sink             Sink         `json:"sink,omitempty" yaml:"sink,omitempty"`
-	ParentLineNumber int          `json:"parent_line_number,omitempty" yaml:"parent_line_number,omitempty"`
-	ParentContent    string       `json:"snippet,omitempty" yaml:"snippet,omitempty"`
-	Fingerprint      string       `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
-	OldFingerprint   string       `json:"old_fingerprint,omitempty" yaml:"old_fingerprint,omitempty"`
-	DetailedContext  string       `json:"detailed_context,omitempty" yaml:"detailed_context,omitempty"`
-	CodeExtract      string       `json:"code_extract,omitempty" yaml:"code_extract,omitempty"`
-	RawCodeExtract   []file.Line  `json:"-" yaml:"-"`
-	SeverityMeta     SeverityMeta `json:"-" yaml:"-"`
+	LineNumber       int                    `json:"line_number,omitempty" yaml:"line_number,omitempty"`
+	FullFilename     string                 `json:"full_filename,omitempty" yaml:"full_filename,omitempty"`
+	Filename         string                 `json:"filename,omitempty" yaml:"filename,omitempty"`
+	NotebookCell     *notebook.CellLocation `json:"notebook_cell,omitempty" yaml:"notebook_cell,omitempty"`
+	DataType         *DataType              `json:"data_type,omitempty" yaml:"data_type,omitempty"`
+	CategoryGroups   []string               `json:"category_groups,omitempty" yaml:"category_groups,omitempty"`
+	Source           Source                 `json:"source,omitempty" yaml:"source,omitempty"`
+	Sink             Sink                   `json:"sink,omitempty" yaml:"sink,omitempty"`
+	ParentLineNumber int                    `json:"parent_line_number,omitempty" yaml:"parent_line_number,omitempty"`
+	ParentContent    string                 `json:"snippet,omitempty" yaml:"snippet,omitempty"`
+	Fingerprint      string                 `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+	OldFingerprint   string                 `json:"old_fingerprint,omitempty" yaml:"old_fingerprint,omitempty"`
+	DetailedContext  string                 `json:"detailed_context,omitempty" yaml:"detailed_context,omitempty"`
+	SecretRuleID     string                 `json:"secret_rule_id,omitempty" yaml:"secret_rule_id,omitempty"`
+	SecretValidity   string                 `json:"secret_validity,omitempty" yaml:"secret_validity,omitempty"`
+	Commit           *Commit                `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Blame            *Commit                `json:"blame,omitempty" yaml:"blame,omitempty"`
+	Owners           []string               `json:"owners,omitempty" yaml:"owners,omitempty"`
+	CodeExtract      string                 `json:"code_extract,omitempty" yaml:"code_extract,omitempty"`
+	RawCodeExtract   []file.Line            `json:"-" yaml:"-"`
+	SeverityMeta     SeverityMeta           `json:"-" yaml:"-"`
+
+	// Suppressed and Ignore are set on ignored findings, when they are included
+	// in the report
+	Suppressed bool                            `json:"suppressed,omitempty" yaml:"suppressed,omitempty"`
+	Ignore     *ignoretypes.IgnoredFingerprint `json:"ignore,omitempty" yaml:"ignore,omitempty"`
+}
+
+// Commit is the commit which added a secret found in the git history, or the
+// commit which last modified a finding when using blame
+type Commit struct {
+	SHA    string `json:"sha" yaml:"sha"`
+	Author string `json:"author" yaml:"author"`
+	Date   string `json:"date" yaml:"date"`
+}
+
+// AuthorSummary is the number of findings, by severity, last modified by an
+// author
+type AuthorSummary struct {
+	Author     string         `json:"author" yaml:"author"`
+	Total      int            `json:"total" yaml:"total"`
+	BySeverity map[string]int `json:"by_severity" yaml:"by_severity"`
+}
+
+// OwnerSummary is the number of findings, by severity, of an owner from the
+// CODEOWNERS file
+type OwnerSummary struct {
+	Owner      string         `json:"owner" yaml:"owner"`
+	Total      int            `json:"total" yaml:"total"`
+	BySeverity map[string]int `json:"by_severity" yaml:"by_severity"`
+}
+
+// Comparison classifies the findings of a report as new, fixed or unchanged
+// since a previous report
+type Comparison struct {
+	Summary   ComparisonSummary `json:"summary" yaml:"summary"`
+	New       []RawFinding      `json:"new" yaml:"new"`
+	Fixed     []RawFinding      `json:"fixed" yaml:"fixed"`
+	Unchanged []RawFinding      `json:"unchanged" yaml:"unchanged"`
+}
+
+// ComparisonSummary is the number of new, fixed and unchanged findings
+type ComparisonSummary struct {
+	New       FindingCount `json:"new" yaml:"new"`
+	Fixed     FindingCount `json:"fixed" yaml:"fixed"`
+	Unchanged FindingCount `json:"unchanged" yaml:"unchanged"`
+}
+
+// FindingCount is a number of findings, by severity
+type FindingCount struct {
+	Total      int            `json:"total" yaml:"total"`
+	BySeverity map[string]int `json:"by_severity" yaml:"by_severity"`
 }
 
 type IgnoredFinding struct {
@@ -75,6 +133,15 @@ func (i IgnoredFinding) GetFinding() Finding {
 	return i.Finding
 }
 
+// Suppressed returns the finding marked as suppressed, with its ignore metadata
+func (i IgnoredFinding) Suppressed() Finding {
+	finding := i.Finding
+	ignoreMeta := i.IgnoreMeta
+	finding.Suppressed = true
+	finding.Ignore = &ignoreMeta
+	return finding
+}
+
 func (i IgnoredFinding) GetIgnoreMeta() *ignoretypes.IgnoredFingerprint {
 	return &i.IgnoreMeta
 }
@@ -122,6 +189,40 @@ type SeverityMeta struct {
 	DisplaySeverity                string   `json:"display_severity" yaml:"display_severity"`
 }
 
+// Location returns the file and line of the finding, including the notebook
+// cell for findings in Jupyter notebooks
+func (f Finding) Location(filename string) string {
+	location := fmt.Sprintf("%s:%d", filename, f.LineNumber)
+	if f.NotebookCell != nil {
+		location += fmt.Sprintf(" (cell %d, line %d)", f.NotebookCell.Cell, f.NotebookCell.LineNumber)
+	}
+
+	return location
+}
+
+// IgnoreSummary describes who ignored a suppressed finding, when and why
+func (f Finding) IgnoreSummary() string {
+	if f.Ignore == nil {
+		return ""
+	}
+
+	summary := "Ignored"
+	if f.Ignore.Author != nil && *f.Ignore.Author != "" {
+		summary += " by " + *f.Ignore.Author
+	}
+	if f.Ignore.IgnoredAt != "" {
+		summary += " on " + f.Ignore.IgnoredAt
+	}
+	if f.Ignore.FalsePositive {
+		summary += " as a false positive"
+	}
+	if f.Ignore.Comment != nil && *f.Ignore.Comment != "" {
+		summary += ": " + *f.Ignore.Comment
+	}
+
+	return summary
+}
+
 func (f Finding) HighlightCodeExtract() string {
 	result := ""
 	for _, line := range f.RawCodeExtract {
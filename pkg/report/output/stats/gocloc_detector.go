@@ -1,6 +1,10 @@
 package stats
 
 import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	flagtypes "github.com/bearer/bearer/pkg/flag/types"
@@ -26,7 +30,97 @@ func GoclocDetectorOutput(path string, opts flagtypes.Options) (*gocloc.Result,
 	languages := gocloc.NewDefinedLanguages()
 	processor := gocloc.NewProcessor(languages, clocOpts)
 
-	return processor.Analyze([]string{path})
+	return processor.Analyze(goclocPaths(path, opts))
+}
+
+// UpdateGoclocResult replaces the statistics of the changed paths, which may be
+// directories or removed files, with a new analysis of those paths
+func UpdateGoclocResult(result *gocloc.Result, changedPaths []string) error {
+	var existingPaths []string
+	for _, changedPath := range changedPaths {
+		prefix := changedPath + string(filepath.Separator)
+		for path := range result.Files {
+			if path == changedPath || strings.HasPrefix(path, prefix) {
+				delete(result.Files, path)
+			}
+		}
+
+		if _, err := os.Stat(changedPath); err == nil {
+			existingPaths = append(existingPaths, changedPath)
+		}
+	}
+
+	if len(existingPaths) != 0 {
+		clocOpts := gocloc.NewClocOptions()
+		clocOpts.SkipDuplicated = true
+
+		changed, err := gocloc.NewProcessor(gocloc.NewDefinedLanguages(), clocOpts).Analyze(existingPaths)
+		if err != nil {
+			return err
+		}
+
+		for path, file := range changed.Files {
+			result.Files[path] = file
+		}
+
+		for name, language := range changed.Languages {
+			if _, exists := result.Languages[name]; !exists {
+				result.Languages[name] = language
+			}
+		}
+	}
+
+	for _, language := range result.Languages {
+		language.Files = nil
+		language.Code = 0
+		language.Comments = 0
+		language.Blanks = 0
+	}
+
+	for path, file := range result.Files {
+		language, exists := result.Languages[file.Lang]
+		if !exists {
+			continue
+		}
+
+		language.Files = append(language.Files, path)
+		language.Code += file.Code
+		language.Comments += file.Comments
+		language.Blanks += file.Blanks
+	}
+
+	total := &gocloc.Language{Name: "TOTAL"}
+	for name, language := range result.Languages {
+		if len(language.Files) == 0 {
+			delete(result.Languages, name)
+			continue
+		}
+
+		sort.Strings(language.Files)
+		total.Total += int32(len(language.Files))
+		total.Code += language.Code
+		total.Comments += language.Comments
+		total.Blanks += language.Blanks
+	}
+	result.Total = total
+
+	return nil
+}
+
+// goclocPaths restricts the analysis to the explicitly given files, if any
+func goclocPaths(path string, opts flagtypes.Options) []string {
+	if opts.FilesFrom == "" && len(opts.Files) == 0 {
+		return []string{path}
+	}
+
+	var paths []string
+	for _, filePath := range opts.Files {
+		if _, err := os.Stat(filePath); err == nil {
+			paths = append(paths, filePath)
+		}
+	}
+
+	return paths
 }
 
 func hideProgress(opts flagtypes.Options) bool {
@@ -0,0 +1,39 @@
+package stats_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hhatto/gocloc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bearer/bearer/pkg/report/output/stats"
+)
+
+func TestUpdateGoclocResult(t *testing.T) {
+	dir := t.TempDir()
+	rubyPath := filepath.Join(dir, "app.rb")
+	pythonPath := filepath.Join(dir, "lib", "main.py")
+
+	require.NoError(t, os.WriteFile(rubyPath, []byte("puts 1\n"), 0600))
+
+	result, err := gocloc.NewProcessor(gocloc.NewDefinedLanguages(), gocloc.NewClocOptions()).Analyze([]string{dir})
+	require.NoError(t, err)
+	require.Contains(t, result.Languages, "Ruby")
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(pythonPath), 0700))
+	require.NoError(t, os.WriteFile(pythonPath, []byte("print(1)\nprint(2)\n"), 0600))
+	require.NoError(t, os.Remove(rubyPath))
+
+	require.NoError(t, stats.UpdateGoclocResult(result, []string{rubyPath, filepath.Dir(pythonPath)}))
+
+	assert.NotContains(t, result.Files, rubyPath)
+	assert.NotContains(t, result.Languages, "Ruby")
+	require.Contains(t, result.Languages, "Python")
+	assert.Equal(t, []string{pythonPath}, result.Languages["Python"].Files)
+	assert.Equal(t, int32(2), result.Languages["Python"].Code)
+	assert.Equal(t, int32(1), result.Total.Total)
+	assert.Equal(t, int32(2), result.Total.Code)
+}
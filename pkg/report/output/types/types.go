@@ -1,6 +1,7 @@
 package types
 
 import (
+	"github.com/bearer/bearer/pkg/commands/process/gitrepository"
 	dataflowtypes "github.com/bearer/bearer/pkg/report/output/dataflow/types"
 	privacytypes "github.com/bearer/bearer/pkg/report/output/privacy/types"
 	saastypes "github.com/bearer/bearer/pkg/report/output/saas/types"
@@ -23,6 +24,9 @@ type ReportData struct {
 	Stats                     *statstypes.Stats
 	SaasReport                *saastypes.BearerReport
 	ExpectedDetections        []securitytypes.ExpectedDetection
+	AuthorSummaries           []securitytypes.AuthorSummary
+	OwnerSummaries            []securitytypes.OwnerSummary
+	GitContext                *gitrepository.Context
 }
 
 type DataFlow struct {
@@ -32,8 +36,12 @@ type DataFlow struct {
 	Risks              []dataflowtypes.RiskDetector `json:"risks,omitempty" yaml:"risks,omitempty"`
 	Components         []dataflowtypes.Component    `json:"components,omitempty" yaml:"components,omitempty"`
 	Dependencies       []dataflowtypes.Dependency   `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	DataStores         []dataflowtypes.DataStore    `json:"data_stores,omitempty" yaml:"data_stores,omitempty"`
+	Endpoints          []dataflowtypes.Endpoint     `json:"api_endpoints,omitempty" yaml:"api_endpoints,omitempty"`
 	Errors             []dataflowtypes.Error        `json:"errors,omitempty" yaml:"errors,omitempty"`
 	Paths              []dataflowtypes.Path         `json:"paths,omitempty" yaml:"paths,omitempty"`
+	// GraphQLDetected is true when a GraphQL schema or resolver was found
+	GraphQLDetected bool `json:"-" yaml:"-"`
 }
 
 type LanguageStats struct {
@@ -38,6 +38,14 @@ type Source struct {
 	EndLineNumber     int    `json:"end_line_number,omitempty" yaml:"end_line_number,omitempty"`
 	EndColumnNumber   int    `json:"end_column_number,omitempty" yaml:"end_column_number,omitempty"`
 	Content           string `json:"content,omitempty" yaml:"content,omitempty"`
+	// Dataflow holds the locations the data flows through between the data type
+	// and the custom detection, eg. assignments to intermediate variables
+	Dataflow []DataflowLocation `json:"dataflow,omitempty" yaml:"dataflow,omitempty"`
+}
+
+type DataflowLocation struct {
+	StartLineNumber int `json:"start_line_number" yaml:"start_line_number"`
+	EndLineNumber   int `json:"end_line_number" yaml:"end_line_number"`
 }
 
 type ReportSchema interface {
@@ -1,5 +1,25 @@
 package secret
 
+const (
+	ValidityLikelyLive = "likely-live"
+	ValidityLikelyFake = "likely-fake"
+	ValidityUnknown    = "unknown"
+)
+
 type Secret struct {
 	Description string `json:"description" yaml:"description"`
+	// RuleID is the ID of the gitleaks rule which matched the secret
+	RuleID string `json:"rule_id,omitempty" yaml:"rule_id,omitempty"`
+	// Fingerprint identifies the secret value, whichever file or commit it is in
+	Fingerprint string `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+	// Validity is whether the secret is likely to be a live credential
+	Validity string  `json:"validity,omitempty" yaml:"validity,omitempty"`
+	Commit   *Commit `json:"commit,omitempty" yaml:"commit,omitempty"`
+}
+
+// Commit is the commit which added a secret found in the git history
+type Commit struct {
+	SHA    string `json:"sha" yaml:"sha"`
+	Author string `json:"author" yaml:"author"`
+	Date   string `json:"date" yaml:"date"`
 }
@@ -159,7 +159,19 @@ func (report *Detectors) AddSecretLeak(
 	secret secret.Secret,
 	source source.Source,
 ) {
-	report.AddDetection(detections.TypeSecretleak, detectors.DetectorGitleaks, source, secret)
+	data := &detections.Detection{
+		Type:         detections.TypeSecretleak,
+		DetectorType: detectors.DetectorGitleaks,
+		Source:       source,
+		Value:        secret,
+	}
+
+	// secrets found in the git history are tied to the commit which added them
+	if secret.Commit != nil {
+		data.CommitSHA = secret.Commit.SHA
+	}
+
+	report.Add(data)
 }
 
 func (report *Detectors) AddDetection(detectionType detections.DetectionType, detectorType detectors.Type, source source.Source, value interface{}) {
@@ -5,12 +5,14 @@ import (
 	"testing"
 
 	"github.com/bradleyjkemp/cupaloy"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/bearer/bearer/pkg/commands/process/settings"
 	"github.com/bearer/bearer/pkg/languages/java"
 	"github.com/bearer/bearer/pkg/languages/ruby"
 	"github.com/bearer/bearer/pkg/scanner/ast"
 	"github.com/bearer/bearer/pkg/scanner/ast/query"
+	asttree "github.com/bearer/bearer/pkg/scanner/ast/tree"
 	"github.com/bearer/bearer/pkg/scanner/ruleset"
 )
 
@@ -189,3 +191,58 @@ func TestExpectedRulesJava(t *testing.T) {
 		tree.RootNode().Dump(),
 	)
 }
+
+func TestDataflowPath(t *testing.T) {
+	content := `email = user.email
+message = "User: #{email}"
+other = "unrelated"
+logger.info(message)
+`
+
+	language := ruby.Get()
+
+	ruleSet, err := ruleset.New(language.ID(), map[string]*settings.Rule{})
+	if err != nil {
+		t.Fatalf("failed to create rule set: %s", err)
+	}
+
+	querySet := query.NewSet(language.ID(), language.SitterLanguage())
+	if err := querySet.Compile(); err != nil {
+		t.Fatalf("failed to compile query set: %s", err)
+	}
+
+	tree, err := ast.ParseAndAnalyze(
+		context.Background(),
+		language,
+		ruleSet,
+		querySet,
+		[]byte(content),
+	)
+	if err != nil {
+		t.Fatalf("failed to parse and analyze input: %s", err)
+	}
+
+	nodesByContent := make(map[string]*asttree.Node)
+	err = tree.RootNode().Walk(func(node *asttree.Node, visitChildren func() error) error {
+		if _, exists := nodesByContent[node.Content()]; !exists {
+			nodesByContent[node.Content()] = node
+		}
+
+		return visitChildren()
+	})
+	if err != nil {
+		t.Fatalf("failed to walk tree: %s", err)
+	}
+
+	sinkNode := nodesByContent["logger.info(message)"]
+	sourceNode := nodesByContent["user.email"]
+
+	var path []string
+	for _, node := range sinkNode.DataflowPath(sourceNode) {
+		path = append(path, node.Content())
+	}
+
+	assert.Equal(t, []string{`message = "User: #{email}"`, "email = user.email"}, path)
+	assert.Nil(t, sinkNode.DataflowPath(nodesByContent[`"unrelated"`]))
+	assert.Empty(t, sourceNode.DataflowPath(sourceNode))
+}
@@ -145,6 +145,68 @@ func (node *Node) AliasOf() []*Node {
 	return node.aliasOf
 }
 
+// DataflowPath returns the nodes that data flows through from the target node
+// to this node, ordered from this node towards the target. Only the nodes
+// reached through an alias or a dataflow source are included, not the nodes
+// contained in this node or the target itself. Returns nil when the target
+// isn't reachable
+func (node *Node) DataflowPath(target *Node) []*Node {
+	nodeCount := node.tree.NodeCount()
+	previous := make([]*Node, nodeCount)
+	viaDataflow := bitset.New(uint(nodeCount))
+	seen := bitset.New(uint(nodeCount))
+	seen.Set(uint(node.ID))
+
+	found := node == target
+	queue := []*Node{node}
+	visit := func(from *Node, next []*Node, isDataflow bool) {
+		for _, nextNode := range next {
+			bit := uint(nextNode.ID)
+			if seen.Test(bit) {
+				continue
+			}
+			seen.Set(bit)
+
+			previous[nextNode.ID] = from
+			if isDataflow {
+				viaDataflow.Set(bit)
+			}
+			if nextNode == target {
+				found = true
+			}
+
+			queue = append(queue, nextNode)
+		}
+	}
+
+	for len(queue) != 0 && !found {
+		current := queue[0]
+		queue = queue[1:]
+
+		visit(current, current.Children(), false)
+		visit(current, current.AliasOf(), true)
+		visit(current, current.DataflowSources(), true)
+	}
+
+	if !found {
+		return nil
+	}
+
+	var result []*Node
+	for current := previous[target.ID]; current != nil && current != node; current = previous[current.ID] {
+		if viaDataflow.Test(uint(current.ID)) && !node.contains(current) {
+			result = append(result, current)
+		}
+	}
+
+	slices.Reverse(result)
+	return result
+}
+
+func (node *Node) contains(other *Node) bool {
+	return other.ContentStart.Byte >= node.ContentStart.Byte && other.ContentEnd.Byte <= node.ContentEnd.Byte
+}
+
 func (node *Node) ExpectedRules() []string {
 	return node.expectedRules
 }
@@ -126,7 +126,7 @@ func translateFilter(
 			Variable:          variable,
 			Rule:              rule,
 			TraversalStrategy: traversalStrategy,
-			IsDatatypeRule:    sourceFilter.Detection == "datatype",
+			IsDatatypeRule:    isDatatypeDetection(sourceFilter.Detection),
 			Filter:            ruleFilter,
 			ImportedVariables: importedVariables,
 		}, nil
@@ -230,7 +230,7 @@ func scoreFilter(filter settings.PatternFilter) int {
 		return 1
 	}
 
-	if filter.Detection == "datatype" {
+	if isDatatypeDetection(filter.Detection) {
 		return 7
 	}
 
@@ -274,3 +274,7 @@ func scoreFilter(filter settings.PatternFilter) int {
 
 	panic(fmt.Sprintf("unknown filter %#v", filter))
 }
+
+func isDatatypeDetection(detection string) bool {
+	return detection == ruleset.BuiltinDatatypeRule.ID() || detection == ruleset.BuiltinSQLDatatypeRule.ID()
+}
@@ -0,0 +1,83 @@
+package datatype
+
+import (
+	classificationschema "github.com/bearer/bearer/pkg/classification/schema"
+	"github.com/bearer/bearer/pkg/report/detectors"
+	"github.com/bearer/bearer/pkg/scanner/ast/traversalstrategy"
+	"github.com/bearer/bearer/pkg/scanner/ast/tree"
+	"github.com/bearer/bearer/pkg/scanner/detectors/common"
+	"github.com/bearer/bearer/pkg/scanner/detectors/sqlquery"
+	"github.com/bearer/bearer/pkg/scanner/detectors/types"
+	"github.com/bearer/bearer/pkg/scanner/ruleset"
+)
+
+// sqlDatatypeDetector classifies the tables and columns referenced by a SQL
+// query. It is separate from the datatype detector so that existing rules
+// don't match on query strings
+type sqlDatatypeDetector struct {
+	datatypeDetector
+}
+
+func NewSQL(detectorType detectors.Type, classifier *classificationschema.Classifier) types.Detector {
+	return &sqlDatatypeDetector{
+		datatypeDetector: datatypeDetector{
+			detectorType: detectorType,
+			classifier:   classifier,
+		},
+	}
+}
+
+func (detector *sqlDatatypeDetector) Rule() *ruleset.Rule {
+	return ruleset.BuiltinSQLDatatypeRule
+}
+
+func (detector *sqlDatatypeDetector) DetectAt(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	queryDetections, err := detectorContext.Scan(node, ruleset.BuiltinSQLQueryRule, traversalstrategy.CursorStrict)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []interface{}
+
+	for _, queryDetection := range queryDetections {
+		object := queryObject(node, queryDetection.Data.(sqlquery.Data))
+
+		data, _, containsValidClassification := detector.classifyObject(detectorContext.Filename(), "", object)
+		if containsValidClassification {
+			result = append(result, data)
+		}
+	}
+
+	return result, nil
+}
+
+// queryObject represents each table as an object, with the columns as its
+// properties
+func queryObject(node *tree.Node, data sqlquery.Data) *types.Detection {
+	tableProperties := make([]common.Property, len(data.Tables))
+
+	for i, table := range data.Tables {
+		columnProperties := make([]common.Property, len(table.Columns))
+		for j, column := range table.Columns {
+			columnProperties[j] = common.Property{Name: column}
+		}
+
+		tableProperties[i] = common.Property{
+			Name: table.Name,
+			Object: &types.Detection{
+				RuleID:    ruleset.BuiltinObjectRule.ID(),
+				MatchNode: node,
+				Data:      common.Object{Properties: columnProperties},
+			},
+		}
+	}
+
+	return &types.Detection{
+		RuleID:    ruleset.BuiltinObjectRule.ID(),
+		MatchNode: node,
+		Data:      common.Object{Properties: tableProperties},
+	}
+}
@@ -19,6 +19,26 @@ func TestInsecureUrlDetector(t *testing.T) {
 	runTest(t, "insecure_url", "insecure_url", "testdata/insecureurl.rb")
 }
 
+func TestSQLQueryDetector(t *testing.T) {
+	runTest(t, "sql_query", "sql_query", "testdata/sqlquery.rb")
+}
+
+func TestSQLQueryDetectorNonQueryStrings(t *testing.T) {
+	runTest(t, "sql_query_not_query", "sql_query", "testdata/sqlquery_not_query.rb")
+}
+
+func TestSQLDynamicWhereDetector(t *testing.T) {
+	runTest(t, "sql_dynamic_where", "sql_dynamic_where", "testdata/sqlquery.rb")
+}
+
+func TestSQLSelectAllDetector(t *testing.T) {
+	runTest(t, "sql_select_all", "sql_select_all", "testdata/sqlquery.rb")
+}
+
+func TestSQLDatatypeDetector(t *testing.T) {
+	runTest(t, "sql_datatype", "sql_datatype", "testdata/sqlquery.rb")
+}
+
 func runTest(t *testing.T, name string, detectorType, fileName string) {
 	testhelper.RunTest(t, name, ruby.Get(), detectorType, fileName)
 }
@@ -0,0 +1,390 @@
+package sqlquery
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/bearer/bearer/pkg/parser/sitter/sql"
+	"github.com/bearer/bearer/pkg/scanner/ast/query"
+	"github.com/bearer/bearer/pkg/scanner/ast/traversalstrategy"
+	"github.com/bearer/bearer/pkg/scanner/ast/tree"
+	"github.com/bearer/bearer/pkg/scanner/detectors/common"
+	"github.com/bearer/bearer/pkg/scanner/detectors/types"
+	"github.com/bearer/bearer/pkg/scanner/ruleset"
+	"github.com/bearer/bearer/pkg/util/set"
+)
+
+// dynamicPlaceholder replaces the non-literal parts of a string (eg.
+// interpolated values) so that the SQL can still be parsed
+const dynamicPlaceholder = "bearer_dynamic_value"
+
+// only strings that look like a query are parsed
+var queryPattern = regexp.MustCompile(
+	`(?is)^\s*(select\s.+\sfrom\s|insert\s+into\s|update\s.+\sset\s|delete\s+from\s|with\s.+\sas\s*\()`,
+)
+
+var language = sql.GetLanguage()
+
+// queryFunctions are the (lowercase) names of the functions and methods of
+// common database libraries which take a query as an argument. Only string
+// arguments of calls to these are parsed as SQL. Names which are commonly used
+// outside of database code (eg. `get`, `all` or `update`) are left out, as the
+// receiver of the call is not known
+var queryFunctions = newQueryFunctions(
+	// generic
+	"execute", "exec", "query", "prepare", "raw",
+	// ruby
+	"exec_query", "exec_update", "exec_delete", "exec_insert", "find_by_sql",
+	"select_all", "select_one", "select_rows", "select_value", "select_values",
+	// javascript
+	"$queryrawunsafe", "$executerawunsafe",
+	// python
+	"executemany", "executescript", "mogrify", "read_sql", "read_sql_query",
+	// go
+	"querycontext", "queryrow", "queryrowcontext", "queryx", "queryrowx",
+	"execcontext", "preparecontext", "mustexec", "namedexec", "namedquery",
+	// java
+	"executequery", "executeupdate", "executelargeupdate", "addbatch",
+	"preparestatement", "preparecall", "createquery", "createnativequery",
+	"createsqlquery", "queryforobject", "queryforlist", "queryformap",
+	"queryforrowset", "batchupdate",
+	// php
+	"mysql_query", "mysqli_query", "mysqli_real_query", "mysqli_prepare",
+	"pg_query", "pg_query_params", "pg_prepare", "pg_send_query",
+	"sqlite_query", "statement", "unprepared",
+)
+
+func newQueryFunctions(names ...string) set.Set[string] {
+	result := set.New[string]()
+	result.AddAll(names)
+	return result
+}
+
+type Data struct {
+	// Statement is the type of the query, eg. select or update
+	Statement string
+	Tables    []Table
+	// SelectAll is true when all columns are selected (eg. `SELECT *`)
+	SelectAll bool
+	// DynamicWhere is true when a non-literal value is used inside a WHERE
+	// (or similar) condition
+	DynamicWhere bool
+}
+
+type Table struct {
+	Name    string
+	Columns []string
+}
+
+type sqlQueryDetector struct {
+	types.DetectorBase
+	callQuery *query.Query
+}
+
+// New creates a detector for queries given as an argument to a database call.
+// The callQuery is a language specific query matching the arguments of a call,
+// which must capture the argument as @match and the name of the function or
+// method being called as @method
+func New(querySet *query.Set, callQuery string) types.Detector {
+	return &sqlQueryDetector{callQuery: querySet.Add(callQuery)}
+}
+
+func (detector *sqlQueryDetector) Rule() *ruleset.Rule {
+	return ruleset.BuiltinSQLQueryRule
+}
+
+func (detector *sqlQueryDetector) DetectAt(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	if !detector.isQueryArgument(node) {
+		return nil, nil
+	}
+
+	detections, err := detectorContext.Scan(node, ruleset.BuiltinStringRule, traversalstrategy.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []interface{}
+	for _, detection := range detections {
+		value := detection.Data.(common.String).Value
+		if !queryPattern.MatchString(value) {
+			continue
+		}
+
+		data, err := Parse(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if data != nil {
+			result = append(result, *data)
+		}
+	}
+
+	return result, nil
+}
+
+func (detector *sqlQueryDetector) isQueryArgument(node *tree.Node) bool {
+	for _, result := range detector.callQuery.MatchAt(node) {
+		if queryFunctions.Has(strings.ToLower(result["method"].Content())) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Parse extracts the structure of a query. Non-literal parts of the query
+// must be marked using common.NonLiteralValue. Returns nil if the value does
+// not reference any tables
+func Parse(value string) (*Data, error) {
+	input := []byte(strings.ReplaceAll(value, common.NonLiteralValue, dynamicPlaceholder))
+
+	rootNode, err := sitter.ParseCtx(context.Background(), input, language)
+	if err != nil {
+		return nil, err
+	}
+
+	// the SQL grammar often fails to parse part of a query (eg. vendor specific
+	// syntax or placeholders) so rather than relying on the shape of the tree,
+	// the tokens are interpreted in order
+	parser := &queryParser{
+		tokens:  tokens(rootNode, input),
+		aliases: make(map[string]string),
+	}
+	parser.parse()
+
+	return parser.data(), nil
+}
+
+type token struct {
+	nodeType string
+	content  string
+	keyword  string
+}
+
+func tokens(rootNode *sitter.Node, input []byte) []token {
+	var result []token
+
+	var visit func(node *sitter.Node)
+	visit = func(node *sitter.Node) {
+		childCount := int(node.ChildCount())
+		if childCount == 0 {
+			content := node.Content(input)
+			if content == "" {
+				return
+			}
+
+			keyword := ""
+			if node.Type() != "identifier" {
+				keyword = strings.ToUpper(content)
+			}
+
+			result = append(result, token{nodeType: node.Type(), content: content, keyword: keyword})
+			return
+		}
+
+		for i := 0; i < childCount; i++ {
+			visit(node.Child(i))
+		}
+	}
+
+	visit(rootNode)
+
+	return result
+}
+
+type clause int
+
+const (
+	clauseNone clause = iota
+	clauseColumns
+	clauseTables
+	clauseCondition
+)
+
+type column struct {
+	qualifier string
+	name      string
+}
+
+type queryParser struct {
+	tokens       []token
+	statement    string
+	tables       []string
+	aliases      map[string]string
+	columns      []column
+	selectAll    bool
+	dynamicWhere bool
+}
+
+func (parser *queryParser) parse() {
+	clause := clauseNone
+
+	for i, token := range parser.tokens {
+		previous := parser.previousKeyword(i)
+
+		switch token.keyword {
+		case "SELECT", "INSERT", "UPDATE", "DELETE":
+			if parser.statement == "" {
+				parser.statement = strings.ToLower(token.keyword)
+			}
+		}
+
+		switch token.keyword {
+		case "SELECT", "SET", "RETURNING":
+			clause = clauseColumns
+			continue
+		case "FROM", "JOIN", "UPDATE", "INTO":
+			clause = clauseTables
+			continue
+		case "WHERE", "ON", "HAVING":
+			clause = clauseCondition
+			continue
+		case "VALUES", "GROUP", "ORDER", "LIMIT", "OFFSET", "UNION":
+			clause = clauseNone
+			continue
+		case "(":
+			// eg. the column list of an insert
+			if clause == clauseTables {
+				clause = clauseColumns
+			}
+			continue
+		case "*":
+			if clause == clauseColumns && (previous == "SELECT" || previous == "DISTINCT" || previous == "," || previous == ".") {
+				parser.selectAll = true
+			}
+			continue
+		}
+
+		if strings.Contains(token.content, dynamicPlaceholder) {
+			if clause == clauseCondition {
+				parser.dynamicWhere = true
+			}
+			continue
+		}
+
+		if token.nodeType != "identifier" {
+			continue
+		}
+
+		name := unquote(token.content)
+
+		switch clause {
+		case clauseTables:
+			parser.addTable(name, previous)
+		case clauseColumns, clauseCondition:
+			if !parser.isFunction(i) {
+				parser.addColumn(name, previous)
+			}
+		}
+	}
+}
+
+func (parser *queryParser) addTable(name, previous string) {
+	switch previous {
+	case "FROM", "JOIN", "UPDATE", "INTO", ",":
+		parser.tables = append(parser.tables, name)
+	case ".":
+		// qualified by the schema
+		if len(parser.tables) != 0 {
+			parser.tables[len(parser.tables)-1] = name
+		}
+	default:
+		if len(parser.tables) != 0 {
+			parser.aliases[name] = parser.tables[len(parser.tables)-1]
+		}
+	}
+}
+
+func (parser *queryParser) addColumn(name, previous string) {
+	switch previous {
+	case "AS", ":", "@", "$":
+		// an alias or a named parameter
+		return
+	case ".":
+		if len(parser.columns) != 0 {
+			qualifier := parser.columns[len(parser.columns)-1].name
+			parser.columns[len(parser.columns)-1] = column{qualifier: qualifier, name: name}
+		}
+	default:
+		parser.columns = append(parser.columns, column{name: name})
+	}
+}
+
+func (parser *queryParser) previousKeyword(i int) string {
+	if i == 0 {
+		return ""
+	}
+
+	previous := parser.tokens[i-1]
+	if previous.keyword == "" {
+		return previous.nodeType
+	}
+
+	return previous.keyword
+}
+
+func (parser *queryParser) isFunction(i int) bool {
+	return i+1 < len(parser.tokens) && parser.tokens[i+1].content == "("
+}
+
+func (parser *queryParser) data() *Data {
+	if len(parser.tables) == 0 {
+		return nil
+	}
+
+	var tables []Table
+	tableIndices := make(map[string]int)
+	for _, name := range parser.tables {
+		if _, duplicate := tableIndices[name]; duplicate {
+			continue
+		}
+
+		tableIndices[name] = len(tables)
+		tables = append(tables, Table{Name: name})
+	}
+
+	seen := make(map[column]struct{})
+	for _, column := range parser.columns {
+		tableIndex := 0
+		if column.qualifier != "" {
+			tableName := column.qualifier
+			if aliasedName, isAlias := parser.aliases[tableName]; isAlias {
+				tableName = aliasedName
+			}
+
+			index, found := tableIndices[tableName]
+			if !found {
+				continue
+			}
+
+			tableIndex = index
+		}
+
+		key := column
+		key.qualifier = tables[tableIndex].Name
+		if _, duplicate := seen[key]; duplicate {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		tables[tableIndex].Columns = append(tables[tableIndex].Columns, column.name)
+	}
+
+	return &Data{
+		Statement:    parser.statement,
+		Tables:       tables,
+		SelectAll:    parser.selectAll,
+		DynamicWhere: parser.dynamicWhere,
+	}
+}
+
+func unquote(name string) string {
+	return strings.Trim(name, "\"`[]")
+}
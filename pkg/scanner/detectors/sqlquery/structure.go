@@ -0,0 +1,54 @@
+package sqlquery
+
+import (
+	"github.com/bearer/bearer/pkg/scanner/ast/traversalstrategy"
+	"github.com/bearer/bearer/pkg/scanner/ast/tree"
+	"github.com/bearer/bearer/pkg/scanner/detectors/types"
+	"github.com/bearer/bearer/pkg/scanner/ruleset"
+)
+
+// structureDetector matches queries with a particular structure
+type structureDetector struct {
+	types.DetectorBase
+	rule    *ruleset.Rule
+	matches func(data Data) bool
+}
+
+// NewDynamicWhere detects queries using a non-literal value in a condition,
+// eg. `"SELECT * FROM users WHERE name = '#{name}'"`
+func NewDynamicWhere() types.Detector {
+	return &structureDetector{
+		rule:    ruleset.BuiltinSQLDynamicWhereRule,
+		matches: func(data Data) bool { return data.DynamicWhere },
+	}
+}
+
+// NewSelectAll detects queries selecting all columns, eg. `SELECT *`
+func NewSelectAll() types.Detector {
+	return &structureDetector{
+		rule:    ruleset.BuiltinSQLSelectAllRule,
+		matches: func(data Data) bool { return data.SelectAll },
+	}
+}
+
+func (detector *structureDetector) Rule() *ruleset.Rule {
+	return detector.rule
+}
+
+func (detector *structureDetector) DetectAt(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	detections, err := detectorContext.Scan(node, ruleset.BuiltinSQLQueryRule, traversalstrategy.CursorStrict)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, detection := range detections {
+		if detector.matches(detection.Data.(Data)) {
+			return []interface{}{nil}, nil
+		}
+	}
+
+	return nil, nil
+}
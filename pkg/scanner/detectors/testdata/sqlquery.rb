@@ -0,0 +1,7 @@
+# match
+db.execute("SELECT * FROM users WHERE email = '#{email}'")
+query = "UPDATE customers SET first_name = ? WHERE id = ?"
+ActiveRecord::Base.connection.exec_query(query)
+# not match
+db.execute("Select a file")
+db.execute("SELECT 1")
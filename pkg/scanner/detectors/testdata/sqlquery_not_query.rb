@@ -0,0 +1,10 @@
+# not match
+"SELECT * FROM users WHERE email = '#{email}'"
+puts "UPDATE customers SET first_name = ? WHERE id = ?"
+message = "Select * from the list, then delete from the cart"
+logger.info("DELETE FROM sessions WHERE user_id = #{user.id}")
+cache.get("SELECT * FROM users WHERE email = '#{email}'")
+records.select("SELECT * FROM users WHERE email = '#{email}'")
+tasks.all("SELECT * FROM users WHERE email = '#{email}'")
+job.run("DELETE FROM sessions WHERE user_id = #{user.id}")
+settings.update("UPDATE customers SET first_name = ? WHERE id = ?")
@@ -87,6 +87,17 @@ func (scanner *Scanner) Scan(
 		return nil, nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	return scanner.ScanContent(ctx, fileStats, fileInfo, contentBytes)
+}
+
+// ScanContent scans the given content as source code in the scanner's
+// language, regardless of the file's detected language
+func (scanner *Scanner) ScanContent(
+	ctx context.Context,
+	fileStats *stats.FileStats,
+	fileInfo *file.FileInfo,
+	contentBytes []byte,
+) ([]*detectortypes.Detection, []*detectortypes.Detection, error) {
 	tree, err := ast.ParseAndAnalyze(ctx, scanner.language, scanner.ruleSet, scanner.querySet, contentBytes)
 	if err != nil {
 		return nil, nil, err
@@ -31,6 +31,30 @@ var (
 		ruleType: RuleTypeBuiltin,
 	}
 
+	BuiltinSQLQueryRule = &Rule{
+		index:    5,
+		id:       "sql_query",
+		ruleType: RuleTypeBuiltin,
+	}
+
+	BuiltinSQLDynamicWhereRule = &Rule{
+		index:    6,
+		id:       "sql_dynamic_where",
+		ruleType: RuleTypeBuiltin,
+	}
+
+	BuiltinSQLSelectAllRule = &Rule{
+		index:    7,
+		id:       "sql_select_all",
+		ruleType: RuleTypeBuiltin,
+	}
+
+	BuiltinSQLDatatypeRule = &Rule{
+		index:    8,
+		id:       "sql_datatype",
+		ruleType: RuleTypeBuiltin,
+	}
+
 	// index in the slice and the index number above must match
 	builtinRules = []*Rule{
 		BuiltinObjectRule,
@@ -38,5 +62,9 @@ var (
 		BuiltinDatatypeRule,
 		BuiltinInsecureURLRule,
 		BuiltinStringLiteralRule,
+		BuiltinSQLQueryRule,
+		BuiltinSQLDynamicWhereRule,
+		BuiltinSQLSelectAllRule,
+		BuiltinSQLDatatypeRule,
 	}
 )
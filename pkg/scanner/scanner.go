@@ -3,6 +3,7 @@ package scanner
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	schemaclassifier "github.com/bearer/bearer/pkg/classification/schema"
@@ -13,11 +14,14 @@ import (
 	"github.com/bearer/bearer/pkg/report/detectors"
 	reportschema "github.com/bearer/bearer/pkg/report/schema"
 	"github.com/bearer/bearer/pkg/report/source"
+	"github.com/bearer/bearer/pkg/scanner/ast/tree"
 	customruletypes "github.com/bearer/bearer/pkg/scanner/detectors/customrule/types"
 	"github.com/bearer/bearer/pkg/scanner/detectors/datatype"
 	detectortypes "github.com/bearer/bearer/pkg/scanner/detectors/types"
 	"github.com/bearer/bearer/pkg/util/file"
+	"github.com/bearer/bearer/pkg/util/notebook"
 	"github.com/bearer/bearer/pkg/util/pluralize"
+	"github.com/bearer/bearer/pkg/util/viewtemplate"
 
 	"github.com/bearer/bearer/pkg/scanner/languagescanner"
 	"github.com/bearer/bearer/pkg/scanner/stats"
@@ -58,114 +62,175 @@ func (scanner *Scanner) Scan(
 		return nil
 	}
 
+	if file.Language == notebook.EnryLanguage {
+		return scanner.scanNotebook(ctx, report, fileStats, file)
+	}
+
+	if hostLanguage := viewtemplate.HostLanguage(file.Language, file.RelativePath); hostLanguage != "" {
+		return scanner.scanTemplate(ctx, report, fileStats, file, hostLanguage)
+	}
+
 	for _, languageScanner := range scanner.languageScanners {
 		detections, expectedDetections, err := languageScanner.Scan(ctx, fileStats, file)
 		if err != nil {
 			return fmt.Errorf("%s scan failed: %w", languageScanner.LanguageID(), err)
 		}
 
-		for _, detection := range expectedDetections {
-			value := ""
-			detectorType := detectors.Type(detection.RuleID)
-			report.AddDetection(reportdetections.TypeExpectedDetection,
-				detectorType,
-				source.New(
-					file,
-					file.Path,
-					detection.MatchNode.ContentStart.Line,
-					detection.MatchNode.ContentStart.Column,
-					detection.MatchNode.ContentEnd.Line,
-					detection.MatchNode.ContentEnd.Column,
-					"",
-				),
-				reportschema.Source{
-					StartLineNumber:   detection.MatchNode.ContentStart.Line,
-					EndLineNumber:     detection.MatchNode.ContentEnd.Line,
-					StartColumnNumber: detection.MatchNode.ContentStart.Column,
-					EndColumnNumber:   detection.MatchNode.ContentEnd.Column,
-					Content:           value,
-				})
+		reportDetections(report, file, nil, detections, expectedDetections)
+	}
+
+	return nil
+}
+
+// sourceMap maps positions in code extracted from a file (such as the code
+// cells of a notebook) back to the file
+type sourceMap interface {
+	Position(line, column int) (int, int)
+}
+
+// scanNotebook scans the code cells of a Jupyter notebook with the scanner
+// for the notebook's language, reporting positions in the notebook file
+func (scanner *Scanner) scanNotebook(
+	ctx context.Context,
+	report report.Report,
+	fileStats *stats.FileStats,
+	file *file.FileInfo,
+) error {
+	content, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	parsedNotebook, err := notebook.Parse(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse notebook: %w", err)
+	}
+
+	return scanner.scanExtracted(ctx, report, fileStats, file, parsedNotebook.Language, parsedNotebook.Source, parsedNotebook)
+}
+
+// scanTemplate scans the code embedded in a view template with the scanner
+// for the template's host language, reporting positions in the template
+func (scanner *Scanner) scanTemplate(
+	ctx context.Context,
+	report report.Report,
+	fileStats *stats.FileStats,
+	file *file.FileInfo,
+	hostLanguage string,
+) error {
+	content, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	template := viewtemplate.Compile(hostLanguage, content)
+
+	return scanner.scanExtracted(ctx, report, fileStats, file, template.Language, template.Source, template)
+}
+
+func (scanner *Scanner) scanExtracted(
+	ctx context.Context,
+	report report.Report,
+	fileStats *stats.FileStats,
+	file *file.FileInfo,
+	languageID string,
+	source []byte,
+	sourceMap sourceMap,
+) error {
+	if len(source) == 0 {
+		return nil
+	}
+
+	for _, languageScanner := range scanner.languageScanners {
+		if languageScanner.LanguageID() != languageID {
+			continue
 		}
 
-		for _, detection := range detections {
-			detectorType := detectors.Type(detection.RuleID)
-			data := detection.Data.(customruletypes.Data)
-
-			if len(data.Datatypes) == 0 {
-				report.AddDetection(reportdetections.TypeCustomRisk,
-					detectorType,
-					source.New(
-						file,
-						file.Path,
-						detection.MatchNode.ContentStart.Line,
-						detection.MatchNode.ContentStart.Column,
-						detection.MatchNode.ContentEnd.Line,
-						detection.MatchNode.ContentEnd.Column,
-						data.Value,
-					),
-					reportschema.Source{
-						StartLineNumber:   detection.MatchNode.ContentStart.Line,
-						EndLineNumber:     detection.MatchNode.ContentEnd.Line,
-						StartColumnNumber: detection.MatchNode.ContentStart.Column,
-						EndColumnNumber:   detection.MatchNode.ContentEnd.Column,
-						Content:           data.Value,
-					})
-			}
-
-			for _, datatypeDetection := range data.Datatypes {
-				reportDatatypeDetection(
-					report,
-					file,
-					detectorType,
-					detection,
-					datatypeDetection,
-					"",
-				)
-			}
+		detections, expectedDetections, err := languageScanner.ScanContent(ctx, fileStats, file, source)
+		if err != nil {
+			return fmt.Errorf("%s scan failed: %w", languageScanner.LanguageID(), err)
 		}
+
+		reportDetections(report, file, sourceMap, detections, expectedDetections)
 	}
 
 	return nil
 }
 
+func reportDetections(
+	report report.Report,
+	file *file.FileInfo,
+	sourceMap sourceMap,
+	detections,
+	expectedDetections []*detectortypes.Detection,
+) {
+	for _, detection := range expectedDetections {
+		value := ""
+		detectorType := detectors.Type(detection.RuleID)
+		position := newPosition(sourceMap, detection.MatchNode)
+		report.AddDetection(reportdetections.TypeExpectedDetection,
+			detectorType,
+			position.source(file, ""),
+			position.schemaSource(value),
+		)
+	}
+
+	for _, detection := range detections {
+		detectorType := detectors.Type(detection.RuleID)
+		data := detection.Data.(customruletypes.Data)
+
+		if len(data.Datatypes) == 0 {
+			position := newPosition(sourceMap, detection.MatchNode)
+			report.AddDetection(reportdetections.TypeCustomRisk,
+				detectorType,
+				position.source(file, data.Value),
+				position.schemaSource(data.Value),
+			)
+		}
+
+		for _, datatypeDetection := range data.Datatypes {
+			reportDatatypeDetection(
+				report,
+				file,
+				sourceMap,
+				detectorType,
+				detection,
+				datatypeDetection,
+				dataflowLocations(sourceMap, detection.MatchNode, datatypeDetection.MatchNode),
+				"",
+			)
+		}
+	}
+}
+
 func reportDatatypeDetection(
 	report reportdetections.ReportDetection,
 	file *file.FileInfo,
+	sourceMap sourceMap,
 	detectorType detectors.Type,
 	detection,
 	datatypeDetection *detectortypes.Detection,
+	dataflow []reportschema.DataflowLocation,
 	objectName string,
 ) {
 	data := datatypeDetection.Data.(datatype.Data)
 
 	for _, property := range data.Properties {
 		detectionContent := detection.MatchNode.Content()
+		detectionSource := newPosition(sourceMap, detection.MatchNode).schemaSource(detectionContent)
+		detectionSource.Dataflow = dataflow
 
 		report.AddDetection(
 			reportdetections.TypeCustomClassified,
 			detectorType,
-			source.New(
-				file,
-				file.Path,
-				property.Node.ContentStart.Line,
-				property.Node.ContentStart.Column,
-				property.Node.ContentEnd.Line,
-				property.Node.ContentEnd.Column,
-				"",
-			),
+			newPosition(sourceMap, property.Node).source(file, ""),
 			reportschema.Schema{
 				ObjectName:           objectName,
 				NormalizedObjectName: pluralize.Singular(strings.ToLower(objectName)),
 				FieldName:            property.Name,
 				NormalizedFieldName:  pluralize.Singular(strings.ToLower(property.Name)),
 				Classification:       property.Classification,
-				Source: &reportschema.Source{
-					StartLineNumber:   detection.MatchNode.ContentStart.Line,
-					EndLineNumber:     detection.MatchNode.ContentEnd.Line,
-					StartColumnNumber: detection.MatchNode.ContentStart.Column,
-					EndColumnNumber:   detection.MatchNode.ContentEnd.Column,
-					Content:           detectionContent,
-				},
+				Source:               &detectionSource,
 			},
 		)
 
@@ -173,15 +238,88 @@ func reportDatatypeDetection(
 			reportDatatypeDetection(
 				report,
 				file,
+				sourceMap,
 				detectorType,
 				detection,
 				property.Datatype,
+				dataflow,
 				property.Name,
 			)
 		}
 	}
 }
 
+// dataflowLocations returns the locations of the nodes the data flows through
+// from the data type detection to the custom detection
+func dataflowLocations(
+	sourceMap sourceMap,
+	matchNode,
+	datatypeNode *tree.Node,
+) []reportschema.DataflowLocation {
+	var result []reportschema.DataflowLocation
+	for _, node := range matchNode.DataflowPath(datatypeNode) {
+		position := newPosition(sourceMap, node)
+		result = append(result, reportschema.DataflowLocation{
+			StartLineNumber: position.startLine,
+			EndLineNumber:   position.endLine,
+		})
+	}
+
+	return result
+}
+
+// position is the location of a node in the scanned file. For code extracted
+// from notebooks and templates, the location is mapped back to the file.
+type position struct {
+	startLine,
+	startColumn,
+	endLine,
+	endColumn int
+}
+
+func newPosition(sourceMap sourceMap, node *tree.Node) position {
+	if sourceMap == nil {
+		return position{
+			startLine:   node.ContentStart.Line,
+			startColumn: node.ContentStart.Column,
+			endLine:     node.ContentEnd.Line,
+			endColumn:   node.ContentEnd.Column,
+		}
+	}
+
+	startLine, startColumn := sourceMap.Position(node.ContentStart.Line, node.ContentStart.Column)
+	endLine, endColumn := sourceMap.Position(node.ContentEnd.Line, node.ContentEnd.Column)
+
+	return position{
+		startLine:   startLine,
+		startColumn: startColumn,
+		endLine:     endLine,
+		endColumn:   endColumn,
+	}
+}
+
+func (position position) source(file *file.FileInfo, text string) source.Source {
+	return source.New(
+		file,
+		file.Path,
+		position.startLine,
+		position.startColumn,
+		position.endLine,
+		position.endColumn,
+		text,
+	)
+}
+
+func (position position) schemaSource(content string) reportschema.Source {
+	return reportschema.Source{
+		StartLineNumber:   position.startLine,
+		EndLineNumber:     position.endLine,
+		StartColumnNumber: position.startColumn,
+		EndColumnNumber:   position.endColumn,
+		Content:           content,
+	}
+}
+
 func (scanner *Scanner) Close() {
 	for _, languageScanner := range scanner.languageScanners {
 		languageScanner.Close()
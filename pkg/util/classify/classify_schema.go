@@ -31,6 +31,12 @@ var databaseDetectorTypes = map[string]struct{}{
 	"sql_lang_create_table": {},
 	"rails":                 {},
 	"schema_rb":             {},
+	"prisma":                {},
+	"typeorm":               {},
+	"sequelize":             {},
+	"sqlalchemy":            {},
+	"alembic":               {},
+	"ent":                   {},
 }
 
 var expectedIdentifierDataTypeIds = map[string]struct{}{
@@ -0,0 +1,326 @@
+package codeowners
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bearer/bearer/pkg/git"
+	"github.com/bearer/bearer/pkg/util/file"
+)
+
+// Locations are the paths, relative to the root of the repository, where GitHub
+// and GitLab look for the CODEOWNERS file, in order of precedence
+var Locations = []string{
+	".github/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+var sectionRegex = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?\s*(.*)$`)
+
+// CodeOwners holds the rules of a CODEOWNERS file
+type CodeOwners struct {
+	rootDir string
+	rules   []rule
+}
+
+type rule struct {
+	// section is the GitLab section of the rule, empty for rules which are not
+	// in a section
+	section string
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// Load reads the CODEOWNERS file of the repository containing target. The
+// root of the repository is the git root, or the target itself outside of git.
+// It returns nil when there is no CODEOWNERS file
+func Load(target string) (*CodeOwners, error) {
+	rootDir, err := git.GetRoot(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if rootDir == "" {
+		rootDir = target
+		if !file.IsDir(rootDir) {
+			rootDir = filepath.Dir(rootDir)
+		}
+	}
+
+	rootDir, err = file.CanonicalPath(rootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, location := range Locations {
+		path := filepath.Join(rootDir, location)
+
+		content, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, err
+		}
+		defer content.Close()
+
+		codeOwners, err := Parse(rootDir, content)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", location, err)
+		}
+
+		return codeOwners, nil
+	}
+
+	return nil, nil
+}
+
+// LoadOptional is like Load, for when owners are only added to the report if
+// available. A missing or invalid CODEOWNERS file is only an error when it is
+// required, eg. to filter by owner. Otherwise, an invalid file is logged and
+// nil is returned
+func LoadOptional(target string, required bool) (*CodeOwners, error) {
+	codeOwners, err := Load(target)
+	if err != nil {
+		if required {
+			return nil, fmt.Errorf("failed to load CODEOWNERS: %w", err)
+		}
+
+		log.Warn().Msgf("ignoring CODEOWNERS file: %s", err)
+		return nil, nil
+	}
+
+	if codeOwners == nil && required {
+		return nil, errors.New("filtering by owner requires a CODEOWNERS file")
+	}
+
+	return codeOwners, nil
+}
+
+// Parse reads the rules of a CODEOWNERS file, using either the GitHub or GitLab
+// syntax. Paths are matched relative to rootDir
+func Parse(rootDir string, reader io.Reader) (*CodeOwners, error) {
+	codeOwners := &CodeOwners{rootDir: rootDir}
+
+	var section string
+	var sectionOwners []string
+
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if match := sectionRegex.FindStringSubmatch(line); match != nil {
+			section = match[1]
+			sectionOwners = strings.Fields(match[2])
+			continue
+		}
+
+		fields := splitFields(line)
+		pattern, err := compilePattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern on line %d: %w", lineNumber, err)
+		}
+
+		owners := fields[1:]
+		if len(owners) == 0 {
+			owners = sectionOwners
+		}
+
+		codeOwners.rules = append(codeOwners.rules, rule{
+			section: section,
+			pattern: pattern,
+			owners:  owners,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return codeOwners, nil
+}
+
+// Owners returns the owners of a file. The last matching rule of each section
+// applies, and the owners of all sections are combined
+func (codeOwners *CodeOwners) Owners(filename string) []string {
+	if codeOwners == nil {
+		return nil
+	}
+
+	// files from the git history may not be in the working tree
+	path, err := file.CanonicalPath(filename)
+	if err != nil {
+		path, err = filepath.Abs(filename)
+		if err != nil {
+			return nil
+		}
+	}
+
+	relativePath, err := filepath.Rel(codeOwners.rootDir, path)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return nil
+	}
+	relativePath = filepath.ToSlash(relativePath)
+
+	var sections []string
+	sectionOwners := make(map[string][]string)
+	for _, rule := range codeOwners.rules {
+		if !rule.pattern.MatchString(relativePath) {
+			continue
+		}
+
+		if _, seen := sectionOwners[rule.section]; !seen {
+			sections = append(sections, rule.section)
+		}
+		sectionOwners[rule.section] = rule.owners
+	}
+
+	var result []string
+	for _, section := range sections {
+		for _, owner := range sectionOwners[section] {
+			if !slices.Contains(result, owner) {
+				result = append(result, owner)
+			}
+		}
+	}
+
+	return result
+}
+
+// HasOwner returns whether any of the owners is one of the given owners. Owners
+// are compared case-insensitively, as GitHub and GitLab names are
+func HasOwner(owners []string, wanted []string) bool {
+	for _, owner := range owners {
+		for _, wantedOwner := range wanted {
+			if strings.EqualFold(owner, wantedOwner) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// splitFields splits a line on whitespace, keeping escaped spaces in the
+// pattern
+func splitFields(line string) []string {
+	var fields []string
+	var current strings.Builder
+
+	escaped := false
+	for _, char := range line {
+		switch {
+		case escaped:
+			if char != ' ' {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(char)
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == ' ' || char == '\t':
+			if current.Len() != 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(char)
+		}
+	}
+
+	if current.Len() != 0 {
+		fields = append(fields, current.String())
+	}
+
+	return fields
+}
+
+// compilePattern converts a gitignore style pattern to a regular expression
+// matching paths relative to the root of the repository
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	directoryOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	// patterns with a slash other than a trailing one are relative to the root,
+	// the others match at any depth
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var expression strings.Builder
+	expression.WriteString("^")
+	if !anchored {
+		expression.WriteString("(?:.*/)?")
+	}
+
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		last := i == len(segments)-1
+
+		if segment == "**" {
+			if last {
+				expression.WriteString(".*")
+			} else {
+				expression.WriteString("(?:.*/)?")
+			}
+			continue
+		}
+
+		expression.WriteString(convertSegment(segment))
+		if !last {
+			expression.WriteString("/")
+		}
+	}
+
+	// a pattern matches the files below a matching directory, except for a
+	// trailing wildcard which only matches the direct children of a directory
+	switch {
+	case directoryOnly:
+		expression.WriteString("/.*")
+	case segments[len(segments)-1] == "*", segments[len(segments)-1] == "**":
+	default:
+		expression.WriteString("(?:/.*)?")
+	}
+	expression.WriteString("$")
+
+	return regexp.Compile(expression.String())
+}
+
+func convertSegment(segment string) string {
+	var result strings.Builder
+
+	escaped := false
+	for _, char := range segment {
+		switch {
+		case escaped:
+			result.WriteString(regexp.QuoteMeta(string(char)))
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '*':
+			result.WriteString("[^/]*")
+		case char == '?':
+			result.WriteString("[^/]")
+		default:
+			result.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+
+	return result.String()
+}
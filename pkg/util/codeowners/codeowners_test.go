@@ -0,0 +1,114 @@
+package codeowners_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bearer/bearer/pkg/util/codeowners"
+)
+
+func TestOwners_GitHub(t *testing.T) {
+	rootDir := t.TempDir()
+
+	codeOwners, err := codeowners.Parse(rootDir, strings.NewReader(`
+# default owners
+*       @acme/everyone
+*.js    @acme/frontend
+/build/logs/ @acme/ops
+docs/*  docs@example.com
+apps/   @acme/apps
+**/secrets @acme/security
+/app/vendor/
+my\ file.rb @acme/spaces
+`))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		filename string
+		expected []string
+	}{
+		{filename: "README.md", expected: []string{"@acme/everyone"}},
+		{filename: "src/index.js", expected: []string{"@acme/frontend"}},
+		{filename: "build/logs/out.txt", expected: []string{"@acme/ops"}},
+		{filename: "docs/index.md", expected: []string{"docs@example.com"}},
+		{filename: "docs/guides/setup.md", expected: []string{"@acme/everyone"}},
+		{filename: "src/apps/main.rb", expected: []string{"@acme/apps"}},
+		{filename: "config/secrets/key.pem", expected: []string{"@acme/security"}},
+		{filename: "app/vendor/lib.rb", expected: nil},
+		{filename: "my file.rb", expected: []string{"@acme/spaces"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.filename, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, codeOwners.Owners(filepath.Join(rootDir, testCase.filename)))
+		})
+	}
+}
+
+func TestOwners_GitLabSections(t *testing.T) {
+	rootDir := t.TempDir()
+
+	codeOwners, err := codeowners.Parse(rootDir, strings.NewReader(`
+*.rb @backend
+
+[Security][2] @security
+app/models/
+config/ @security @ops
+
+^[Docs]
+*.md @docs
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"@backend", "@security"}, codeOwners.Owners(filepath.Join(rootDir, "app/models/user.rb")))
+	assert.Equal(t, []string{"@backend", "@security", "@ops"}, codeOwners.Owners(filepath.Join(rootDir, "config/initializer.rb")))
+	assert.Equal(t, []string{"@docs"}, codeOwners.Owners(filepath.Join(rootDir, "README.md")))
+	assert.Nil(t, codeOwners.Owners(filepath.Join(rootDir, "main.go")))
+}
+
+func TestLoad(t *testing.T) {
+	rootDir := t.TempDir()
+
+	codeOwners, err := codeowners.Load(rootDir)
+	require.NoError(t, err)
+	assert.Nil(t, codeOwners)
+
+	require.NoError(t, os.Mkdir(filepath.Join(rootDir, ".github"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, ".github", "CODEOWNERS"), []byte("* @acme/team\n"), 0600))
+
+	codeOwners, err = codeowners.Load(rootDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@acme/team"}, codeOwners.Owners(filepath.Join(rootDir, "main.rb")))
+}
+
+func TestLoadOptional(t *testing.T) {
+	rootDir := t.TempDir()
+
+	codeOwners, err := codeowners.LoadOptional(rootDir, false)
+	require.NoError(t, err)
+	assert.Nil(t, codeOwners)
+
+	_, err = codeowners.LoadOptional(rootDir, true)
+	assert.Error(t, err)
+
+	// an unreadable CODEOWNERS file
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, ".github", "CODEOWNERS"), 0700))
+
+	codeOwners, err = codeowners.LoadOptional(rootDir, false)
+	require.NoError(t, err)
+	assert.Nil(t, codeOwners)
+
+	_, err = codeowners.LoadOptional(rootDir, true)
+	assert.Error(t, err)
+}
+
+func TestHasOwner(t *testing.T) {
+	assert.True(t, codeowners.HasOwner([]string{"@acme/backend", "@acme/Security"}, []string{"@acme/security"}))
+	assert.False(t, codeowners.HasOwner([]string{"@acme/backend"}, []string{"@acme/security"}))
+	assert.False(t, codeowners.HasOwner(nil, []string{"@acme/security"}))
+}
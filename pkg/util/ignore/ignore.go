@@ -64,6 +64,16 @@ func MergeIgnoredFingerprints(fingerprintsToIgnore map[string]types.IgnoredFinge
 	return nil
 }
 
+func WriteIgnoreFile(ignoredFingerprints map[string]types.IgnoredFingerprint, ignoreFilePath string) error {
+	data, err := json.MarshalIndent(ignoredFingerprints, "", "  ")
+	if err != nil {
+		// failed to marshall data
+		return err
+	}
+
+	return os.WriteFile(ignoreFilePath, data, 0644)
+}
+
 var bold = color.New(color.Bold).SprintFunc()
 var morePrefix = color.HiBlackString("├─ ")
 var lastPrefix = color.HiBlackString("└─ ")
@@ -73,31 +83,35 @@ func DisplayIgnoredEntryTextString(fingerprintId string, entry types.IgnoredFing
 	if noColor && !initialColorSetting {
 		color.NoColor = true
 	}
-	prefix := morePrefix
-	result := fmt.Sprintf(bold(color.HiBlueString("%s \n")), fingerprintId)
-
-	if entry.Author == nil && entry.Comment == nil {
-		prefix = lastPrefix
-	}
-	result += fmt.Sprintf("%sIgnored At: %s", prefix, bold(entry.IgnoredAt))
 
+	lines := []string{fmt.Sprintf("Ignored At: %s", bold(entry.IgnoredAt))}
 	if entry.Author != nil {
-		result += fmt.Sprintf("\n%sAuthor: %s", prefix, bold(*entry.Author))
+		lines = append(lines, fmt.Sprintf("Author: %s", bold(*entry.Author)))
 	}
-
-	if entry.Comment == nil {
-		prefix = lastPrefix
+	if entry.Owner != nil {
+		lines = append(lines, fmt.Sprintf("Owner: %s", bold(*entry.Owner)))
 	}
-	var falsePositiveStr string
+
+	falsePositiveStr := "No"
 	if entry.FalsePositive {
 		falsePositiveStr = "Yes"
-	} else {
-		falsePositiveStr = "No"
 	}
-	result += fmt.Sprintf("\n%sFalse positive? %s", prefix, bold(falsePositiveStr))
+	lines = append(lines, fmt.Sprintf("False positive? %s", bold(falsePositiveStr)))
 
 	if entry.Comment != nil {
-		result += fmt.Sprintf("\n%sComment: %s", lastPrefix, bold(*entry.Comment))
+		lines = append(lines, fmt.Sprintf("Comment: %s", bold(*entry.Comment)))
+	}
+
+	result := fmt.Sprintf(bold(color.HiBlueString("%s \n")), fingerprintId)
+	for i, line := range lines {
+		prefix := morePrefix
+		if i == len(lines)-1 {
+			prefix = lastPrefix
+		}
+		if i > 0 {
+			result += "\n"
+		}
+		result += prefix + line
 	}
 
 	color.NoColor = initialColorSetting
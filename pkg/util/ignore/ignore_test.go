@@ -112,3 +112,55 @@ func TestMergeIgnoredFingerprints(t *testing.T) {
 		})
 	}
 }
+
+func TestDisplayIgnoredEntryTextString(t *testing.T) {
+	author := "jane"
+	owner := "team-a"
+	comment := "not an issue"
+
+	tests := []struct {
+		Name  string
+		Entry types.IgnoredFingerprint
+		Want  string
+	}{
+		{
+			Name:  "no optional fields",
+			Entry: types.IgnoredFingerprint{IgnoredAt: "2023-08-28T09:30:01Z"},
+			Want: "123 \n" +
+				"├─ Ignored At: 2023-08-28T09:30:01Z\n" +
+				"└─ False positive? No",
+		},
+		{
+			Name:  "owner only",
+			Entry: types.IgnoredFingerprint{IgnoredAt: "2023-08-28T09:30:01Z", Owner: &owner, FalsePositive: true},
+			Want: "123 \n" +
+				"├─ Ignored At: 2023-08-28T09:30:01Z\n" +
+				"├─ Owner: team-a\n" +
+				"└─ False positive? Yes",
+		},
+		{
+			Name:  "author and comment",
+			Entry: types.IgnoredFingerprint{IgnoredAt: "2023-08-28T09:30:01Z", Author: &author, Comment: &comment},
+			Want: "123 \n" +
+				"├─ Ignored At: 2023-08-28T09:30:01Z\n" +
+				"├─ Author: jane\n" +
+				"├─ False positive? No\n" +
+				"└─ Comment: not an issue",
+		},
+		{
+			Name:  "all fields",
+			Entry: types.IgnoredFingerprint{IgnoredAt: "2023-08-28T09:30:01Z", Author: &author, Owner: &owner, Comment: &comment},
+			Want: "123 \n" +
+				"├─ Ignored At: 2023-08-28T09:30:01Z\n" +
+				"├─ Author: jane\n" +
+				"├─ Owner: team-a\n" +
+				"├─ False positive? No\n" +
+				"└─ Comment: not an issue",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.Name, func(t *testing.T) {
+			assert.Equal(t, testCase.Want, ignore.DisplayIgnoredEntryTextString("123", testCase.Entry, true))
+		})
+	}
+}
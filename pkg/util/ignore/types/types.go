@@ -1,8 +1,9 @@
 package types
 
 type IgnoredFingerprint struct {
-	Author        *string `json:"author,omitempty"`
-	Comment       *string `json:"comment,omitempty"`
-	FalsePositive bool    `json:"false_positive"`
-	IgnoredAt     string  `json:"ignored_at"`
+	Author        *string `json:"author,omitempty" yaml:"author,omitempty"`
+	Comment       *string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Owner         *string `json:"owner,omitempty" yaml:"owner,omitempty"`
+	FalsePositive bool    `json:"false_positive" yaml:"false_positive"`
+	IgnoredAt     string  `json:"ignored_at" yaml:"ignored_at"`
 }
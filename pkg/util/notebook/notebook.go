@@ -0,0 +1,271 @@
+// Package notebook extracts the code cells of Jupyter notebooks so that they
+// can be scanned as regular source code, and maps positions in that code back
+// to the notebook file.
+package notebook
+
+import (
+	"errors"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnryLanguage is the language enry reports for Jupyter notebooks
+const EnryLanguage = "Jupyter Notebook"
+
+const defaultLanguage = "python"
+
+// Notebook holds the code cells of a notebook joined into a single source.
+// Cells are separated by a blank line so that each cell starts on a fresh
+// statement.
+type Notebook struct {
+	Language string
+	Source   []byte
+	lines    []line
+}
+
+// CellLocation identifies a line inside a notebook cell. Both values are
+// 1-based; Cell counts every cell of the notebook, not only code cells.
+type CellLocation struct {
+	Cell       int `json:"cell" yaml:"cell"`
+	LineNumber int `json:"line_number" yaml:"line_number"`
+}
+
+type line struct {
+	cell         int
+	cellLine     int
+	fileLine     int
+	columnOffset int
+}
+
+type cellString struct {
+	value  string
+	line   int
+	column int
+}
+
+// Parse reads the notebook JSON. Cells written in a language other than the
+// notebook's main language (eg. from nbformat 3 notebooks) are skipped.
+func Parse(content []byte) (*Notebook, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("notebook is not a JSON object")
+	}
+	root := document.Content[0]
+
+	notebook := &Notebook{Language: notebookLanguage(root)}
+
+	var source strings.Builder
+	cellIndex := 0
+	addCells := func(cells *yaml.Node) {
+		if cells == nil || cells.Kind != yaml.SequenceNode {
+			return
+		}
+
+		for _, cell := range cells.Content {
+			cellIndex++
+
+			if scalarValue(mappingValue(cell, "cell_type")) != "code" {
+				continue
+			}
+
+			if language := scalarValue(mappingValue(cell, "language")); language != "" && language != notebook.Language {
+				continue
+			}
+
+			code, lines := cellCode(cellIndex, cellSource(cell))
+			if code == "" {
+				continue
+			}
+
+			if source.Len() != 0 {
+				source.WriteString("\n")
+				notebook.lines = append(notebook.lines, line{})
+			}
+
+			source.WriteString(code)
+			notebook.lines = append(notebook.lines, lines...)
+		}
+	}
+
+	addCells(mappingValue(root, "cells"))
+
+	if worksheets := mappingValue(root, "worksheets"); worksheets != nil {
+		for _, worksheet := range worksheets.Content {
+			addCells(mappingValue(worksheet, "cells"))
+		}
+	}
+
+	notebook.Source = []byte(source.String())
+
+	return notebook, nil
+}
+
+// cellCode joins the strings of a cell, always ending with a newline, and
+// returns the position of each resulting line in the notebook file
+func cellCode(cellIndex int, cellStrings []cellString) (string, []line) {
+	var code strings.Builder
+	var lines []line
+	cellLine := 1
+	atLineStart := true
+
+	for _, cellString := range cellStrings {
+		for i, char := range cellString.value {
+			if atLineStart {
+				columnOffset := 0
+				if i == 0 {
+					columnOffset = cellString.column
+				}
+
+				lines = append(lines, line{
+					cell:         cellIndex,
+					cellLine:     cellLine,
+					fileLine:     cellString.line,
+					columnOffset: columnOffset,
+				})
+
+				atLineStart = false
+			}
+
+			code.WriteRune(char)
+
+			if char == '\n' {
+				cellLine++
+				atLineStart = true
+			}
+		}
+	}
+
+	if code.Len() == 0 {
+		return "", nil
+	}
+
+	result := commentMagics(code.String())
+	if !strings.HasSuffix(result, "\n") {
+		result += "\n"
+	}
+
+	return result, lines
+}
+
+// Position converts a 1-based line and column in the notebook source into
+// the corresponding position in the notebook file
+func (notebook *Notebook) Position(sourceLine, sourceColumn int) (int, int) {
+	line := notebook.line(sourceLine)
+	if line == nil {
+		return sourceLine, sourceColumn
+	}
+
+	return line.fileLine, line.columnOffset + sourceColumn
+}
+
+// CellLocation returns the cell and line within the cell for a 1-based line
+// in the notebook file. When a single line of the file holds several lines of
+// code, the first of them is used.
+func (notebook *Notebook) CellLocation(fileLine int) *CellLocation {
+	for _, line := range notebook.lines {
+		if line.cell != 0 && line.fileLine == fileLine {
+			return &CellLocation{Cell: line.cell, LineNumber: line.cellLine}
+		}
+	}
+
+	return nil
+}
+
+func (notebook *Notebook) line(sourceLine int) *line {
+	if sourceLine < 1 || sourceLine > len(notebook.lines) {
+		return nil
+	}
+
+	line := &notebook.lines[sourceLine-1]
+	if line.cell == 0 {
+		return nil
+	}
+
+	return line
+}
+
+// commentMagics turns IPython magics and shell escapes (eg. `%matplotlib` or
+// `!pip install`) into comments, keeping the positions of everything else
+func commentMagics(code string) string {
+	lines := strings.SplitAfter(code, "\n")
+
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		if strings.HasPrefix(trimmed, "%") || strings.HasPrefix(trimmed, "!") {
+			lines[i] = line[:len(line)-len(trimmed)] + "#" + trimmed[1:]
+		}
+	}
+
+	return strings.Join(lines, "")
+}
+
+func notebookLanguage(root *yaml.Node) string {
+	metadata := mappingValue(root, "metadata")
+
+	if language := scalarValue(mappingValue(mappingValue(metadata, "kernelspec"), "language")); language != "" {
+		return strings.ToLower(language)
+	}
+
+	if language := scalarValue(mappingValue(mappingValue(metadata, "language_info"), "name")); language != "" {
+		return strings.ToLower(language)
+	}
+
+	return defaultLanguage
+}
+
+// cellSource returns the strings making up the code of a cell. nbformat 4
+// uses `source` and nbformat 3 uses `input`; both may hold a single string or
+// a list of lines.
+func cellSource(cell *yaml.Node) []cellString {
+	value := mappingValue(cell, "source")
+	if value == nil {
+		value = mappingValue(cell, "input")
+	}
+
+	if value == nil {
+		return nil
+	}
+
+	switch value.Kind {
+	case yaml.ScalarNode:
+		return []cellString{{value: value.Value, line: value.Line, column: value.Column}}
+	case yaml.SequenceNode:
+		var result []cellString
+		for _, item := range value.Content {
+			if item.Kind == yaml.ScalarNode {
+				result = append(result, cellString{value: item.Value, line: item.Line, column: item.Column})
+			}
+		}
+
+		return result
+	}
+
+	return nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func scalarValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return node.Value
+}
@@ -0,0 +1,97 @@
+package notebook_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/pkg/util/notebook"
+)
+
+const nbformat4 = `{
+ "cells": [
+  {
+   "cell_type": "markdown",
+   "source": ["# Title"]
+  },
+  {
+   "cell_type": "code",
+   "source": [
+    "%matplotlib inline\n",
+    "import pickle"
+   ]
+  },
+  {
+   "cell_type": "code",
+   "source": "def load(data):\n    return pickle.loads(data)\n"
+  }
+ ],
+ "metadata": {
+  "kernelspec": {"language": "python"}
+ }
+}`
+
+func TestParse(t *testing.T) {
+	parsed, err := notebook.Parse([]byte(nbformat4))
+	if err != nil {
+		t.Fatalf("failed to parse notebook: %s", err)
+	}
+
+	assert.Equal(t, "python", parsed.Language)
+	assert.Equal(
+		t,
+		"#matplotlib inline\nimport pickle\n\ndef load(data):\n    return pickle.loads(data)\n",
+		string(parsed.Source),
+	)
+
+	line, column := parsed.Position(2, 1)
+	assert.Equal(t, 11, line)
+	assert.Equal(t, 6, column)
+
+	line, _ = parsed.Position(5, 5)
+	assert.Equal(t, 16, line)
+
+	assert.Equal(t, &notebook.CellLocation{Cell: 2, LineNumber: 2}, parsed.CellLocation(11))
+	assert.Equal(t, &notebook.CellLocation{Cell: 3, LineNumber: 1}, parsed.CellLocation(16))
+	assert.Nil(t, parsed.CellLocation(4))
+}
+
+const nbformat3 = `{
+ "metadata": {},
+ "worksheets": [
+  {
+   "cells": [
+    {
+     "cell_type": "code",
+     "input": ["x = 1\n"],
+     "language": "python"
+    },
+    {
+     "cell_type": "code",
+     "input": ["puts 1\n"],
+     "language": "ruby"
+    },
+    {
+     "cell_type": "code",
+     "input": ["y = 2\n"],
+     "language": "python"
+    }
+   ]
+  }
+ ]
+}`
+
+func TestParseNbformat3(t *testing.T) {
+	parsed, err := notebook.Parse([]byte(nbformat3))
+	if err != nil {
+		t.Fatalf("failed to parse notebook: %s", err)
+	}
+
+	assert.Equal(t, "x = 1\n\ny = 2\n", string(parsed.Source))
+	assert.Equal(t, &notebook.CellLocation{Cell: 3, LineNumber: 1}, parsed.CellLocation(18))
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := notebook.Parse([]byte(`["not", "a", "notebook"]`))
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package output
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"os"
@@ -106,6 +107,15 @@ func ReportJSON(outputDetections any) (string, error) {
 	return string(jsonBytes), nil
 }
 
+func ReportXML(outputDetections any) (string, error) {
+	xmlBytes, err := xml.MarshalIndent(&outputDetections, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to xml marshal detections: %s", err)
+	}
+
+	return xml.Header + string(xmlBytes), nil
+}
+
 func ReportYAML(outputDetections any) (string, error) {
 	yamlBytes, err := yaml.Marshal(&outputDetections)
 	if err != nil {
@@ -0,0 +1,172 @@
+package viewtemplate
+
+// bladeDirectives maps the directives affecting the flow of data to the PHP
+// they compile to, which is the prefix, the directive's arguments (if any) and
+// the suffix. Other directives are ignored.
+var bladeDirectives = map[string]struct {
+	prefix, suffix string
+}{
+	"if":         {"if ", ":"},
+	"elseif":     {"elseif ", ":"},
+	"else":       {"else:", ""},
+	"endif":      {"endif;", ""},
+	"unless":     {"if (! ", "):"},
+	"endunless":  {"endif;", ""},
+	"isset":      {"if (isset", "):"},
+	"endisset":   {"endif;", ""},
+	"foreach":    {"foreach ", ":"},
+	"endforeach": {"endforeach;", ""},
+	"forelse":    {"foreach ", ":"},
+	"endforelse": {"endforeach;", ""},
+	"for":        {"for ", ":"},
+	"endfor":     {"endfor;", ""},
+	"while":      {"while ", ":"},
+	"endwhile":   {"endwhile;", ""},
+	"json":       {"echo json_encode", ";"},
+}
+
+// compileBlade compiles a Blade template into PHP the way Laravel does
+func compileBlade(builder *builder) {
+	builder.write("<?php\n")
+	offset := 0
+
+	for offset < len(builder.content) {
+		switch {
+		case builder.hasPrefix(offset, "@{{"):
+			offset = skipPast(builder, offset+3, "}}")
+		case builder.hasPrefix(offset, "@{!!"):
+			offset = skipPast(builder, offset+4, "!!}")
+		case builder.hasPrefix(offset, "@@"):
+			offset += 2
+		case builder.hasPrefix(offset, "{{--"):
+			offset = skipPast(builder, offset+4, "--}}")
+		case builder.hasPrefix(offset, "{{"):
+			offset = compileBladeEcho(builder, offset+2, "}}", "echo e(", ");\n")
+		case builder.hasPrefix(offset, "{!!"):
+			offset = compileBladeEcho(builder, offset+3, "!!}", "echo ", ";\n")
+		case builder.hasPrefix(offset, "<?php"):
+			offset = compileBladeEcho(builder, offset+5, "?>", "", ";\n")
+		case builder.hasPrefix(offset, "<?="):
+			offset = compileBladeEcho(builder, offset+3, "?>", "echo ", ";\n")
+		case builder.content[offset] == '@' && (offset == 0 || !isWordChar(builder.content[offset-1])):
+			offset = compileBladeDirective(builder, offset+1)
+		default:
+			offset++
+		}
+	}
+}
+
+// compileBladeEcho compiles the code up to the end marker, returning the
+// offset after the marker
+func compileBladeEcho(builder *builder, start int, endMarker, prefix, suffix string) int {
+	end := builder.index(start, endMarker)
+	if end == -1 {
+		end = len(builder.content)
+	}
+
+	if builder.text(start, end) != "" {
+		builder.write(prefix)
+		builder.copy(start, end)
+		builder.write(suffix)
+	}
+
+	return min(end+len(endMarker), len(builder.content))
+}
+
+// compileBladeDirective compiles the directive starting at the given offset
+// (after the `@`), returning the offset after the directive
+func compileBladeDirective(builder *builder, start int) int {
+	nameEnd := start
+	for nameEnd < len(builder.content) && isWordChar(builder.content[nameEnd]) {
+		nameEnd++
+	}
+
+	name := string(builder.content[start:nameEnd])
+
+	switch name {
+	case "php":
+		argumentsStart := builder.trimmedStart(nameEnd, len(builder.content))
+		if argumentsStart < len(builder.content) && builder.content[argumentsStart] == '(' {
+			if argumentsEnd := matchingParenthesis(builder, argumentsStart); argumentsEnd != -1 {
+				builder.copy(argumentsStart+1, argumentsEnd-1)
+				builder.write(";\n")
+				return argumentsEnd
+			}
+		}
+
+		return compileBladeEcho(builder, nameEnd, "@endphp", "", ";\n")
+	case "verbatim":
+		return skipPast(builder, nameEnd, "@endverbatim")
+	}
+
+	directive, known := bladeDirectives[name]
+	if !known {
+		return nameEnd
+	}
+
+	if directive.suffix == "" {
+		builder.write(directive.prefix + "\n")
+		return nameEnd
+	}
+
+	argumentsStart := builder.trimmedStart(nameEnd, len(builder.content))
+	if argumentsStart == len(builder.content) || builder.content[argumentsStart] != '(' {
+		return nameEnd
+	}
+
+	argumentsEnd := matchingParenthesis(builder, argumentsStart)
+	if argumentsEnd == -1 {
+		return nameEnd
+	}
+
+	builder.write(directive.prefix)
+	builder.copy(argumentsStart, argumentsEnd)
+	builder.write(directive.suffix + "\n")
+
+	return argumentsEnd
+}
+
+// matchingParenthesis returns the offset after the parenthesis closing the
+// one at the given offset, or -1
+func matchingParenthesis(builder *builder, start int) int {
+	depth := 0
+	var quote byte
+
+	for i := start; i < len(builder.content); i++ {
+		char := builder.content[i]
+
+		if quote != 0 {
+			if char == '\\' {
+				i++
+			} else if char == quote {
+				quote = 0
+			}
+
+			continue
+		}
+
+		switch char {
+		case '"', '\'':
+			quote = char
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return -1
+}
+
+// skipPast returns the offset after the next occurrence of the end marker
+func skipPast(builder *builder, start int, endMarker string) int {
+	end := builder.index(start, endMarker)
+	if end == -1 {
+		return len(builder.content)
+	}
+
+	return end + len(endMarker)
+}
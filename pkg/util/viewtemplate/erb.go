@@ -0,0 +1,65 @@
+package viewtemplate
+
+import "regexp"
+
+// blockExpressionPattern matches output tags which open a block, such as
+// `<%= form_with model: @user do |form| %>`
+var blockExpressionPattern = regexp.MustCompile(`(\bdo|\{)\s*(\|[^|]*\|)?\s*$`)
+
+// compileERB compiles an ERB template the way ActionView does
+func compileERB(builder *builder) {
+	offset := 0
+
+	for {
+		start := builder.index(offset, "<%")
+		if start == -1 {
+			return
+		}
+
+		if builder.hasPrefix(start, "<%%") {
+			offset = start + 3
+			continue
+		}
+
+		end := builder.index(start+2, "%>")
+		if end == -1 {
+			return
+		}
+		offset = end + 2
+
+		codeStart := start + 2
+		codeEnd := end
+		if codeEnd > codeStart && builder.content[codeEnd-1] == '-' {
+			codeEnd--
+		}
+
+		switch {
+		case builder.hasPrefix(start, "<%#"):
+			continue
+		case builder.hasPrefix(start, "<%=="):
+			compileERBOutput(builder, "@output_buffer.safe_append=", codeStart+2, codeEnd)
+		case builder.hasPrefix(start, "<%="):
+			compileERBOutput(builder, "@output_buffer.append=", codeStart+1, codeEnd)
+		default:
+			if builder.hasPrefix(start, "<%-") {
+				codeStart++
+			}
+
+			builder.copy(codeStart, codeEnd)
+			builder.write("\n")
+		}
+	}
+}
+
+func compileERBOutput(builder *builder, method string, start, end int) {
+	if blockExpressionPattern.MatchString(builder.text(start, end)) {
+		builder.write(method + " ")
+		builder.copy(start, end)
+		builder.write("\n")
+		return
+	}
+
+	builder.write(method + "(")
+	builder.copy(start, end)
+	builder.write(")\n")
+}
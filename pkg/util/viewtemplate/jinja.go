@@ -0,0 +1,280 @@
+package viewtemplate
+
+import "strings"
+
+// jinjaCompiler compiles Jinja2 (and Django) templates into Python. Control
+// structures are compiled into indented Python blocks; tags which don't
+// affect the flow of data are ignored.
+type jinjaCompiler struct {
+	*builder
+	// blocks records whether each open block has a statement yet
+	blocks []bool
+	// autoescape is the stack of autoescape settings, raw output is compiled
+	// when the innermost setting is off
+	autoescape []bool
+}
+
+func compileJinja(builder *builder) {
+	compiler := &jinjaCompiler{builder: builder}
+	offset := 0
+
+	for {
+		start := compiler.nextTag(offset)
+		if start == -1 {
+			break
+		}
+
+		switch {
+		case builder.hasPrefix(start, "{#"):
+			end := builder.index(start+2, "#}")
+			if end == -1 {
+				offset = len(builder.content)
+				continue
+			}
+			offset = end + 2
+		case builder.hasPrefix(start, "{{"):
+			end := builder.index(start+2, "}}")
+			if end == -1 {
+				offset = len(builder.content)
+				continue
+			}
+			offset = end + 2
+
+			compiler.output(trimWhitespaceControl(builder, start+2, end))
+		default:
+			end := builder.index(start+2, "%}")
+			if end == -1 {
+				offset = len(builder.content)
+				continue
+			}
+			offset = end + 2
+
+			bodyStart, bodyEnd := trimWhitespaceControl(builder, start+2, end)
+			offset = compiler.statement(bodyStart, bodyEnd, offset)
+		}
+	}
+
+	for len(compiler.blocks) != 0 {
+		compiler.closeBlock()
+	}
+}
+
+func (compiler *jinjaCompiler) nextTag(offset int) int {
+	for {
+		start := compiler.index(offset, "{")
+		if start == -1 || start+1 >= len(compiler.content) {
+			return -1
+		}
+
+		switch compiler.content[start+1] {
+		case '{', '%', '#':
+			return start
+		}
+
+		offset = start + 1
+	}
+}
+
+// output compiles an expression tag, keeping the expression before any
+// filters
+func (compiler *jinjaCompiler) output(start, end int) {
+	expressionEnd := end
+	raw := len(compiler.autoescape) != 0 && !compiler.autoescape[len(compiler.autoescape)-1]
+
+	filters := splitFilters(compiler.builder, start, end)
+	if len(filters) != 0 {
+		expressionEnd = filters[0] - 1
+
+		for i, filterStart := range filters {
+			filterEnd := end
+			if i+1 < len(filters) {
+				filterEnd = filters[i+1] - 1
+			}
+
+			if filterName(compiler.text(filterStart, filterEnd)) == "safe" {
+				raw = true
+			}
+		}
+	}
+
+	function := "escape("
+	if raw {
+		function = "Markup("
+	}
+
+	compiler.line()
+	compiler.write(function)
+	compiler.copy(start, expressionEnd)
+	compiler.write(")\n")
+}
+
+// statement compiles a statement tag, returning the offset to continue from
+func (compiler *jinjaCompiler) statement(start, end, offset int) int {
+	bodyStart := compiler.trimmedStart(start, end)
+	keywordEnd := bodyStart
+	for keywordEnd < end && isWordChar(compiler.content[keywordEnd]) {
+		keywordEnd++
+	}
+
+	keyword := string(compiler.content[bodyStart:keywordEnd])
+	arguments := compiler.text(keywordEnd, end)
+
+	switch keyword {
+	case "set":
+		if !strings.Contains(arguments, "=") {
+			return offset
+		}
+
+		compiler.line()
+		compiler.copy(keywordEnd, end)
+		compiler.write("\n")
+	case "with":
+		compiler.line()
+		compiler.write("if True:\n")
+		compiler.blocks = append(compiler.blocks, false)
+		compiler.line()
+		compiler.copy(keywordEnd, end)
+		compiler.write("\n")
+	case "if", "for", "while":
+		compiler.line()
+		compiler.write(keyword + " ")
+		compiler.copy(keywordEnd, end)
+		compiler.write(":\n")
+		compiler.blocks = append(compiler.blocks, false)
+	case "macro":
+		compiler.line()
+		compiler.write("def ")
+		compiler.copy(keywordEnd, end)
+		compiler.write(":\n")
+		compiler.blocks = append(compiler.blocks, false)
+	case "elif", "else":
+		if len(compiler.blocks) == 0 {
+			return offset
+		}
+
+		compiler.closeBlock()
+		compiler.line()
+		compiler.write(keyword)
+		if keyword == "elif" {
+			compiler.write(" ")
+			compiler.copy(keywordEnd, end)
+		}
+		compiler.write(":\n")
+		compiler.blocks = append(compiler.blocks, false)
+	case "endif", "endfor", "endwhile", "endwith", "endmacro":
+		if len(compiler.blocks) != 0 {
+			compiler.closeBlock()
+		}
+	case "autoescape":
+		compiler.autoescape = append(compiler.autoescape, arguments != "false" && arguments != "off")
+	case "endautoescape":
+		if len(compiler.autoescape) != 0 {
+			compiler.autoescape = compiler.autoescape[:len(compiler.autoescape)-1]
+		}
+	case "raw", "verbatim":
+		return compiler.skipRaw(offset, "end"+keyword)
+	}
+
+	return offset
+}
+
+// line starts a new statement in the current block
+func (compiler *jinjaCompiler) line() {
+	if len(compiler.blocks) != 0 {
+		compiler.blocks[len(compiler.blocks)-1] = true
+	}
+
+	compiler.write(strings.Repeat("    ", len(compiler.blocks)))
+}
+
+func (compiler *jinjaCompiler) closeBlock() {
+	if !compiler.blocks[len(compiler.blocks)-1] {
+		compiler.line()
+		compiler.write("pass\n")
+	}
+
+	compiler.blocks = compiler.blocks[:len(compiler.blocks)-1]
+}
+
+// skipRaw returns the offset after the tag ending a raw block
+func (compiler *jinjaCompiler) skipRaw(offset int, endKeyword string) int {
+	for {
+		start := compiler.index(offset, "{%")
+		if start == -1 {
+			return len(compiler.content)
+		}
+
+		end := compiler.index(start+2, "%}")
+		if end == -1 {
+			return len(compiler.content)
+		}
+
+		bodyStart, bodyEnd := trimWhitespaceControl(compiler.builder, start+2, end)
+		if compiler.text(bodyStart, bodyEnd) == endKeyword {
+			return end + 2
+		}
+
+		offset = end + 2
+	}
+}
+
+// trimWhitespaceControl removes the `-` and `+` whitespace control markers
+// from the body of a tag
+func trimWhitespaceControl(builder *builder, start, end int) (int, int) {
+	if start < end && (builder.content[start] == '-' || builder.content[start] == '+') {
+		start++
+	}
+
+	if end > start && (builder.content[end-1] == '-' || builder.content[end-1] == '+') {
+		end--
+	}
+
+	return start, end
+}
+
+// splitFilters returns the start offset of each filter applied to an
+// expression, ignoring `|` inside strings and brackets
+func splitFilters(builder *builder, start, end int) []int {
+	var filters []int
+	depth := 0
+	var quote byte
+
+	for i := start; i < end; i++ {
+		char := builder.content[i]
+
+		if quote != 0 {
+			if char == '\\' {
+				i++
+			} else if char == quote {
+				quote = 0
+			}
+
+			continue
+		}
+
+		switch char {
+		case '"', '\'':
+			quote = char
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case '|':
+			if depth == 0 {
+				filters = append(filters, i+1)
+			}
+		}
+	}
+
+	return filters
+}
+
+func filterName(filter string) string {
+	for i := 0; i < len(filter); i++ {
+		if !isWordChar(filter[i]) {
+			return filter[:i]
+		}
+	}
+
+	return filter
+}
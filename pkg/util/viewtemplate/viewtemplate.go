@@ -0,0 +1,212 @@
+// Package viewtemplate compiles server-side view templates (ERB, Jinja2 and
+// Blade) into code in their host language, so that the code they embed can be
+// scanned by the host language's engine. Positions in the compiled code can be
+// mapped back to the template.
+//
+// Output tags are compiled into the code the framework itself generates for
+// them, which tells escaped output apart from raw output:
+//
+//	ERB:    <%= x %>  -> @output_buffer.append=(x)
+//	        <%== x %> -> @output_buffer.safe_append=(x)
+//	Jinja2: {{ x }}   -> escape(x)
+//	        {{ x|safe }} (or inside `autoescape false`) -> Markup(x)
+//	Blade:  {{ $x }}  -> echo e($x);
+//	        {!! $x !!} -> echo $x;
+package viewtemplate
+
+import (
+	"bytes"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// enry languages of the supported templates
+const (
+	erbLanguage   = "HTML+ERB"
+	jinjaLanguage = "Jinja"
+	bladeLanguage = "Blade"
+	htmlLanguage  = "HTML"
+)
+
+// Template is the host language code compiled from a template
+type Template struct {
+	Language    string
+	Source      []byte
+	segments    []segment
+	sourceLines []int
+	fileLines   []int
+}
+
+// segment is a part of the compiled code copied as is from the template
+type segment struct {
+	sourceOffset int
+	fileOffset   int
+	length       int
+}
+
+// HostLanguage returns the id of the language embedded in the template with
+// the given enry language, or an empty string if the file isn't a supported
+// template. HTML files are treated as Jinja2 (or Django) templates when they
+// live in a `templates` directory.
+func HostLanguage(enryLanguage string, path string) string {
+	switch enryLanguage {
+	case erbLanguage:
+		return "ruby"
+	case jinjaLanguage:
+		return "python"
+	case bladeLanguage:
+		return "php"
+	case htmlLanguage:
+		directories := strings.Split(filepath.ToSlash(filepath.Dir(path)), "/")
+		if slices.Contains(directories, "templates") {
+			return "python"
+		}
+	}
+
+	return ""
+}
+
+// Compile compiles the template into code in the given host language
+func Compile(hostLanguage string, content []byte) *Template {
+	builder := &builder{content: content}
+
+	switch hostLanguage {
+	case "ruby":
+		compileERB(builder)
+	case "python":
+		compileJinja(builder)
+	case "php":
+		compileBlade(builder)
+	}
+
+	return &Template{
+		Language:    hostLanguage,
+		Source:      builder.source.Bytes(),
+		segments:    builder.segments,
+		sourceLines: lineOffsets(builder.source.Bytes()),
+		fileLines:   lineOffsets(content),
+	}
+}
+
+// Position converts a 1-based line and column in the compiled code into the
+// corresponding position in the template. Positions in generated code map to
+// the start of the next code copied from the template on the same line (eg.
+// for `echo ` in `echo $x;`), or else to the end of the previous one.
+func (template *Template) Position(sourceLine, sourceColumn int) (int, int) {
+	if len(template.segments) == 0 || sourceLine < 1 || sourceLine > len(template.sourceLines) {
+		return sourceLine, sourceColumn
+	}
+
+	offset := template.sourceLines[sourceLine-1] + sourceColumn - 1
+
+	index := sort.Search(len(template.segments), func(i int) bool {
+		return template.segments[i].sourceOffset > offset
+	}) - 1
+
+	var fileOffset int
+	if index >= 0 && offset < template.segments[index].sourceOffset+template.segments[index].length {
+		segment := template.segments[index]
+		fileOffset = segment.fileOffset + offset - segment.sourceOffset
+	} else if next := index + 1; next < len(template.segments) &&
+		(index < 0 || !bytes.Contains(template.Source[offset:template.segments[next].sourceOffset], []byte("\n"))) {
+		fileOffset = template.segments[next].fileOffset
+	} else {
+		segment := template.segments[index]
+		fileOffset = segment.fileOffset + segment.length
+	}
+
+	line := sort.Search(len(template.fileLines), func(i int) bool {
+		return template.fileLines[i] > fileOffset
+	})
+
+	return line, fileOffset - template.fileLines[line-1] + 1
+}
+
+type builder struct {
+	content  []byte
+	source   bytes.Buffer
+	segments []segment
+}
+
+// write adds generated code
+func (builder *builder) write(code string) {
+	builder.source.WriteString(code)
+}
+
+// copy adds the code between the given template offsets, ignoring
+// surrounding whitespace
+func (builder *builder) copy(start, end int) {
+	for start < end && isSpace(builder.content[start]) {
+		start++
+	}
+
+	for end > start && isSpace(builder.content[end-1]) {
+		end--
+	}
+
+	if start == end {
+		return
+	}
+
+	builder.segments = append(builder.segments, segment{
+		sourceOffset: builder.source.Len(),
+		fileOffset:   start,
+		length:       end - start,
+	})
+	builder.source.Write(builder.content[start:end])
+}
+
+// text returns the template content between the given offsets, without
+// surrounding whitespace
+func (builder *builder) text(start, end int) string {
+	return strings.TrimSpace(string(builder.content[start:end]))
+}
+
+// index returns the offset of the first occurrence of value at or after the
+// given offset, or -1
+func (builder *builder) index(offset int, value string) int {
+	index := bytes.Index(builder.content[offset:], []byte(value))
+	if index == -1 {
+		return -1
+	}
+
+	return offset + index
+}
+
+func (builder *builder) hasPrefix(offset int, prefix string) bool {
+	return bytes.HasPrefix(builder.content[offset:], []byte(prefix))
+}
+
+// trimmedStart returns the offset of the first non-whitespace character at or
+// after the given offset and before end
+func (builder *builder) trimmedStart(start, end int) int {
+	for start < end && isSpace(builder.content[start]) {
+		start++
+	}
+
+	return start
+}
+
+func lineOffsets(content []byte) []int {
+	offsets := []int{0}
+	for i, char := range content {
+		if char == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+
+	return offsets
+}
+
+func isSpace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\n' || char == '\r'
+}
+
+func isWordChar(char byte) bool {
+	return char == '_' ||
+		(char >= 'a' && char <= 'z') ||
+		(char >= 'A' && char <= 'Z') ||
+		(char >= '0' && char <= '9')
+}
@@ -0,0 +1,109 @@
+package viewtemplate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/pkg/util/viewtemplate"
+)
+
+func TestHostLanguage(t *testing.T) {
+	assert.Equal(t, "ruby", viewtemplate.HostLanguage("HTML+ERB", "app/views/users/show.html.erb"))
+	assert.Equal(t, "python", viewtemplate.HostLanguage("Jinja", "mail/welcome.j2"))
+	assert.Equal(t, "python", viewtemplate.HostLanguage("HTML", "app/templates/users/show.html"))
+	assert.Equal(t, "php", viewtemplate.HostLanguage("Blade", "resources/views/show.blade.php"))
+	assert.Equal(t, "", viewtemplate.HostLanguage("HTML", "public/index.html"))
+	assert.Equal(t, "", viewtemplate.HostLanguage("Ruby", "app/models/user.rb"))
+}
+
+func TestCompileERB(t *testing.T) {
+	template := viewtemplate.Compile("ruby", []byte(`<h1><%= @user.name %></h1>
+<%# comment %>
+<% if @user.admin? -%>
+  <p><%== @user.email %></p>
+<% end %>
+<%= form_with model: @user do |form| %><% end %>
+<%% literal %>
+`))
+
+	assert.Equal(t, `@output_buffer.append=(@user.name)
+if @user.admin?
+@output_buffer.safe_append=(@user.email)
+end
+@output_buffer.append= form_with model: @user do |form|
+end
+`, string(template.Source))
+
+	line, column := template.Position(3, 1)
+	assert.Equal(t, 4, line)
+	assert.Equal(t, 11, column)
+
+	line, column = template.Position(3, 40)
+	assert.Equal(t, 4, line)
+	assert.Equal(t, 22, column)
+}
+
+func TestCompileJinja(t *testing.T) {
+	template := viewtemplate.Compile("python", []byte(`<h1>{{ user.name|title }}</h1>
+{% if user.admin -%}
+  <p>{{ user.email | safe }}</p>
+{% elif user.staff %}
+{% else %}
+  {% set label = "{{ user }}" %}
+{% endif %}
+{% for address in user.addresses %}{{ address.street }}{% endfor %}
+{% autoescape false %}{{ user.phone_number }}{% endautoescape %}
+{# {{ user.ignored }} #}
+{% raw %}{{ user.ignored }}{% endraw %}
+`))
+
+	assert.Equal(t, `escape(user.name)
+if user.admin:
+    Markup(user.email)
+elif user.staff:
+    pass
+else:
+    label = "{{ user }}"
+for address in user.addresses:
+    escape(address.street)
+Markup(user.phone_number)
+`, string(template.Source))
+
+	line, column := template.Position(3, 5)
+	assert.Equal(t, 3, line)
+	assert.Equal(t, 9, column)
+}
+
+func TestCompileBlade(t *testing.T) {
+	template := viewtemplate.Compile("php", []byte(`<h1>{{ $user->name }}</h1>
+@if ($user->admin)
+  <p>{!! $user->email !!}</p>
+@endif
+@foreach ($user->addresses as $address) {{ $address->street }} @endforeach
+@php($count = 1)
+<script>var user = @json($user->phone_number);</script>
+{{-- {!! $user->ignored !!} --}}
+@{{ ignored }} contact@example.com
+`))
+
+	assert.Equal(t, `<?php
+echo e($user->name);
+if ($user->admin):
+echo $user->email;
+endif;
+foreach ($user->addresses as $address):
+echo e($address->street);
+endforeach;
+$count = 1;
+echo json_encode($user->phone_number);
+`, string(template.Source))
+
+	line, column := template.Position(4, 1)
+	assert.Equal(t, 3, line)
+	assert.Equal(t, 10, column)
+
+	line, column = template.Position(4, 18)
+	assert.Equal(t, 3, line)
+	assert.Equal(t, 22, column)
+}
@@ -28,7 +28,13 @@ type BinaryVersionMeta struct {
 }
 
 func GetScanVersionMeta(ctx context.Context, options flagtypes.Options, languages []string) (meta *VersionMeta, err error) {
-	if options.RuleOptions.DisableDefaultRules && options.GeneralOptions.DisableVersionCheck {
+	// the default rules are loaded from the bundle instead
+	if options.RuleOptions.RulesBundle != "" {
+		languages = nil
+	}
+
+	if (options.RuleOptions.DisableDefaultRules || options.RuleOptions.RulesBundle != "") &&
+		options.GeneralOptions.DisableVersionCheck {
 		log.Debug().Msg("skipping version API call as check and default rules both disabled")
 
 		return &VersionMeta{
@@ -53,6 +53,8 @@ var (
 		flag.RuleFlagGroup,
 		flag.ScanFlagGroup,
 		flag.WorkerFlagGroup,
+		// after the scan flags, so that --language is documented from the scan flag
+		flag.RulesPullFlagGroup,
 	}
 	boundFlags = set.New[*flagtypes.Flag]()
 )